@@ -62,7 +62,6 @@ require (
 	golang.org/x/text v0.23.0 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	gopkg.in/warnings.v0 v0.1.2 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 	gorm.io/driver/mysql v1.5.6 // indirect
 )
 
@@ -71,6 +70,7 @@ require (
 	github.com/mattn/go-sqlite3 v1.14.31 // indirect
 	github.com/shirou/gopsutil/v4 v4.25.7
 	golang.org/x/sys v0.35.0
+	gopkg.in/yaml.v3 v3.0.1
 	gorm.io/datatypes v1.2.6
 	gorm.io/gorm v1.30.1
 )
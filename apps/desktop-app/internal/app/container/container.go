@@ -8,14 +8,12 @@ import (
 	"github.com/oLenador/mulltbost/internal/core/domain/services/booster"
 	"github.com/oLenador/mulltbost/internal/core/domain/services/i18n"
 	"github.com/oLenador/mulltbost/internal/core/domain/services/monitoring"
-	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/connection"
 	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/system"
 	"github.com/wailsapp/wails/v3/pkg/application"
 
-	boosterBase "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/base"
 	storage "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage"
-	models "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
 	repos "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/repositories"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/secrets"
 )
 
 type Container struct {
@@ -29,20 +27,23 @@ type Container struct {
 
 func NewContainer(appService *application.App) (*Container, error) {
 
-	db, err := storage.NewDB()
+	db, backupCipher, recovery, err := storage.NewDB(secrets.DefaultStores())
 	if err != nil {
 		fmt.Printf("error on open db: %v", err)
 		return nil, err
 	}
 
-	if err := storage.AutoMigrateModels(db, &models.BoosterRollbackState{}, &models.BoostOperation{}, &models.BoostActivationState{}); err != nil {
-		fmt.Printf("automigrate : %v", err)
-		return nil, err
-	}
-
-	boostActivationRepo := repos.NewBoostConfigRepository(db)
+	// Os boosters só são conhecidos dentro do booster.NewService, que faz a sincronização
+	boostActivationRepo := repos.NewBoostConfigRepository(db, nil)
 	rollbackRepo := repos.NewRollbackRepo(db)
+	rollbackRepo.SetCipher(backupCipher)
 	boostOperationsRepo := repos.NewBoostOperationsRepo(db)
+	operationJournalRepo := repos.NewOperationJournalRepo(db)
+	operationJournalRepo.SetCipher(backupCipher)
+	snapshotRepo := repos.NewSnapshotRepo(db)
+	snapshotRepo.SetCipher(backupCipher)
+	boosterExpiryRepo := repos.NewBoosterExpiryRepo(db)
+	boosterSettingsRepo := repos.NewBoosterSettingsRepo(db)
 
 	systemMetricsRepo := system.NewMetricsRepository()
 	metricsService := monitoring.NewService(systemMetricsRepo)
@@ -55,10 +56,20 @@ func NewContainer(appService *application.App) (*Container, error) {
 		boostOperationsRepo, 
 		appService.Event, 
 		boostActivationRepo,
+		operationJournalRepo,
+		snapshotRepo,
+		boosterExpiryRepo,
+		boosterSettingsRepo,
 	)
 	if err != nil {
 		return nil, err
 	} 
+	boosterService.SetDatabaseRecovery(recovery)
+
+	backupScheduler := storage.NewBackupScheduler(db, storage.NewBackups(storage.BackupDir(), storage.DefaultBackupsKept), storage.DefaultBackupInterval)
+	backupScheduler.Start()
+	appService.OnShutdown(backupScheduler.Stop)
+
 	container := &Container{
 		BoosterService: boosterService,
 		MetricsService: metricsService,
@@ -67,22 +78,3 @@ func NewContainer(appService *application.App) (*Container, error) {
 
 	return container, nil
 }
-
-func (c *Container) initAllBoosts() error {
-
-	ps := boosterBase.GetPlatformServices()
-	deps := inbound.NewExecutorDepServices(ps)
-
-	loaders := map[string][]inbound.BoosterUseCase{
-		"connection": connection.GetAllPlugins(deps),
-	}
-
-	for _, boostArray := range loaders {
-		for _, booster := range boostArray {
-			if err := c.BoosterService.RegisterBooster(booster); err != nil {
-				return err
-			}
-		}
-	}
-	return nil
-}
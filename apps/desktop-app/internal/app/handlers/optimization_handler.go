@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"time"
 
 	"github.com/oLenador/mulltbost/internal/app/container"
 	"github.com/oLenador/mulltbost/internal/core/domain/dto"
@@ -41,11 +42,14 @@ func (h *BoosterHandler) GetExecutionQueueState() *entities.QueueState {
 }
 
 
-func (h *BoosterHandler) InitBoosterApply(id string) (entities.InitResult, error) {
-    return h.container.BoosterService.InitBoosterApply(h.ctx, id)
+func (h *BoosterHandler) InitBoosterApply(id string, opts ...entities.ApplyOptions) (entities.InitResult, error) {
+    return h.container.BoosterService.InitBoosterApply(h.ctx, id, opts...)
 }
-func (h *BoosterHandler) InitBoosterApplyBatch(ids []string) (entities.InitResult, error) {
-    return h.container.BoosterService.InitBoosterApplyBatch(h.ctx, ids)
+func (h *BoosterHandler) InitBoosterApplyBatch(ids []string, opts ...entities.ApplyOptions) (entities.InitResult, error) {
+    return h.container.BoosterService.InitBoosterApplyBatch(h.ctx, ids, opts...)
+}
+func (h *BoosterHandler) InitBoosterApplyTransaction(ids []string) (entities.InitResult, error) {
+	return h.container.BoosterService.InitBoosterApplyTransaction(h.ctx, ids)
 }
 func (h *BoosterHandler) InitRevertBooster(id string) (entities.InitResult, error) {
 	return h.container.BoosterService.InitRevertBooster(h.ctx, id)
@@ -54,3 +58,71 @@ func (h *BoosterHandler) InitRevertBoosterBatch(ids []string) (entities.InitResu
 	return h.container.BoosterService.InitRevertBoosterBatch(h.ctx, ids)
 }
 
+
+func (h *BoosterHandler) PlanBooster(id string) (*entities.BoostPlan, error) {
+	return h.container.BoosterService.PlanBooster(h.ctx, id)
+}
+func (h *BoosterHandler) PlanBoosterBatch(ids []string) (*entities.BoostBatchPlan, error) {
+	return h.container.BoosterService.PlanBoosterBatch(h.ctx, ids)
+}
+
+
+func (h *BoosterHandler) CheckBoosterDrift() ([]entities.BoostVerifyResult, error) {
+	return h.container.BoosterService.CheckBoosterDrift(h.ctx)
+}
+
+func (h *BoosterHandler) GetReconciliationReport() *entities.ReconciliationReport {
+	return h.container.BoosterService.GetReconciliationReport(h.ctx)
+}
+func (h *BoosterHandler) GetDatabaseRecovery() *entities.DatabaseRecovery {
+	return h.container.BoosterService.GetDatabaseRecovery(h.ctx)
+}
+
+func (h *BoosterHandler) GetOperation(operationID string) (*entities.OperationDetails, error) {
+	return h.container.BoosterService.GetOperation(h.ctx, operationID)
+}
+func (h *BoosterHandler) GetBatch(batchID string) (*entities.BatchDetails, error) {
+	return h.container.BoosterService.GetBatch(h.ctx, batchID)
+}
+func (h *BoosterHandler) GetOperationStats(filter entities.OperationStatsFilter) (*entities.OperationStats, error) {
+	return h.container.BoosterService.GetOperationStats(h.ctx, filter)
+}
+func (h *BoosterHandler) CancelOperation(operationID string) error {
+	return h.container.BoosterService.CancelOperation(h.ctx, operationID)
+}
+func (h *BoosterHandler) CancelBatch(batchID string) error {
+	return h.container.BoosterService.CancelBatch(h.ctx, batchID)
+}
+func (h *BoosterHandler) CreateSnapshot(name string) (*entities.Snapshot, error) {
+	return h.container.BoosterService.CreateSnapshot(h.ctx, name)
+}
+func (h *BoosterHandler) ListSnapshots() ([]entities.Snapshot, error) {
+	return h.container.BoosterService.ListSnapshots(h.ctx)
+}
+func (h *BoosterHandler) GetSnapshot(snapshotID string) (*entities.Snapshot, error) {
+	return h.container.BoosterService.GetSnapshot(h.ctx, snapshotID)
+}
+func (h *BoosterHandler) DeleteSnapshot(snapshotID string) error {
+	return h.container.BoosterService.DeleteSnapshot(h.ctx, snapshotID)
+}
+func (h *BoosterHandler) RestoreSnapshot(snapshotID string) (*entities.SnapshotRestore, error) {
+	return h.container.BoosterService.RestoreSnapshot(h.ctx, snapshotID)
+}
+func (h *BoosterHandler) ExtendBoosterExpiry(boosterID string, extra time.Duration) (*entities.BoosterExpiry, error) {
+	return h.container.BoosterService.ExtendBoosterExpiry(h.ctx, boosterID, extra)
+}
+func (h *BoosterHandler) CancelBoosterExpiry(boosterID string) error {
+	return h.container.BoosterService.CancelBoosterExpiry(h.ctx, boosterID)
+}
+func (h *BoosterHandler) GetBoosterExpiries() ([]entities.BoosterExpiry, error) {
+	return h.container.BoosterService.GetBoosterExpiries(h.ctx)
+}
+func (h *BoosterHandler) GetBoosterParams(boosterID string) (*entities.BoosterSettings, error) {
+	return h.container.BoosterService.GetBoosterParams(h.ctx, boosterID)
+}
+func (h *BoosterHandler) SetBoosterParams(boosterID string, values map[string]interface{}) (*entities.BoosterSettings, error) {
+	return h.container.BoosterService.SetBoosterParams(h.ctx, boosterID, values)
+}
+func (h *BoosterHandler) HealthCheck() *entities.HealthStatus {
+	return h.container.BoosterService.HealthCheck(h.ctx)
+}
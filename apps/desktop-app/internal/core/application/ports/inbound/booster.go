@@ -2,6 +2,7 @@ package inbound
 
 import (
 	"context"
+	"time"
 
 	"github.com/oLenador/mulltbost/internal/core/domain/dto"
 	"github.com/oLenador/mulltbost/internal/core/domain/entities"
@@ -10,12 +11,14 @@ import (
 
 type BoosterUseCase interface {
 	Execute(ctx context.Context) (*entities.BoostApplyResult, error)
+	Plan(ctx context.Context) (*entities.BoostPlan, error)
+	Verify(ctx context.Context) (*entities.BoostVerifyResult, error)
 	Validate(ctx context.Context) error
 	CanApply(ctx context.Context) bool
 	CanRevert(ctx context.Context) bool
 	GetEntity() entities.Booster
 	GetEntityDto(lang i18n.Language) dto.BoosterDto
-	Revert(ctx context.Context) (*entities.BoostRevertResult, error)
+	Revert(ctx context.Context, backupData entities.BackupData) (*entities.BoostRevertResult, error)
 }
 
 type BoosterService interface {
@@ -25,10 +28,32 @@ type BoosterService interface {
 	GetAvailableBoosters(ctx context.Context, lang i18n.Language) []dto.GetBoosterDto
 	GetBoostersByCategory(ctx context.Context, category entities.BoosterCategory, lang i18n.Language) []dto.GetBoosterDto
 	GetExecutionQueueState(ctx context.Context) *entities.QueueState
-	InitBoosterApply(ctx context.Context, id string) (entities.InitResult, error)
-	InitBoosterApplyBatch(ctx context.Context, ids []string) (entities.InitResult, error)
+	InitBoosterApply(ctx context.Context, id string, opts ...entities.ApplyOptions) (entities.InitResult, error)
+	InitBoosterApplyBatch(ctx context.Context, ids []string, opts ...entities.ApplyOptions) (entities.InitResult, error)
+	InitBoosterApplyTransaction(ctx context.Context, ids []string) (entities.InitResult, error)
 	InitRevertBooster(ctx context.Context, id string) (entities.InitResult, error)
 	InitRevertBoosterBatch(ctx context.Context, ids []string) (entities.InitResult, error)
+	PlanBooster(ctx context.Context, id string) (*entities.BoostPlan, error)
+	PlanBoosterBatch(ctx context.Context, ids []string) (*entities.BoostBatchPlan, error)
+	CheckBoosterDrift(ctx context.Context) ([]entities.BoostVerifyResult, error)
+	GetReconciliationReport(ctx context.Context) *entities.ReconciliationReport
+	GetDatabaseRecovery(ctx context.Context) *entities.DatabaseRecovery
+	GetOperation(ctx context.Context, operationID string) (*entities.OperationDetails, error)
+	GetBatch(ctx context.Context, batchID string) (*entities.BatchDetails, error)
+	GetOperationStats(ctx context.Context, filter entities.OperationStatsFilter) (*entities.OperationStats, error)
+	CancelOperation(ctx context.Context, operationID string) error
+	CancelBatch(ctx context.Context, batchID string) error
+	CreateSnapshot(ctx context.Context, name string) (*entities.Snapshot, error)
+	ListSnapshots(ctx context.Context) ([]entities.Snapshot, error)
+	GetSnapshot(ctx context.Context, snapshotID string) (*entities.Snapshot, error)
+	DeleteSnapshot(ctx context.Context, snapshotID string) error
+	RestoreSnapshot(ctx context.Context, snapshotID string) (*entities.SnapshotRestore, error)
+	ExtendBoosterExpiry(ctx context.Context, boosterID string, extra time.Duration) (*entities.BoosterExpiry, error)
+	CancelBoosterExpiry(ctx context.Context, boosterID string) error
+	GetBoosterExpiries(ctx context.Context) ([]entities.BoosterExpiry, error)
+	GetBoosterParams(ctx context.Context, boosterID string) (*entities.BoosterSettings, error)
+	SetBoosterParams(ctx context.Context, boosterID string, values map[string]interface{}) (*entities.BoosterSettings, error)
+	HealthCheck(ctx context.Context) *entities.HealthStatus
 }
 
 type MonitoringService interface {
@@ -46,6 +71,9 @@ type SystemInfoService interface {
 
 type PlatformExecutor interface {
 	Execute(ctx context.Context, boosterID string) (*entities.BoostApplyResult, error)
+	Plan(ctx context.Context, boosterID string) ([]entities.PlannedChange, error)
+	// Verify retorna as alterações do booster que não estão mais em efeito
+	Verify(ctx context.Context, boosterID string) ([]entities.PlannedChange, error)
 	Validate(ctx context.Context) error
 	CanExecute(ctx context.Context) bool
 	Revert(ctx context.Context, backupData entities.BackupData) (*entities.BoostRevertResult, error)
@@ -2,16 +2,52 @@ package outbound
 
 import (
     "context"
+    "errors"
+    "time"
+
     "github.com/oLenador/mulltbost/internal/core/domain/entities"
 )
 
+var ErrActivationStateNotFound = errors.New("boost activation state not found")
+
+// BoosterStateRepository guarda o estado de rollback de cada booster; GetByID
+// retorna nil sem erro quando o booster nunca foi aplicado
 type BoosterStateRepository interface {
     Save(ctx context.Context, state *entities.BoosterRollbackState) error
     GetByID(ctx context.Context, id string) (*entities.BoosterRollbackState, error)
-    GetAll(ctx context.Context) ([]*entities.BoosterRollbackState, error)
+    GetAll(ctx context.Context) ([]entities.BoosterRollbackState, error)
     Delete(ctx context.Context, id string) error
 }
 
+// BoosterActivationRepository guarda o que o usuário ativou. GetBoostState
+// retorna ErrActivationStateNotFound para um booster que nunca foi sincronizado.
+type BoosterActivationRepository interface {
+    // SyncWithAvailableBoosts cria o estado dos boosters novos e marca como
+    // obsoletos os que não existem mais
+    SyncWithAvailableBoosts(ctx context.Context, boosts []entities.Booster) error
+    GetBoostState(ctx context.Context, boosterID string) (*entities.BoosterActivationState, error)
+    SetAppliedState(ctx context.Context, boosterID string, applied bool, errMsg string) error
+}
+
+// OperationHistoryRepository guarda o histórico de operações; GetByID retorna
+// nil sem erro quando a operação não existe
+type OperationHistoryRepository interface {
+    Save(ctx context.Context, operation *entities.BoostOperation) error
+    GetByID(ctx context.Context, id string) (*entities.BoostOperation, error)
+    GetByBatchID(ctx context.Context, batchID string) ([]entities.BoostOperation, error)
+    Query(ctx context.Context, filter entities.OperationStatsFilter) ([]entities.BoostOperation, error)
+    // CloseUnfinished encerra as operações enfileiradas ou em execução e
+    // retorna quantas foram alteradas
+    CloseUnfinished(ctx context.Context, status entities.OperationStatus, errMsg string, completedAt time.Time) (int64, error)
+    GetByBoosterID(ctx context.Context, boosterID string) (*[]entities.BoostOperation, error)
+    GetAll(ctx context.Context) (*[]entities.BoostOperation, error)
+    GetByStatus(ctx context.Context, status entities.OperationStatus) (*[]entities.BoostOperation, error)
+    GetByType(ctx context.Context, operationType entities.BoosterOperationType) (*[]entities.BoostOperation, error)
+    GetByTimeRange(ctx context.Context, startTime, endTime time.Time) (*[]entities.BoostOperation, error)
+    GetRecent(ctx context.Context, limit int) (*[]entities.BoostOperation, error)
+    DeleteOlderThan(ctx context.Context, olderThan time.Time) error
+}
+
 type SystemMetricsRepository interface {
     GetCPUMetrics(ctx context.Context) (*entities.CPUMetrics, error)
     GetMemoryMetrics(ctx context.Context) (*entities.MemoryMetrics, error)
@@ -23,6 +23,14 @@ type GetBoosterDto struct {
 	AppliedAt    *time.Time
 	RevertedAt   *time.Time
 	Tags         []string
+	Params       entities.ParamSchema
+	ParamValues  entities.BoosterParams
+	// Available é falso para boosters que não podem ser usados nesta máquina
+	Available         bool
+	UnavailableReason entities.UnavailableReason
+	// UnsupportedReasons são os requisitos do booster que a máquina não atende
+	UnsupportedReasons []entities.UnsupportedReason
+	RequiresReboot     bool
 }
 
 
@@ -39,4 +47,5 @@ type BoosterDto struct {
 	RiskLevel    entities.RiskLevel
 	Version      string
 	Tags         []string
+	Params       entities.ParamSchema
 }
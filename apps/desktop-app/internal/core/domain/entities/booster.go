@@ -46,6 +46,7 @@ const (
 	ExecutionReverting  BoosterExecutionStatus = "reverting"
 	ExecutionPending  BoosterExecutionStatus = "pending"
 	ExecutionReverted  BoosterExecutionStatus = "reverted"
+	ExecutionDrifted   BoosterExecutionStatus = "drifted"
 
 	ExecutionInactive  BoosterExecutionStatus = "Inactive"
 )
@@ -59,6 +60,7 @@ const (
 // Operation Status - Estados das operações
 const (
 	OperationPending    OperationStatus = "pending"
+	OperationQueued     OperationStatus = "queued"
 	OperationProcessing OperationStatus = "processing"
 	OperationCompleted  OperationStatus = "completed"
 	OperationFailed     OperationStatus = "failed"
@@ -104,6 +106,16 @@ type Booster struct {
 	RiskLevel      RiskLevel
 	Version        string
 	Tags           []string
+	DriftPolicy    DriftPolicy
+	// Timeout limita cada tentativa de execução; zero usa o padrão do pool
+	Timeout time.Duration
+	Retry   RetryPolicy
+	// Resources lista os recursos do sistema alterados pelo booster (FileResource, RegistryResource...)
+	Resources []string
+	// Params são os parâmetros que o usuário pode configurar; os valores chegam ao executor pelo contexto
+	Params ParamSchema
+	// Requirements são verificados uma vez por boot pelo probe de capacidades
+	Requirements Requirements
 }
 
 type BackupData map[string]interface{}
@@ -118,6 +130,20 @@ type BoosterRollbackState struct {
 	ErrorMsg   string
 }
 
+// BoosterActivationState é o estado de ativação escolhido pelo usuário, que o
+// reconciliador mantém em acordo com o BoosterRollbackState
+type BoosterActivationState struct {
+	ID           string
+	IsApplied    bool
+	AppliedAt    *time.Time
+	RevertedAt   *time.Time
+	Version      string
+	Status       BoosterExecutionStatus
+	ErrorMessage string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
 type BoostOperation struct {
 	ID         string
 	BoosterID  string
@@ -125,6 +151,17 @@ type BoostOperation struct {
 	AppliedAt  time.Time
 	RevertedAt time.Time
 	ErrorMsg   string
+	Attempts   int
+	BatchID    string
+	Status     OperationStatus
+	// Message é a mensagem de resultado reportada pelo executor
+	Message     string
+	QueuedAt    time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+	Duration    time.Duration
+	// Version é a versão do booster quando a operação foi pedida
+	Version string
 }
 
 
@@ -141,6 +178,9 @@ type QueueItem struct {
 	Operation   BoosterOperationType
 	OperationID string
 	SubmittedAt time.Time
+	BatchID     string
+	DependsOn   []string
+	Priority    OperationPriority
 	Context     context.Context
 	Cancel      context.CancelFunc
 }
\ No newline at end of file
@@ -0,0 +1,21 @@
+package entities
+
+// UnavailableReason explica por que um booster conhecido não pode ser usado nesta máquina
+type UnavailableReason string
+
+const (
+	// UnavailableUnsupportedPlatform indica que o booster não declara o sistema atual
+	UnavailableUnsupportedPlatform UnavailableReason = "unsupported_platform"
+	// UnavailableNotImplemented indica que o booster não tem executor para o sistema atual
+	UnavailableNotImplemented UnavailableReason = "not_implemented"
+)
+
+// SupportsPlatform informa se o booster declara a plataforma
+func (b Booster) SupportsPlatform(platform Platform) bool {
+	for _, p := range b.Platform {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
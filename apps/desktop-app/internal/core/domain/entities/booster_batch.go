@@ -0,0 +1,20 @@
+package entities
+
+type BatchOutcomeStatus string
+
+const (
+	BatchOutcomeSucceeded  BatchOutcomeStatus = "succeeded"
+	BatchOutcomeFailed     BatchOutcomeStatus = "failed"
+	BatchOutcomeSkipped    BatchOutcomeStatus = "skipped"
+	BatchOutcomeRejected   BatchOutcomeStatus = "rejected"
+	BatchOutcomeRolledBack BatchOutcomeStatus = "rolled_back"
+)
+
+// BatchBoosterOutcome é o resultado de um booster dentro de um lote
+type BatchBoosterOutcome struct {
+	BoosterID   string               `json:"boosterId"`
+	OperationID string               `json:"operationId,omitempty"`
+	Operation   BoosterOperationType `json:"operation"`
+	Status      BatchOutcomeStatus   `json:"status"`
+	Error       string               `json:"error,omitempty"`
+}
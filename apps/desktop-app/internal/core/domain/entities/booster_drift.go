@@ -0,0 +1,34 @@
+package entities
+
+import "time"
+
+// DriftPolicy define o que fazer quando um booster aplicado deixa de estar em efeito
+type DriftPolicy string
+
+const (
+	DriftPolicyNotify  DriftPolicy = "notify"
+	DriftPolicyReapply DriftPolicy = "reapply"
+	DriftPolicyIgnore  DriftPolicy = "ignore"
+)
+
+// BoostVerifyResult é o resultado da verificação de um booster aplicado.
+// Em Drifted, CurrentValue é o valor encontrado e NewValue o valor esperado.
+type BoostVerifyResult struct {
+	BoosterID          string
+	InEffect           bool
+	Drifted            []PlannedChange
+	Policy             DriftPolicy
+	ReapplyOperationID string
+	CheckedAt          time.Time
+}
+
+// DriftedChanges filtra as alterações cujo valor atual difere do esperado
+func DriftedChanges(changes []PlannedChange) []PlannedChange {
+	var drifted []PlannedChange
+	for _, change := range changes {
+		if change.CurrentValue != change.NewValue {
+			drifted = append(drifted, change)
+		}
+	}
+	return drifted
+}
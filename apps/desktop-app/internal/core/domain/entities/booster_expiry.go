@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// ApplyOptions são as opções opcionais de uma aplicação de booster
+type ApplyOptions struct {
+	// ExpiresIn reverte o booster automaticamente após a duração; zero mantém aplicado
+	ExpiresIn time.Duration `json:"expiresIn"`
+}
+
+// BoosterExpiry é o timer persistido que reverte um booster aplicado por tempo limitado
+type BoosterExpiry struct {
+	BoosterID string    `json:"boosterId"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	// WarnedAt indica quando o aviso de expiração próxima foi emitido
+	WarnedAt  *time.Time `json:"warnedAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// Remaining retorna quanto falta para o timer disparar (zero se já venceu)
+func (e BoosterExpiry) Remaining(now time.Time) time.Duration {
+	if remaining := e.ExpiresAt.Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
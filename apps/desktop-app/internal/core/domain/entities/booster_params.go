@@ -0,0 +1,277 @@
+package entities
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ParamType é o tipo de um parâmetro configurável de booster
+type ParamType string
+
+const (
+	ParamEnum   ParamType = "enum"
+	ParamInt    ParamType = "int"
+	ParamIPList ParamType = "ip_list"
+	ParamString ParamType = "string"
+)
+
+var ErrInvalidParam = errors.New("invalid booster parameter")
+
+// ParamSpec descreve um parâmetro do booster e as regras de validação do valor
+type ParamSpec struct {
+	Key            string      `json:"key"`
+	NameKey        string      `json:"nameKey"`
+	DescriptionKey string      `json:"descriptionKey,omitempty"`
+	Type           ParamType   `json:"type"`
+	Default        interface{} `json:"default"`
+	// Options são os valores aceitos por um enum
+	Options []string `json:"options,omitempty"`
+	// Min e Max limitam um int; Max zero deixa o intervalo sem limite superior
+	Min int `json:"min,omitempty"`
+	Max int `json:"max,omitempty"`
+	// MaxItems limita o tamanho de uma lista de IPs; MaxLength o de uma string
+	MaxItems  int `json:"maxItems,omitempty"`
+	MaxLength int `json:"maxLength,omitempty"`
+}
+
+// ParamSchema é o conjunto de parâmetros que um booster aceita
+type ParamSchema []ParamSpec
+
+// BoosterParams são os valores dos parâmetros de um booster, já normalizados:
+// string para enum e string, int para int e []string para lista de IPs
+type BoosterParams map[string]interface{}
+
+func (s ParamSchema) spec(key string) (ParamSpec, bool) {
+	for _, spec := range s {
+		if spec.Key == key {
+			return spec, true
+		}
+	}
+	return ParamSpec{}, false
+}
+
+// Defaults retorna os valores padrão de todos os parâmetros
+func (s ParamSchema) Defaults() BoosterParams {
+	params := make(BoosterParams, len(s))
+	for _, spec := range s {
+		value, err := spec.Normalize(spec.Default)
+		if err != nil {
+			continue
+		}
+		params[spec.Key] = value
+	}
+	return params
+}
+
+// Validate valida os valores informados e completa os ausentes com os padrões.
+// Chaves desconhecidas e valores inválidos são rejeitados.
+func (s ParamSchema) Validate(values map[string]interface{}) (BoosterParams, error) {
+	params := s.Defaults()
+	var errs []error
+	for key, value := range values {
+		spec, ok := s.spec(key)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%w: unknown parameter %q", ErrInvalidParam, key))
+			continue
+		}
+		normalized, err := spec.Normalize(value)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		params[key] = normalized
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return params, nil
+}
+
+// Resolve aplica os valores salvos sobre os padrões, ignorando os que não valem
+// mais para o schema atual (ex.: parâmetro removido numa nova versão do booster)
+func (s ParamSchema) Resolve(values map[string]interface{}) BoosterParams {
+	params := s.Defaults()
+	for key, value := range values {
+		spec, ok := s.spec(key)
+		if !ok {
+			continue
+		}
+		if normalized, err := spec.Normalize(value); err == nil {
+			params[key] = normalized
+		}
+	}
+	return params
+}
+
+// Normalize valida um valor do parâmetro e o converte para o tipo normalizado.
+// Valores lidos de JSON (float64, json.Number, []interface{}) são aceitos.
+func (p ParamSpec) Normalize(value interface{}) (interface{}, error) {
+	switch p.Type {
+	case ParamEnum:
+		option, ok := value.(string)
+		if !ok {
+			return nil, p.invalid("expected a string, got %T", value)
+		}
+		for _, allowed := range p.Options {
+			if option == allowed {
+				return option, nil
+			}
+		}
+		return nil, p.invalid("%q is not one of %s", option, strings.Join(p.Options, ", "))
+
+	case ParamInt:
+		number, err := toInt(value)
+		if err != nil {
+			return nil, p.invalid("%v", err)
+		}
+		if number < p.Min || (p.Max != 0 && number > p.Max) {
+			return nil, p.invalid("%d is out of range [%d, %d]", number, p.Min, p.Max)
+		}
+		return number, nil
+
+	case ParamIPList:
+		items, err := toStringList(value)
+		if err != nil {
+			return nil, p.invalid("%v", err)
+		}
+		if len(items) == 0 {
+			return nil, p.invalid("at least one IP address is required")
+		}
+		if p.MaxItems > 0 && len(items) > p.MaxItems {
+			return nil, p.invalid("at most %d IP addresses are allowed", p.MaxItems)
+		}
+		ips := make([]string, len(items))
+		for i, item := range items {
+			ip := net.ParseIP(strings.TrimSpace(item))
+			if ip == nil {
+				return nil, p.invalid("%q is not a valid IP address", item)
+			}
+			ips[i] = ip.String()
+		}
+		return ips, nil
+
+	case ParamString:
+		text, ok := value.(string)
+		if !ok {
+			return nil, p.invalid("expected a string, got %T", value)
+		}
+		if p.MaxLength > 0 && len(text) > p.MaxLength {
+			return nil, p.invalid("longer than %d characters", p.MaxLength)
+		}
+		return text, nil
+
+	default:
+		return nil, p.invalid("unsupported parameter type %q", p.Type)
+	}
+}
+
+func (p ParamSpec) invalid(format string, args ...interface{}) error {
+	return fmt.Errorf("%w %q: %s", ErrInvalidParam, p.Key, fmt.Sprintf(format, args...))
+}
+
+func toInt(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int32:
+		return int(v), nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("%v is not an integer", v)
+		}
+		return int(v), nil
+	case json.Number:
+		number, err := strconv.Atoi(v.String())
+		if err != nil {
+			return 0, fmt.Errorf("%s is not an integer", v)
+		}
+		return number, nil
+	case string:
+		number, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return 0, fmt.Errorf("%q is not an integer", v)
+		}
+		return number, nil
+	default:
+		return 0, fmt.Errorf("expected an integer, got %T", value)
+	}
+}
+
+func toStringList(value interface{}) ([]string, error) {
+	switch v := value.(type) {
+	case []string:
+		return v, nil
+	case []interface{}:
+		items := make([]string, len(v))
+		for i, item := range v {
+			text, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of strings, got %T", item)
+			}
+			items[i] = text
+		}
+		return items, nil
+	case string:
+		return strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ' ' }), nil
+	default:
+		return nil, fmt.Errorf("expected a list, got %T", value)
+	}
+}
+
+// String retorna o valor de um parâmetro enum ou string
+func (p BoosterParams) String(key string) string {
+	value, _ := p[key].(string)
+	return value
+}
+
+// Int retorna o valor de um parâmetro int
+func (p BoosterParams) Int(key string) int {
+	value, _ := p[key].(int)
+	return value
+}
+
+// StringList retorna o valor de um parâmetro lista de IPs
+func (p BoosterParams) StringList(key string) []string {
+	value, _ := p[key].([]string)
+	return value
+}
+
+// Equal compara dois conjuntos de valores normalizados
+func (p BoosterParams) Equal(other BoosterParams) bool {
+	return reflect.DeepEqual(p, other)
+}
+
+type paramsKey struct{}
+
+// WithBoosterParams associa ao contexto os valores dos parâmetros da execução
+func WithBoosterParams(ctx context.Context, params BoosterParams) context.Context {
+	return context.WithValue(ctx, paramsKey{}, params)
+}
+
+// ParamsFromContext retorna os valores que o executor deve usar; sem valores no
+// contexto, valem os padrões do schema
+func ParamsFromContext(ctx context.Context, schema ParamSchema) BoosterParams {
+	if params, ok := ctx.Value(paramsKey{}).(BoosterParams); ok && params != nil {
+		return schema.Resolve(params)
+	}
+	return schema.Defaults()
+}
+
+// BoosterSettings são os valores configurados de um booster
+type BoosterSettings struct {
+	BoosterID string        `json:"boosterId"`
+	Schema    ParamSchema   `json:"schema"`
+	Params    BoosterParams `json:"params"`
+	// ReapplyOperationID é a reaplicação enfileirada porque os valores mudaram
+	// com o booster aplicado
+	ReapplyOperationID string `json:"reapplyOperationId,omitempty"`
+}
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// PlannedChange descreve uma alteração que o booster pretende fazer no sistema
+type PlannedChange struct {
+	Resource     string `json:"resource"`
+	CurrentValue string `json:"currentValue"`
+	NewValue     string `json:"newValue"`
+	Reversible   bool   `json:"reversible"`
+}
+
+// BoostPlan é a prévia (dry-run) de uma operação de booster
+type BoostPlan struct {
+	BoosterID   string               `json:"boosterId"`
+	Operation   BoosterOperationType `json:"operation"`
+	Requested   bool                 `json:"requested"`
+	Changes     []PlannedChange      `json:"changes"`
+	GeneratedAt time.Time            `json:"generatedAt"`
+}
+
+// BoostBatchPlan é a prévia de um lote, na ordem em que seria executado.
+// Rejected traz a mensagem de erro de cada booster que ficou fora do lote.
+type BoostBatchPlan struct {
+	Plans    []BoostPlan       `json:"plans"`
+	Rejected map[string]string `json:"rejected,omitempty"`
+}
+
+// NewPlannedChange formata os valores atuais e novos; nil indica valor não definido
+func NewPlannedChange(resource string, currentValue, newValue interface{}, reversible bool) PlannedChange {
+	return PlannedChange{
+		Resource:     resource,
+		CurrentValue: formatPlanValue(currentValue),
+		NewValue:     formatPlanValue(newValue),
+		Reversible:   reversible,
+	}
+}
+
+func formatPlanValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,46 @@
+package entities
+
+import "time"
+
+// LiveState é o estado do booster reportado pelo executor no sistema em execução
+type LiveState string
+
+const (
+	LiveInEffect    LiveState = "in_effect"
+	LiveNotInEffect LiveState = "not_in_effect"
+	LiveUnknown     LiveState = "unknown"
+)
+
+// ReconciliationAction é o reparo aplicado pela reconciliação de inicialização
+type ReconciliationAction string
+
+const (
+	ReconcileNone            ReconciliationAction = "none"
+	ReconcileActivate        ReconciliationAction = "activate"
+	ReconcileActivateDrifted ReconciliationAction = "activate_drifted"
+	ReconcileDeactivate      ReconciliationAction = "deactivate"
+	ReconcileMarkDrifted     ReconciliationAction = "mark_drifted"
+	ReconcileManualReview    ReconciliationAction = "manual_review"
+)
+
+// BoosterReconciliation registra o que foi encontrado e feito para um booster
+type BoosterReconciliation struct {
+	BoosterID         string               `json:"boosterId"`
+	ActivationApplied bool                 `json:"activationApplied"`
+	RollbackApplied   bool                 `json:"rollbackApplied"`
+	Live              LiveState            `json:"live"`
+	Action            ReconciliationAction `json:"action"`
+	Reason            string               `json:"reason"`
+	Error             string               `json:"error,omitempty"`
+}
+
+// ReconciliationReport é o resultado da reconciliação feita na inicialização
+type ReconciliationReport struct {
+	StartedAt      time.Time               `json:"startedAt"`
+	CompletedAt    time.Time               `json:"completedAt"`
+	Entries        []BoosterReconciliation `json:"entries"`
+	Repaired       int                     `json:"repaired"`
+	NeedsAttention int                     `json:"needsAttention"`
+	// InterruptedOperations lista as operações recuperadas do journal antes da reconciliação
+	InterruptedOperations []JournalRecovery `json:"interruptedOperations"`
+}
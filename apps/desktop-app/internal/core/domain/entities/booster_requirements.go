@@ -0,0 +1,121 @@
+package entities
+
+import (
+	"strconv"
+	"strings"
+)
+
+type InitSystem string
+
+const (
+	InitSystemd  InitSystem = "systemd"
+	InitOpenRC   InitSystem = "openrc"
+	InitRunit    InitSystem = "runit"
+	InitSysVinit InitSystem = "sysvinit"
+)
+
+// DistroFamily segue os valores de ID e ID_LIKE do /etc/os-release
+type DistroFamily string
+
+const (
+	DistroDebian DistroFamily = "debian"
+	DistroFedora DistroFamily = "fedora"
+	DistroRHEL   DistroFamily = "rhel"
+	DistroArch   DistroFamily = "arch"
+	DistroSUSE   DistroFamily = "suse"
+)
+
+// Requirements são as condições da máquina para o booster funcionar. Campos
+// vazios não restringem nada.
+type Requirements struct {
+	// Elevation exige root no Linux ou administrador no Windows
+	Elevation bool
+	// Binaries são executáveis procurados no PATH
+	Binaries []string
+	Kernel   VersionRange
+	// InitSystems aceita qualquer um dos sistemas de init listados
+	InitSystems []InitSystem
+	// SysfsPaths precisam existir (ex: /sys/kernel/mm/transparent_hugepage/enabled)
+	SysfsPaths []string
+	// DistroFamilies aceita qualquer uma das famílias listadas
+	DistroFamilies []DistroFamily
+	// RebootRequired avisa que o efeito só vale depois de reiniciar; não bloqueia o booster
+	RebootRequired bool
+}
+
+// VersionRange é um intervalo de versões com Min inclusivo e Max exclusivo
+type VersionRange struct {
+	Min string
+	Max string
+}
+
+func (r VersionRange) IsZero() bool {
+	return r.Min == "" && r.Max == ""
+}
+
+// Contains compara só a parte numérica da versão: "6.1.0-13-amd64" vale como 6.1.0
+func (r VersionRange) Contains(version string) bool {
+	if r.Min != "" && CompareVersions(version, r.Min) < 0 {
+		return false
+	}
+	if r.Max != "" && CompareVersions(version, r.Max) >= 0 {
+		return false
+	}
+	return true
+}
+
+// CompareVersions compara versões numéricas separadas por ponto; partes
+// ausentes valem zero
+func CompareVersions(a, b string) int {
+	pa, pb := versionParts(a), versionParts(b)
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var x, y int
+		if i < len(pa) {
+			x = pa[i]
+		}
+		if i < len(pb) {
+			y = pb[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func versionParts(version string) []int {
+	end := strings.IndexFunc(version, func(r rune) bool { return r != '.' && (r < '0' || r > '9') })
+	if end >= 0 {
+		version = version[:end]
+	}
+	var parts []int
+	for _, part := range strings.Split(strings.Trim(version, "."), ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+type RequirementCode string
+
+const (
+	RequirementElevation    RequirementCode = "elevation_required"
+	RequirementBinary       RequirementCode = "binary_missing"
+	RequirementKernel       RequirementCode = "kernel_version_unsupported"
+	RequirementInitSystem   RequirementCode = "init_system_unsupported"
+	RequirementSysfsPath    RequirementCode = "sysfs_path_missing"
+	RequirementDistroFamily RequirementCode = "distro_family_unsupported"
+)
+
+// UnsupportedReason é um requisito não atendido, legível pela interface
+type UnsupportedReason struct {
+	Code RequirementCode `json:"code"`
+	// Detail identifica o que faltou: o binário, o caminho, a versão encontrada...
+	Detail string `json:"detail,omitempty"`
+}
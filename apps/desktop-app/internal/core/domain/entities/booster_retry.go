@@ -0,0 +1,89 @@
+package entities
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrorClass classifica a falha de uma tentativa para a política de retry
+type ErrorClass string
+
+const (
+	// ErrorClassTimeout: a tentativa excedeu o timeout do booster
+	ErrorClassTimeout ErrorClass = "timeout"
+	// ErrorClassTransient: o executor sinalizou que a falha é temporária
+	ErrorClassTransient ErrorClass = "transient"
+	// ErrorClassFailedResult: o executor terminou sem erro mas reportou falha
+	ErrorClassFailedResult ErrorClass = "failed_result"
+	// ErrorClassPermanent: qualquer outro erro
+	ErrorClassPermanent ErrorClass = "permanent"
+)
+
+// RetryPolicy define quantas vezes e com que espera uma operação é repetida.
+// A espera antes da tentativa n+1 é InitialBackoff * Multiplier^(n-1), limitada por MaxBackoff.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	RetryOn        []ErrorClass
+}
+
+// Retries informa se a classe de erro pode ser repetida
+func (p RetryPolicy) Retries(class ErrorClass) bool {
+	for _, retryable := range p.RetryOn {
+		if retryable == class {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff retorna a espera após a tentativa informada (começando em 1)
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	delay := float64(p.InitialBackoff)
+	for i := 1; i < attempt; i++ {
+		delay *= multiplier
+		if p.MaxBackoff > 0 && delay >= float64(p.MaxBackoff) {
+			return p.MaxBackoff
+		}
+	}
+	return time.Duration(delay)
+}
+
+// TransientError marca uma falha do executor como temporária (ex.: serviço ocupado)
+type TransientError struct {
+	Err error
+}
+
+func NewTransientError(err error) error {
+	return &TransientError{Err: err}
+}
+
+func (e *TransientError) Error() string { return e.Err.Error() }
+func (e *TransientError) Unwrap() error { return e.Err }
+
+// IsTransient reconhece TransientError e erros que se declaram temporários
+func IsTransient(err error) bool {
+	var transient *TransientError
+	if errors.As(err, &transient) {
+		return true
+	}
+	var temporary interface{ Temporary() bool }
+	return errors.As(err, &temporary) && temporary.Temporary()
+}
+
+// OperationAttempt descreve o resultado de uma tentativa de execução
+type OperationAttempt struct {
+	Attempt     int           `json:"attempt"`
+	MaxAttempts int           `json:"maxAttempts"`
+	Error       string        `json:"error,omitempty"`
+	ErrorClass  ErrorClass    `json:"errorClass,omitempty"`
+	WillRetry   bool          `json:"willRetry"`
+	Backoff     time.Duration `json:"backoff"`
+}
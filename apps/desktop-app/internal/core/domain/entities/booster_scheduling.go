@@ -0,0 +1,50 @@
+package entities
+
+import "strings"
+
+// OperationPriority é a faixa da queue em que a operação é executada. Operações
+// interativas passam na frente de lotes, que passam na frente de trabalho agendado.
+type OperationPriority string
+
+const (
+	PriorityInteractive OperationPriority = "interactive"
+	PriorityBatch       OperationPriority = "batch"
+	PriorityScheduled   OperationPriority = "scheduled"
+)
+
+// PriorityLanes é o número de faixas de prioridade da queue
+const PriorityLanes = 3
+
+// Lane retorna o índice da faixa; 0 é a mais prioritária e o valor vazio é interativo
+func (p OperationPriority) Lane() int {
+	switch p {
+	case PriorityBatch:
+		return 1
+	case PriorityScheduled:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// Recursos do sistema declarados pelos boosters. Operações que compartilham um
+// recurso nunca rodam ao mesmo tempo.
+
+func FileResource(path string) string {
+	return "file:" + path
+}
+
+// RegistryResource normaliza a chave, já que o registro não diferencia maiúsculas
+func RegistryResource(key string) string {
+	return "registry:" + strings.ToLower(key)
+}
+
+func SystemResource(name string) string {
+	return "system:" + name
+}
+
+// BoosterResource é o recurso implícito de todo booster: duas operações do mesmo
+// booster nunca rodam em paralelo
+func BoosterResource(boosterID string) string {
+	return "booster:" + boosterID
+}
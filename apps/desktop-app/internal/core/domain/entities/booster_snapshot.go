@@ -0,0 +1,53 @@
+package entities
+
+import "time"
+
+// SnapshotOrigin indica o que motivou a criação do snapshot
+type SnapshotOrigin string
+
+const (
+	SnapshotManual      SnapshotOrigin = "manual"
+	SnapshotBeforeBatch SnapshotOrigin = "before_batch"
+)
+
+// SnapshotBooster é o estado de um booster aplicado no momento do snapshot
+type SnapshotBooster struct {
+	BoosterID  string     `json:"boosterId"`
+	Version    string     `json:"version"`
+	AppliedAt  *time.Time `json:"appliedAt,omitempty"`
+	BackupData BackupData `json:"backupData"`
+	// LiveValues são os valores reportados pelo executor para cada recurso que o booster altera
+	LiveValues map[string]string `json:"liveValues,omitempty"`
+	LiveError  string            `json:"liveError,omitempty"`
+}
+
+// Snapshot é um ponto de restauração com todos os boosters aplicados
+type Snapshot struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Origin    SnapshotOrigin    `json:"origin"`
+	BatchID   string            `json:"batchId,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Boosters  []SnapshotBooster `json:"boosters"`
+}
+
+// BoosterIDs retorna os boosters que estavam aplicados no snapshot
+func (s Snapshot) BoosterIDs() []string {
+	ids := make([]string, len(s.Boosters))
+	for i, booster := range s.Boosters {
+		ids[i] = booster.BoosterID
+	}
+	return ids
+}
+
+// SnapshotRestore descreve as operações enfileiradas para voltar ao estado de um snapshot
+type SnapshotRestore struct {
+	SnapshotID string `json:"snapshotId"`
+	// BatchID identifica o lote na queue; vazio se nada precisava mudar
+	BatchID string `json:"batchId,omitempty"`
+	// SafetySnapshotID é o snapshot automático criado antes da restauração
+	SafetySnapshotID string            `json:"safetySnapshotId,omitempty"`
+	Revert           []string          `json:"revert"`
+	Apply            []string          `json:"apply"`
+	Rejected         map[string]string `json:"rejected,omitempty"`
+}
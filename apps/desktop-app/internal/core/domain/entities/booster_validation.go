@@ -0,0 +1,37 @@
+package entities
+
+import "fmt"
+
+type BoosterValidationCode string
+
+const (
+	ValidationUnknownBooster    BoosterValidationCode = "unknown_booster"
+	ValidationUnknownDependency BoosterValidationCode = "unknown_dependency"
+	ValidationMissingDependency BoosterValidationCode = "missing_dependency"
+	ValidationDependencyCycle   BoosterValidationCode = "dependency_cycle"
+	ValidationConflict          BoosterValidationCode = "conflict"
+	ValidationDependentApplied  BoosterValidationCode = "dependent_applied"
+	ValidationFailed            BoosterValidationCode = "validation_failed"
+)
+
+// BoosterValidationError descreve por que um booster foi recusado no planejamento.
+// Os campos são exportados para que o erro seja serializado nos eventos.
+type BoosterValidationError struct {
+	BoosterID string                `json:"boosterId"`
+	Code      BoosterValidationCode `json:"code"`
+	Message   string                `json:"message"`
+	Related   []string              `json:"related,omitempty"`
+}
+
+func NewBoosterValidationError(boosterID string, code BoosterValidationCode, related []string, format string, args ...interface{}) *BoosterValidationError {
+	return &BoosterValidationError{
+		BoosterID: boosterID,
+		Code:      code,
+		Message:   fmt.Sprintf(format, args...),
+		Related:   related,
+	}
+}
+
+func (e *BoosterValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.BoosterID, e.Message)
+}
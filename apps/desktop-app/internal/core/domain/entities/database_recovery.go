@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// DatabaseRecovery descreve a restauração automática do banco depois que a
+// verificação de integridade encontrou corrupção na inicialização
+type DatabaseRecovery struct {
+	RecoveredAt time.Time `json:"recoveredAt"`
+	// Issues é a saída do PRAGMA integrity_check, ou o erro ao abrir o arquivo
+	Issues []string `json:"issues"`
+	// CorruptedCopy é onde o arquivo corrompido foi guardado
+	CorruptedCopy string `json:"corruptedCopy"`
+	// RestoredFrom fica vazio quando não havia backup bom e o banco foi recriado
+	RestoredFrom  string     `json:"restoredFrom,omitempty"`
+	BackupTakenAt *time.Time `json:"backupTakenAt,omitempty"`
+	// LostOperations são as operações registradas depois do backup restaurado
+	LostOperations []LostOperation `json:"lostOperations,omitempty"`
+	// LostOperationsUnknown indica que o arquivo corrompido não pôde ser lido:
+	// qualquer operação feita depois do backup pode ter sido perdida
+	LostOperationsUnknown bool `json:"lostOperationsUnknown"`
+}
+
+// LostOperation é uma operação cujo registro, e o backup de rollback junto
+// com ele, não existe no banco restaurado
+type LostOperation struct {
+	OperationID string               `json:"operationId"`
+	BoosterID   string               `json:"boosterId"`
+	Operation   BoosterOperationType `json:"operation,omitempty"`
+	At          time.Time            `json:"at"`
+}
@@ -9,5 +9,11 @@ const (
 	EventFailed EventStatus = "booster.failed"
 	EventQueued EventStatus = "booster.queued"
 	EventBatchQueued EventStatus = "booster.batch_queued"
+	EventBatchCompleted EventStatus = "booster.batch_completed"
 	EventCancelled EventStatus = "booster.cancelled"
+	EventDrifted EventStatus = "booster.drifted"
+	EventAttempt EventStatus = "booster.attempt"
+	EventExpiring EventStatus = "booster.expiring"
+	EventExpired EventStatus = "booster.expired"
+	EventHealthChanged EventStatus = "booster.health_changed"
 )
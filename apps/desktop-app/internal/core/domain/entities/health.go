@@ -0,0 +1,51 @@
+package entities
+
+import "time"
+
+type WorkerState string
+
+const (
+	WorkerIdle WorkerState = "idle"
+	WorkerBusy WorkerState = "busy"
+	// WorkerStuck indica uma operação rodando há mais tempo que o limite
+	WorkerStuck WorkerState = "stuck"
+	// WorkerUnresponsive indica um worker que parou de enviar heartbeats
+	WorkerUnresponsive WorkerState = "unresponsive"
+	WorkerStopped      WorkerState = "stopped"
+)
+
+// WorkerHealth é o estado de um worker do pool no momento da verificação
+type WorkerHealth struct {
+	ID            int                  `json:"id"`
+	State         WorkerState          `json:"state"`
+	LastHeartbeat time.Time            `json:"lastHeartbeat"`
+	BoosterID     string               `json:"boosterId,omitempty"`
+	OperationID   string               `json:"operationId,omitempty"`
+	Operation     BoosterOperationType `json:"operation,omitempty"`
+	StartedAt     *time.Time           `json:"startedAt,omitempty"`
+	RunningFor    time.Duration        `json:"runningFor,omitempty"`
+	// Restarts conta quantas vezes o worker foi substituído após um panic
+	Restarts  int    `json:"restarts"`
+	LastPanic string `json:"lastPanic,omitempty"`
+}
+
+// StuckOperation é uma operação que passou do limite de execução
+type StuckOperation struct {
+	WorkerID    int                  `json:"workerId"`
+	BoosterID   string               `json:"boosterId"`
+	OperationID string               `json:"operationId"`
+	Operation   BoosterOperationType `json:"operation"`
+	RunningFor  time.Duration        `json:"runningFor"`
+}
+
+// HealthStatus contém informações sobre a saúde do serviço de boosters
+type HealthStatus struct {
+	IsHealthy          bool             `json:"isHealthy"`
+	QueueSize          int              `json:"queueSize"`
+	ActiveWorkers      int              `json:"activeWorkers"`
+	RegisteredBoosters int              `json:"registeredBoosters"`
+	Workers            []WorkerHealth   `json:"workers"`
+	StuckOperations    []StuckOperation `json:"stuckOperations,omitempty"`
+	Issues             []string         `json:"issues,omitempty"`
+	CheckedAt          time.Time        `json:"checkedAt"`
+}
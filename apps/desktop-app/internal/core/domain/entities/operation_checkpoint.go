@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrOperationCancelled indica que a operação foi cancelada antes de terminar
+var ErrOperationCancelled = errors.New("operation cancelled")
+
+// CheckpointRecorder recebe o backup acumulado a cada checkpoint do executor
+type CheckpointRecorder func(step string, backup BackupData)
+
+type checkpointKey struct{}
+
+// WithCheckpointRecorder associa ao contexto quem acompanha os checkpoints da execução
+func WithCheckpointRecorder(ctx context.Context, recorder CheckpointRecorder) context.Context {
+	return context.WithValue(ctx, checkpointKey{}, recorder)
+}
+
+// Checkpoint marca um ponto seguro entre duas alterações do executor. backup deve
+// conter o necessário para desfazer tudo o que já foi alterado. Se a operação foi
+// cancelada, retorna um erro com ErrOperationCancelled e o executor deve parar,
+// devolvendo o backup no resultado para que as alterações parciais sejam desfeitas.
+func Checkpoint(ctx context.Context, step string, backup BackupData) error {
+	if recorder, ok := ctx.Value(checkpointKey{}).(CheckpointRecorder); ok && recorder != nil {
+		recorder(step, backup)
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%w at %s: %w", ErrOperationCancelled, step, err)
+	}
+	return nil
+}
+
+// IsCancellation indica se o erro veio de um cancelamento ou timeout da operação
+func IsCancellation(err error) bool {
+	return errors.Is(err, ErrOperationCancelled) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,130 @@
+package entities
+
+import "time"
+
+// IsTerminal indica se a operação não muda mais de status
+func (s OperationStatus) IsTerminal() bool {
+	return s == OperationCompleted || s == OperationFailed || s == OperationCancelled
+}
+
+// OperationDetails é o estado persistido de uma operação consultado pelo OperationID
+type OperationDetails struct {
+	OperationID string               `json:"operationId"`
+	BoosterID   string               `json:"boosterId"`
+	BatchID     string               `json:"batchId,omitempty"`
+	Operation   BoosterOperationType `json:"operation"`
+	Status      OperationStatus      `json:"status"`
+	QueuedAt    time.Time            `json:"queuedAt"`
+	StartedAt   *time.Time           `json:"startedAt,omitempty"`
+	CompletedAt *time.Time           `json:"completedAt,omitempty"`
+	// Duration é o tempo de execução, do início ao término
+	Duration time.Duration `json:"duration"`
+	Version  string        `json:"version,omitempty"`
+	Attempts int           `json:"attempts"`
+	Error    string        `json:"error,omitempty"`
+	Message  string        `json:"message,omitempty"`
+}
+
+// NewOperationDetails monta os detalhes a partir do registro do histórico
+func NewOperationDetails(op BoostOperation) OperationDetails {
+	details := OperationDetails{
+		OperationID: op.ID,
+		BoosterID:   op.BoosterID,
+		BatchID:     op.BatchID,
+		Operation:   op.Type,
+		Status:      op.Status,
+		QueuedAt:    op.QueuedAt,
+		StartedAt:   op.StartedAt,
+		CompletedAt: op.CompletedAt,
+		Duration:    op.Duration,
+		Version:     op.Version,
+		Attempts:    op.Attempts,
+		Error:       op.ErrorMsg,
+		Message:     op.Message,
+	}
+	// Registros anteriores ao ciclo de vida só eram gravados ao término
+	if details.Status == "" {
+		details.Status = OperationCompleted
+		if op.ErrorMsg != "" {
+			details.Status = OperationFailed
+		}
+	}
+	if details.Duration == 0 && op.StartedAt != nil && op.CompletedAt != nil {
+		details.Duration = op.CompletedAt.Sub(*op.StartedAt)
+	}
+	return details
+}
+
+// BatchDetails agrega as operações de um lote
+type BatchDetails struct {
+	BatchID     string             `json:"batchId"`
+	Status      OperationStatus    `json:"status"`
+	Total       int                `json:"total"`
+	Queued      int                `json:"queued"`
+	Processing  int                `json:"processing"`
+	Completed   int                `json:"completed"`
+	Failed      int                `json:"failed"`
+	Cancelled   int                `json:"cancelled"`
+	QueuedAt    time.Time          `json:"queuedAt"`
+	StartedAt   *time.Time         `json:"startedAt,omitempty"`
+	CompletedAt *time.Time         `json:"completedAt,omitempty"`
+	Duration    time.Duration      `json:"duration"`
+	Operations  []OperationDetails `json:"operations"`
+}
+
+// NewBatchDetails agrega as operações do lote. O lote está em andamento enquanto
+// houver operação pendente; terminado, falha se alguma falhou.
+func NewBatchDetails(batchID string, ops []BoostOperation) BatchDetails {
+	batch := BatchDetails{
+		BatchID:    batchID,
+		Total:      len(ops),
+		Operations: make([]OperationDetails, 0, len(ops)),
+	}
+
+	for _, op := range ops {
+		details := NewOperationDetails(op)
+		batch.Operations = append(batch.Operations, details)
+
+		switch details.Status {
+		case OperationCompleted:
+			batch.Completed++
+		case OperationFailed:
+			batch.Failed++
+		case OperationCancelled:
+			batch.Cancelled++
+		case OperationProcessing:
+			batch.Processing++
+		default:
+			batch.Queued++
+		}
+
+		if batch.QueuedAt.IsZero() || details.QueuedAt.Before(batch.QueuedAt) {
+			batch.QueuedAt = details.QueuedAt
+		}
+		if details.StartedAt != nil && (batch.StartedAt == nil || details.StartedAt.Before(*batch.StartedAt)) {
+			batch.StartedAt = details.StartedAt
+		}
+		if details.CompletedAt != nil && (batch.CompletedAt == nil || details.CompletedAt.After(*batch.CompletedAt)) {
+			batch.CompletedAt = details.CompletedAt
+		}
+	}
+
+	switch {
+	case batch.Queued == batch.Total:
+		batch.Status = OperationQueued
+	case batch.Queued > 0 || batch.Processing > 0:
+		batch.Status = OperationProcessing
+		batch.CompletedAt = nil
+	case batch.Failed > 0:
+		batch.Status = OperationFailed
+	case batch.Cancelled > 0:
+		batch.Status = OperationCancelled
+	default:
+		batch.Status = OperationCompleted
+	}
+
+	if batch.Status.IsTerminal() && batch.StartedAt != nil && batch.CompletedAt != nil {
+		batch.Duration = batch.CompletedAt.Sub(*batch.StartedAt)
+	}
+	return batch
+}
@@ -0,0 +1,66 @@
+package entities
+
+import "time"
+
+// JournalPhase é a fase de uma operação no journal de execução
+type JournalPhase string
+
+const (
+	JournalQueued           JournalPhase = "queued"
+	JournalStarted          JournalPhase = "started"
+	JournalExecutorFinished JournalPhase = "executor_finished"
+	JournalStateSaved       JournalPhase = "state_saved"
+	JournalFailed           JournalPhase = "failed"
+	JournalCancelled        JournalPhase = "cancelled"
+	JournalRecovered        JournalPhase = "recovered"
+)
+
+// IsTerminal informa se a operação não precisa de recuperação nesta fase
+func (p JournalPhase) IsTerminal() bool {
+	switch p {
+	case JournalStateSaved, JournalFailed, JournalCancelled, JournalRecovered:
+		return true
+	}
+	return false
+}
+
+// JournalEntry é o registro write-ahead de uma operação da queue.
+// Success, Message e BackupData são preenchidos quando o executor termina.
+type JournalEntry struct {
+	OperationID string
+	BoosterID   string
+	Operation   BoosterOperationType
+	BatchID     string
+	DependsOn   []string
+	Phase       JournalPhase
+	Success     bool
+	Message     string
+	BackupData  BackupData
+	Error       string
+	QueuedAt    time.Time
+	UpdatedAt   time.Time
+}
+
+// JournalRecoveryAction é o que a inicialização fez com uma operação interrompida
+type JournalRecoveryAction string
+
+const (
+	JournalActionResumed    JournalRecoveryAction = "resumed"
+	JournalActionCompleted  JournalRecoveryAction = "completed"
+	JournalActionRolledBack JournalRecoveryAction = "rolled_back"
+	JournalActionFailed     JournalRecoveryAction = "failed"
+	// JournalActionManualReview é uma operação interrompida durante a execução:
+	// o sistema pode ter ficado alterado pela metade e nada é repetido
+	JournalActionManualReview JournalRecoveryAction = "manual_review"
+)
+
+// JournalRecovery descreve a recuperação de uma operação interrompida
+type JournalRecovery struct {
+	OperationID    string                `json:"operationId"`
+	BoosterID      string                `json:"boosterId"`
+	Operation      BoosterOperationType  `json:"operation"`
+	Phase          JournalPhase          `json:"phase"`
+	Action         JournalRecoveryAction `json:"action"`
+	NewOperationID string                `json:"newOperationId,omitempty"`
+	Error          string                `json:"error,omitempty"`
+}
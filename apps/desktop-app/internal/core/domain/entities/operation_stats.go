@@ -0,0 +1,114 @@
+package entities
+
+import "time"
+
+// OperationStatsFilter restringe as estatísticas a um booster e a uma janela de
+// tempo; campos vazios não filtram
+type OperationStatsFilter struct {
+	BoosterID string     `json:"boosterId,omitempty"`
+	Since     *time.Time `json:"since,omitempty"`
+	Until     *time.Time `json:"until,omitempty"`
+}
+
+// OperationAggregate são os totais de um conjunto de operações. As taxas são
+// calculadas sobre as operações terminadas.
+type OperationAggregate struct {
+	Total             int           `json:"total"`
+	Successful        int           `json:"successful"`
+	Failed            int           `json:"failed"`
+	Cancelled         int           `json:"cancelled"`
+	Processing        int           `json:"processing"`
+	Pending           int           `json:"pending"`
+	ApplyOperations   int           `json:"applyOperations"`
+	RevertOperations  int           `json:"revertOperations"`
+	RetriedOperations int           `json:"retriedOperations"`
+	SuccessRate       float64       `json:"successRate"`
+	FailureRate       float64       `json:"failureRate"`
+	AverageDuration   time.Duration `json:"averageDuration"`
+	MaxDuration       time.Duration `json:"maxDuration"`
+	LastOperationAt   *time.Time    `json:"lastOperationAt,omitempty"`
+
+	timedOperations int
+	totalDuration   time.Duration
+}
+
+// Add contabiliza uma operação
+func (a *OperationAggregate) Add(op BoostOperation) {
+	a.Total++
+	details := NewOperationDetails(op)
+
+	switch details.Status {
+	case OperationCompleted:
+		a.Successful++
+	case OperationFailed:
+		a.Failed++
+	case OperationCancelled:
+		a.Cancelled++
+	case OperationProcessing:
+		a.Processing++
+	default:
+		a.Pending++
+	}
+
+	switch op.Type {
+	case ApplyOperationType:
+		a.ApplyOperations++
+	case RevertOperationType:
+		a.RevertOperations++
+	}
+
+	if op.Attempts > 1 {
+		a.RetriedOperations++
+	}
+	if details.Duration > 0 {
+		a.timedOperations++
+		a.totalDuration += details.Duration
+		if details.Duration > a.MaxDuration {
+			a.MaxDuration = details.Duration
+		}
+	}
+	if a.LastOperationAt == nil || op.AppliedAt.After(*a.LastOperationAt) {
+		at := op.AppliedAt
+		a.LastOperationAt = &at
+	}
+}
+
+// finish calcula as taxas e a duração média
+func (a *OperationAggregate) finish() {
+	if finished := a.Successful + a.Failed + a.Cancelled; finished > 0 {
+		a.SuccessRate = float64(a.Successful) / float64(finished) * 100
+		a.FailureRate = float64(a.Failed) / float64(finished) * 100
+	}
+	if a.timedOperations > 0 {
+		a.AverageDuration = a.totalDuration / time.Duration(a.timedOperations)
+	}
+}
+
+// OperationStats são os totais gerais do filtro e os totais de cada booster
+type OperationStats struct {
+	OperationAggregate
+	Filter    OperationStatsFilter          `json:"filter"`
+	ByBooster map[string]OperationAggregate `json:"byBooster"`
+}
+
+// NewOperationStats agrega as operações já filtradas
+func NewOperationStats(filter OperationStatsFilter, ops []BoostOperation) *OperationStats {
+	stats := &OperationStats{
+		Filter:    filter,
+		ByBooster: make(map[string]OperationAggregate),
+	}
+
+	for _, op := range ops {
+		stats.Add(op)
+		booster := stats.ByBooster[op.BoosterID]
+		booster.Add(op)
+		stats.ByBooster[op.BoosterID] = booster
+	}
+
+	stats.finish()
+	for id, booster := range stats.ByBooster {
+		booster.finish()
+		stats.ByBooster[id] = booster
+	}
+	return stats
+}
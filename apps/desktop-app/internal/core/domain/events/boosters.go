@@ -34,3 +34,49 @@ type BoosterBatchProgressEvent struct {
 	ValidationErrors map[string]error
 	QueueSize        int
 }
+
+type BoosterBatchResultEvent struct {
+	EventType     entities.EventStatus
+	Timestamp     time.Time
+	BatchID       string
+	OperationType entities.BoosterOperationType
+	Transactional bool
+	Committed     bool
+	Outcomes      []entities.BatchBoosterOutcome
+	Rollbacks     []entities.BatchBoosterOutcome
+}
+
+type BoosterDriftEvent struct {
+	EventType          entities.EventStatus
+	Timestamp          time.Time
+	BoosterID          string
+	Status             entities.BoosterExecutionStatus
+	Policy             entities.DriftPolicy
+	Drifted            []entities.PlannedChange
+	ReapplyOperationID string
+}
+
+type BoosterAttemptEvent struct {
+	EventType     entities.EventStatus
+	Timestamp     time.Time
+	BoosterID     string
+	OperationID   string
+	OperationType entities.BoosterOperationType
+	Attempt       entities.OperationAttempt
+}
+
+type BoosterExpiryEvent struct {
+	EventType         entities.EventStatus
+	Timestamp         time.Time
+	BoosterID         string
+	ExpiresAt         time.Time
+	Remaining         time.Duration
+	RevertOperationID string
+}
+
+// BoosterHealthEvent é emitido quando a saúde do serviço de boosters muda
+type BoosterHealthEvent struct {
+	EventType entities.EventStatus
+	Timestamp time.Time
+	Health    entities.HealthStatus
+}
@@ -0,0 +1,121 @@
+package booster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+func newCancelFixture(t *testing.T, boosters ...*testBooster) (*Service, chan string) {
+	started := make(chan string, 10)
+	for _, b := range boosters {
+		b.started = started
+		if b.execResult == nil {
+			b.execResult = okResult()
+		}
+	}
+	service, _ := newRecordedService(t, boosters...)
+	service.workerPool.SetDefaultPolicy(ExecutionPolicy{CancelGrace: time.Second})
+	return service, started
+}
+
+func TestCancelOperation_DiscardsQueuedOperation(t *testing.T) {
+	block := make(chan struct{})
+	queued := &testBooster{id: "queued"}
+	service, started := newCancelFixture(t,
+		&testBooster{id: "a", execBlock: block},
+		&testBooster{id: "b", execBlock: block},
+		queued,
+	)
+	ctx := context.Background()
+
+	// os dois workers ficam ocupados; a terceira operação continua na queue
+	a, err := service.InitBoosterApply(ctx, "a")
+	require.NoError(t, err)
+	b, err := service.InitBoosterApply(ctx, "b")
+	require.NoError(t, err)
+	<-started
+	<-started
+	res, err := service.InitBoosterApply(ctx, "queued")
+	require.NoError(t, err)
+
+	require.NoError(t, service.CancelOperation(ctx, res.OperationID))
+	err = service.queueManager.AwaitOperation(ctx, res.OperationID)
+	assert.ErrorIs(t, err, entities.ErrOperationCancelled)
+
+	close(block)
+	require.NoError(t, service.queueManager.AwaitOperation(ctx, a.OperationID))
+	require.NoError(t, service.queueManager.AwaitOperation(ctx, b.OperationID))
+	assert.Equal(t, 0, queued.execCalls)
+
+	details, err := service.GetOperation(ctx, res.OperationID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OperationCancelled, details.Status)
+
+	assert.ErrorIs(t, service.CancelOperation(ctx, res.OperationID), ErrNotFound)
+}
+
+func TestCancelOperation_RevertsPartialChangesOfRunningOperation(t *testing.T) {
+	running := &testBooster{
+		id:        "running",
+		steps:     []string{"first", "second"},
+		revertRes: &entities.BoostRevertResult{Success: true},
+	}
+	service, started := newCancelFixture(t, running)
+	ctx := context.Background()
+
+	res, err := service.InitBoosterApply(ctx, "running")
+	require.NoError(t, err)
+	expectStarted(t, started, "running")
+
+	require.NoError(t, service.CancelOperation(ctx, res.OperationID))
+	err = service.queueManager.AwaitOperation(ctx, res.OperationID)
+	assert.ErrorIs(t, err, entities.ErrOperationCancelled)
+	assert.ErrorContains(t, err, "partial changes reverted")
+
+	// só o primeiro passo chegou a ser aplicado
+	assert.Equal(t, entities.BackupData{"first": "original"}, running.revertBackup)
+
+	applied, err := service.processor.IsApplied(ctx, "running")
+	require.NoError(t, err)
+	assert.False(t, applied)
+
+	details, err := service.GetOperation(ctx, res.OperationID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OperationCancelled, details.Status)
+}
+
+func TestCancelBatch_CancelsQueuedAndRunningOperations(t *testing.T) {
+	service, started := newCancelFixture(t,
+		&testBooster{id: "a", steps: []string{"a1", "a2"}, revertRes: &entities.BoostRevertResult{Success: true}},
+		&testBooster{id: "b", steps: []string{"b1", "b2"}, revertRes: &entities.BoostRevertResult{Success: true}},
+		&testBooster{id: "c"},
+	)
+	ctx := context.Background()
+
+	res, err := service.InitBoosterApplyBatch(ctx, []string{"a", "b", "c"})
+	require.NoError(t, err)
+	<-started
+	<-started
+
+	require.NoError(t, service.CancelBatch(ctx, res.OperationID))
+
+	batch, err := service.GetBatch(ctx, res.OperationID)
+	require.NoError(t, err)
+	require.Len(t, batch.Operations, 3)
+	for _, op := range batch.Operations {
+		assert.ErrorIs(t, service.queueManager.AwaitOperation(ctx, op.OperationID), entities.ErrOperationCancelled)
+	}
+
+	batch, err = service.GetBatch(ctx, res.OperationID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, batch.Cancelled)
+	assert.Equal(t, entities.OperationCancelled, batch.Status)
+
+	assert.ErrorIs(t, service.CancelBatch(ctx, res.OperationID), ErrNotFound)
+}
@@ -0,0 +1,214 @@
+package booster
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/wailsapp/wails/lib/logger"
+)
+
+var ErrRequirementsNotMet = errors.New("booster requirements are not met")
+
+// SystemProbe lê as características da máquina usadas nos requisitos dos boosters
+type SystemProbe interface {
+	// BootID muda a cada boot; os resultados são reaproveitados enquanto não mudar
+	BootID(ctx context.Context) (string, error)
+	IsElevated(ctx context.Context) bool
+	HasBinary(ctx context.Context, name string) bool
+	KernelVersion(ctx context.Context) (string, error)
+	InitSystem(ctx context.Context) (entities.InitSystem, error)
+	PathExists(ctx context.Context, path string) bool
+	DistroFamilies(ctx context.Context) ([]entities.DistroFamily, error)
+}
+
+// CapabilityCache guarda os resultados do probe do boot atual entre execuções do app
+type CapabilityCache interface {
+	Load(ctx context.Context, bootID string) (map[string][]entities.UnsupportedReason, error)
+	Save(ctx context.Context, bootID string, results map[string][]entities.UnsupportedReason) error
+}
+
+// CapabilityProber avalia os requisitos declarados pelos boosters. O resultado de
+// cada conjunto de requisitos é calculado uma vez por boot; só a elevação, que
+// depende de como o app foi iniciado, é verificada a cada execução.
+type CapabilityProber struct {
+	probe    SystemProbe
+	cache    CapabilityCache
+	mu       sync.Mutex
+	loaded   bool
+	bootID   string
+	results  map[string][]entities.UnsupportedReason
+	elevated *bool
+	logger   *logger.CustomLogger
+}
+
+func NewCapabilityProber(probe SystemProbe, cache CapabilityCache) *CapabilityProber {
+	return &CapabilityProber{
+		probe:   probe,
+		cache:   cache,
+		results: make(map[string][]entities.UnsupportedReason),
+		logger:  logger.NewCustomLogger("[CapabilityProber]"),
+	}
+}
+
+// Unsupported retorna os requisitos do booster que a máquina não atende
+func (c *CapabilityProber) Unsupported(ctx context.Context, entity entities.Booster) []entities.UnsupportedReason {
+	req := entity.Requirements
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var reasons []entities.UnsupportedReason
+	if req.Elevation && !c.isElevated(ctx) {
+		reasons = append(reasons, entities.UnsupportedReason{Code: entities.RequirementElevation})
+	}
+
+	c.load(ctx)
+	key := requirementsKey(req)
+	cached, ok := c.results[key]
+	if !ok {
+		cached = c.evaluate(ctx, req)
+		c.results[key] = cached
+		c.save(ctx)
+	}
+	return append(reasons, cached...)
+}
+
+// Check retorna ErrRequirementsNotMet com os motivos quando algum requisito falta
+func (c *CapabilityProber) Check(ctx context.Context, entity entities.Booster) error {
+	reasons := c.Unsupported(ctx, entity)
+	if len(reasons) == 0 {
+		return nil
+	}
+	details := make([]string, 0, len(reasons))
+	for _, reason := range reasons {
+		if reason.Detail == "" {
+			details = append(details, string(reason.Code))
+			continue
+		}
+		details = append(details, fmt.Sprintf("%s (%s)", reason.Code, reason.Detail))
+	}
+	return fmt.Errorf("%w: %s", ErrRequirementsNotMet, strings.Join(details, ", "))
+}
+
+func (c *CapabilityProber) isElevated(ctx context.Context) bool {
+	if c.elevated == nil {
+		elevated := c.probe.IsElevated(ctx)
+		c.elevated = &elevated
+	}
+	return *c.elevated
+}
+
+// load lê do cache os resultados do boot atual na primeira avaliação
+func (c *CapabilityProber) load(ctx context.Context) {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+
+	bootID, err := c.probe.BootID(ctx)
+	if err != nil {
+		c.logger.Warnf("failed to read boot id, capability results will not be cached: %v", err)
+		return
+	}
+	c.bootID = bootID
+	if c.cache == nil {
+		return
+	}
+	results, err := c.cache.Load(ctx, bootID)
+	if err != nil {
+		c.logger.Warnf("failed to load capability cache: %v", err)
+		return
+	}
+	for key, reasons := range results {
+		c.results[key] = reasons
+	}
+}
+
+func (c *CapabilityProber) save(ctx context.Context) {
+	if c.cache == nil || c.bootID == "" {
+		return
+	}
+	if err := c.cache.Save(ctx, c.bootID, c.results); err != nil {
+		c.logger.Warnf("failed to save capability cache: %v", err)
+	}
+}
+
+func (c *CapabilityProber) evaluate(ctx context.Context, req entities.Requirements) []entities.UnsupportedReason {
+	reasons := []entities.UnsupportedReason{}
+
+	for _, binary := range req.Binaries {
+		if !c.probe.HasBinary(ctx, binary) {
+			reasons = append(reasons, entities.UnsupportedReason{Code: entities.RequirementBinary, Detail: binary})
+		}
+	}
+
+	if !req.Kernel.IsZero() {
+		version, err := c.probe.KernelVersion(ctx)
+		if err != nil || !req.Kernel.Contains(version) {
+			reasons = append(reasons, entities.UnsupportedReason{Code: entities.RequirementKernel, Detail: version})
+		}
+	}
+
+	if len(req.InitSystems) > 0 {
+		init, err := c.probe.InitSystem(ctx)
+		if err != nil || !containsInit(req.InitSystems, init) {
+			reasons = append(reasons, entities.UnsupportedReason{Code: entities.RequirementInitSystem, Detail: string(init)})
+		}
+	}
+
+	for _, path := range req.SysfsPaths {
+		if !c.probe.PathExists(ctx, path) {
+			reasons = append(reasons, entities.UnsupportedReason{Code: entities.RequirementSysfsPath, Detail: path})
+		}
+	}
+
+	if len(req.DistroFamilies) > 0 {
+		families, err := c.probe.DistroFamilies(ctx)
+		if err != nil || !containsAnyDistro(req.DistroFamilies, families) {
+			detail := make([]string, len(families))
+			for i, family := range families {
+				detail[i] = string(family)
+			}
+			reasons = append(reasons, entities.UnsupportedReason{Code: entities.RequirementDistroFamily, Detail: strings.Join(detail, ",")})
+		}
+	}
+
+	return reasons
+}
+
+// requirementsKey identifica os requisitos avaliados por boot; a elevação e o
+// aviso de reboot não entram no cache
+func requirementsKey(req entities.Requirements) string {
+	req.Elevation = false
+	req.RebootRequired = false
+	data, _ := json.Marshal(req)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+func containsInit(accepted []entities.InitSystem, init entities.InitSystem) bool {
+	for _, a := range accepted {
+		if a == init {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAnyDistro(accepted, families []entities.DistroFamily) bool {
+	for _, a := range accepted {
+		for _, family := range families {
+			if a == family {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package booster
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// fakeProbe simula a máquina e conta quantas vezes cada binário foi procurado
+type fakeProbe struct {
+	bootID   string
+	elevated bool
+	binaries map[string]bool
+	kernel   string
+	init     entities.InitSystem
+	paths    map[string]bool
+	distros  []entities.DistroFamily
+	lookups  map[string]int
+}
+
+func (p *fakeProbe) BootID(ctx context.Context) (string, error) { return p.bootID, nil }
+func (p *fakeProbe) IsElevated(ctx context.Context) bool        { return p.elevated }
+func (p *fakeProbe) HasBinary(ctx context.Context, name string) bool {
+	p.lookups[name]++
+	return p.binaries[name]
+}
+func (p *fakeProbe) KernelVersion(ctx context.Context) (string, error) { return p.kernel, nil }
+func (p *fakeProbe) InitSystem(ctx context.Context) (entities.InitSystem, error) {
+	return p.init, nil
+}
+func (p *fakeProbe) PathExists(ctx context.Context, path string) bool { return p.paths[path] }
+func (p *fakeProbe) DistroFamilies(ctx context.Context) ([]entities.DistroFamily, error) {
+	return p.distros, nil
+}
+
+type memoryCapabilityCache struct {
+	bootID  string
+	results map[string][]entities.UnsupportedReason
+}
+
+func (c *memoryCapabilityCache) Load(ctx context.Context, bootID string) (map[string][]entities.UnsupportedReason, error) {
+	if c.bootID != bootID {
+		return nil, nil
+	}
+	return c.results, nil
+}
+
+func (c *memoryCapabilityCache) Save(ctx context.Context, bootID string, results map[string][]entities.UnsupportedReason) error {
+	c.bootID = bootID
+	c.results = make(map[string][]entities.UnsupportedReason, len(results))
+	for key, reasons := range results {
+		c.results[key] = reasons
+	}
+	return nil
+}
+
+func newFakeProbe(bootID string) *fakeProbe {
+	return &fakeProbe{
+		bootID:   bootID,
+		binaries: map[string]bool{"sysctl": true},
+		kernel:   "6.1.0-13-amd64",
+		init:     entities.InitSystemd,
+		paths:    map[string]bool{"/sys/kernel/mm/transparent_hugepage/enabled": true},
+		distros:  []entities.DistroFamily{"ubuntu", entities.DistroDebian},
+		lookups:  map[string]int{},
+	}
+}
+
+func TestCapabilityProber_ReportsUnmetRequirements(t *testing.T) {
+	prober := NewCapabilityProber(newFakeProbe("boot-1"), nil)
+	ctx := context.Background()
+
+	met := entities.Booster{Requirements: entities.Requirements{
+		Binaries:       []string{"sysctl"},
+		Kernel:         entities.VersionRange{Min: "5.10"},
+		InitSystems:    []entities.InitSystem{entities.InitSystemd, entities.InitOpenRC},
+		SysfsPaths:     []string{"/sys/kernel/mm/transparent_hugepage/enabled"},
+		DistroFamilies: []entities.DistroFamily{entities.DistroDebian},
+		RebootRequired: true,
+	}}
+	assert.Empty(t, prober.Unsupported(ctx, met))
+
+	unmet := entities.Booster{Requirements: entities.Requirements{
+		Elevation:      true,
+		Binaries:       []string{"sysctl", "ethtool"},
+		Kernel:         entities.VersionRange{Min: "5.10", Max: "6.1"},
+		InitSystems:    []entities.InitSystem{entities.InitOpenRC},
+		SysfsPaths:     []string{"/sys/module/usbcore/parameters/autosuspend"},
+		DistroFamilies: []entities.DistroFamily{entities.DistroArch},
+	}}
+	assert.Equal(t, []entities.UnsupportedReason{
+		{Code: entities.RequirementElevation},
+		{Code: entities.RequirementBinary, Detail: "ethtool"},
+		{Code: entities.RequirementKernel, Detail: "6.1.0-13-amd64"},
+		{Code: entities.RequirementInitSystem, Detail: "systemd"},
+		{Code: entities.RequirementSysfsPath, Detail: "/sys/module/usbcore/parameters/autosuspend"},
+		{Code: entities.RequirementDistroFamily, Detail: "ubuntu,debian"},
+	}, prober.Unsupported(ctx, unmet))
+}
+
+func TestCapabilityProber_CachesResultsPerBoot(t *testing.T) {
+	ctx := context.Background()
+	cache := &memoryCapabilityCache{}
+	entity := entities.Booster{Requirements: entities.Requirements{Elevation: true, Binaries: []string{"ethtool"}}}
+
+	probe := newFakeProbe("boot-1")
+	prober := NewCapabilityProber(probe, cache)
+	prober.Unsupported(ctx, entity)
+	prober.Unsupported(ctx, entity)
+	assert.Equal(t, 1, probe.lookups["ethtool"])
+
+	// Nova execução do app no mesmo boot: o resultado vem do cache, mas a
+	// elevação é verificada de novo
+	probe = newFakeProbe("boot-1")
+	probe.elevated = true
+	reasons := NewCapabilityProber(probe, cache).Unsupported(ctx, entity)
+	assert.Zero(t, probe.lookups["ethtool"])
+	assert.Equal(t, []entities.UnsupportedReason{{Code: entities.RequirementBinary, Detail: "ethtool"}}, reasons)
+
+	// Outro boot descarta o cache
+	probe = newFakeProbe("boot-2")
+	probe.binaries["ethtool"] = true
+	assert.Empty(t, NewCapabilityProber(probe, cache).Unsupported(ctx, entities.Booster{Requirements: entities.Requirements{Binaries: []string{"ethtool"}}}))
+	assert.Equal(t, 1, probe.lookups["ethtool"])
+}
+
+func TestProcessApply_RejectsUnmetRequirements(t *testing.T) {
+	proc := newResolverProcessor(t,
+		&testBooster{id: "needs_root", execResult: okResult(), requirements: entities.Requirements{Elevation: true}},
+	)
+	proc.SetCapabilityProber(NewCapabilityProber(newFakeProbe("boot-1"), nil))
+
+	res, err := proc.ProcessApply(context.Background(), "needs_root")
+	require.NoError(t, err)
+	assert.False(t, res.Success)
+	assert.True(t, errors.Is(res.Error, ErrRequirementsNotMet))
+
+	err = proc.ValidateBoosterOperation(context.Background(), "needs_root", entities.ApplyOperationType)
+	assert.ErrorIs(t, err, ErrRequirementsNotMet)
+}
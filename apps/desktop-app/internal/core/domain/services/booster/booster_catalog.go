@@ -0,0 +1,112 @@
+package booster
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/wailsapp/wails/lib/logger"
+)
+
+var ErrDuplicateBooster = errors.New("booster id already registered")
+
+// CatalogSource é uma origem de boosters: uma categoria implementada em Go,
+// os manifestos ou os plugins. Conflitos de ID em origens externas são
+// ignorados com um aviso; nas internas são erro de build.
+type CatalogSource struct {
+	Name     string
+	External bool
+	Load     func() ([]inbound.BoosterUseCase, error)
+}
+
+// UnavailableBooster é um booster conhecido que não pode ser usado nesta máquina
+type UnavailableBooster struct {
+	Booster inbound.BoosterUseCase
+	Reason  entities.UnavailableReason
+}
+
+// implementer é implementado pelos boosters que podem não ter executor na plataforma atual
+type implementer interface {
+	Implemented() bool
+}
+
+// Catalog registra os boosters de todas as origens no processador, filtrando
+// pela plataforma atual e guardando o motivo dos que ficaram de fora
+type Catalog struct {
+	platform    entities.Platform
+	mu          sync.RWMutex
+	sources     map[string]string
+	unavailable []UnavailableBooster
+	logger      *logger.CustomLogger
+}
+
+func NewCatalog(platform entities.Platform) *Catalog {
+	return &Catalog{
+		platform: platform,
+		sources:  make(map[string]string),
+		logger:   logger.NewCustomLogger("[BoosterCatalog]"),
+	}
+}
+
+// Register carrega as origens em ordem; a primeira origem a declarar um ID fica com ele
+func (c *Catalog) Register(processor *BoosterProcessor, sources ...CatalogSource) error {
+	for _, source := range sources {
+		boosters, err := source.Load()
+		if err != nil {
+			return fmt.Errorf("failed to load %s boosters: %w", source.Name, err)
+		}
+		for _, b := range boosters {
+			if b == nil {
+				continue
+			}
+			if err := c.add(processor, source.Name, b); err != nil {
+				if source.External && errors.Is(err, ErrDuplicateBooster) {
+					c.logger.Warnf("skipping %s booster: %v", source.Name, err)
+					continue
+				}
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Catalog) add(processor *BoosterProcessor, source string, b inbound.BoosterUseCase) error {
+	entity := b.GetEntity()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if owner, exists := c.sources[entity.ID]; exists {
+		return fmt.Errorf("%w: %s (from %s, already provided by %s)", ErrDuplicateBooster, entity.ID, source, owner)
+	}
+
+	switch {
+	case !entity.SupportsPlatform(c.platform):
+		c.unavailable = append(c.unavailable, UnavailableBooster{Booster: b, Reason: entities.UnavailableUnsupportedPlatform})
+	case !implemented(b):
+		c.unavailable = append(c.unavailable, UnavailableBooster{Booster: b, Reason: entities.UnavailableNotImplemented})
+	default:
+		if err := processor.RegisterBooster(b); err != nil {
+			return err
+		}
+	}
+	c.sources[entity.ID] = source
+	return nil
+}
+
+// Unavailable retorna os boosters que não foram registrados e o motivo
+func (c *Catalog) Unavailable() []UnavailableBooster {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]UnavailableBooster(nil), c.unavailable...)
+}
+
+func implemented(b inbound.BoosterUseCase) bool {
+	if i, ok := b.(implementer); ok {
+		return i.Implemented()
+	}
+	return true
+}
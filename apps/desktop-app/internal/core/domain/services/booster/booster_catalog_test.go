@@ -0,0 +1,100 @@
+package booster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/oLenador/mulltbost/internal/core/domain/services/i18n"
+)
+
+// unimplementedBooster simula um booster sem executor na plataforma atual
+type unimplementedBooster struct{ *testBooster }
+
+func (unimplementedBooster) Implemented() bool { return false }
+
+func catalogSource(name string, external bool, boosters ...inbound.BoosterUseCase) CatalogSource {
+	return CatalogSource{
+		Name:     name,
+		External: external,
+		Load:     func() ([]inbound.BoosterUseCase, error) { return boosters, nil },
+	}
+}
+
+func TestCatalog_FiltersByPlatformAndImplementation(t *testing.T) {
+	linux := []entities.Platform{entities.PlatformLinux}
+	windows := []entities.Platform{entities.PlatformWindows}
+
+	proc := NewBoosterProcessor(nil)
+	catalog := NewCatalog(entities.PlatformLinux)
+	err := catalog.Register(proc, catalogSource("builtin", false,
+		&testBooster{id: "native", platforms: linux},
+		&testBooster{id: "windows_only", platforms: windows},
+		unimplementedBooster{&testBooster{id: "pending", platforms: linux}},
+		nil,
+	))
+	require.NoError(t, err)
+
+	_, registered := proc.GetBooster("native")
+	assert.True(t, registered)
+	_, registered = proc.GetBooster("windows_only")
+	assert.False(t, registered)
+
+	reasons := map[string]entities.UnavailableReason{}
+	for _, unavailable := range catalog.Unavailable() {
+		reasons[unavailable.Booster.GetEntity().ID] = unavailable.Reason
+	}
+	assert.Equal(t, map[string]entities.UnavailableReason{
+		"windows_only": entities.UnavailableUnsupportedPlatform,
+		"pending":      entities.UnavailableNotImplemented,
+	}, reasons)
+}
+
+func TestCatalog_RejectsDuplicateIDs(t *testing.T) {
+	linux := []entities.Platform{entities.PlatformLinux}
+
+	// Entre origens internas, um ID repetido é erro
+	err := NewCatalog(entities.PlatformLinux).Register(NewBoosterProcessor(nil),
+		catalogSource("connection", false, &testBooster{id: "a", platforms: linux}),
+		catalogSource("precision", false, &testBooster{id: "a", platforms: linux}),
+	)
+	assert.ErrorIs(t, err, ErrDuplicateBooster)
+
+	// Origens externas não substituem um booster já registrado, mesmo indisponível
+	builtin := &testBooster{id: "a", platforms: []entities.Platform{entities.PlatformWindows}}
+	proc := NewBoosterProcessor(nil)
+	catalog := NewCatalog(entities.PlatformLinux)
+	err = catalog.Register(proc,
+		catalogSource("connection", false, builtin),
+		catalogSource("plugin", true, &testBooster{id: "a", platforms: linux}, &testBooster{id: "b", platforms: linux}),
+	)
+	require.NoError(t, err)
+	_, registered := proc.GetBooster("a")
+	assert.False(t, registered)
+	_, registered = proc.GetBooster("b")
+	assert.True(t, registered)
+	require.Len(t, catalog.Unavailable(), 1)
+	assert.Same(t, builtin, catalog.Unavailable()[0].Booster)
+
+	// O processador também recusa IDs repetidos
+	assert.ErrorIs(t, proc.RegisterBooster(&testBooster{id: "b"}), ErrDuplicateBooster)
+}
+
+func TestGetAvailableBoosters_ListsUnavailableWithReason(t *testing.T) {
+	catalog := NewCatalog(entities.PlatformLinux)
+	proc := NewBoosterProcessor(nil)
+	require.NoError(t, catalog.Register(proc, catalogSource("builtin", false,
+		&testBooster{id: "windows_only", platforms: []entities.Platform{entities.PlatformWindows}},
+	)))
+	service := &Service{processor: proc, catalog: catalog}
+
+	boosters := service.GetAvailableBoosters(context.Background(), i18n.English)
+	require.Len(t, boosters, 1)
+	assert.Equal(t, "windows_only", boosters[0].ID)
+	assert.False(t, boosters[0].Available)
+	assert.Equal(t, entities.UnavailableUnsupportedPlatform, boosters[0].UnavailableReason)
+}
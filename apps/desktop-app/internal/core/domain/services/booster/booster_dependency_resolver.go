@@ -0,0 +1,500 @@
+package booster
+
+import (
+	"context"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// DependencyPolicy define como o resolver trata dependências ausentes e conflitos
+type DependencyPolicy struct {
+	// IncludeMissingDependencies inclui no plano as dependências não aplicadas
+	// (e, na reversão, os dependentes aplicados) em vez de recusar o booster
+	IncludeMissingDependencies bool
+	// RevertConflicting reverte automaticamente boosters aplicados que conflitam
+	// em vez de recusar o booster solicitado
+	RevertConflicting bool
+}
+
+// PlannedStep é uma operação do plano; DependsOn lista os boosters do mesmo
+// plano que precisam terminar antes dela
+type PlannedStep struct {
+	BoosterID string
+	Operation entities.BoosterOperationType
+	DependsOn []string
+	Requested bool
+}
+
+// BatchPlan é o resultado do planejamento: passos ordenados e boosters recusados
+type BatchPlan struct {
+	Steps  []PlannedStep
+	Errors map[string]error
+}
+
+func newBatchPlan() *BatchPlan {
+	return &BatchPlan{
+		Steps:  make([]PlannedStep, 0),
+		Errors: make(map[string]error),
+	}
+}
+
+func (bp *BatchPlan) reject(err *entities.BoosterValidationError) {
+	if _, exists := bp.Errors[err.BoosterID]; !exists {
+		bp.Errors[err.BoosterID] = err
+	}
+}
+
+func (bp *BatchPlan) isRejected(boosterID string) bool {
+	_, exists := bp.Errors[boosterID]
+	return exists
+}
+
+// DependencyResolver ordena operações em lote respeitando Dependencies e Conflicts
+type DependencyResolver struct {
+	processor *BoosterProcessor
+	policy    DependencyPolicy
+}
+
+func NewDependencyResolver(processor *BoosterProcessor, policy DependencyPolicy) *DependencyResolver {
+	return &DependencyResolver{
+		processor: processor,
+		policy:    policy,
+	}
+}
+
+// planGraph guarda os nós na ordem em que entraram no plano e as arestas
+// "precisa vir antes" entre eles
+type planGraph struct {
+	nodes     []string
+	index     map[string]int
+	requested map[string]bool
+	before    map[string][]string
+}
+
+func newPlanGraph() *planGraph {
+	return &planGraph{
+		nodes:     make([]string, 0),
+		index:     make(map[string]int),
+		requested: make(map[string]bool),
+		before:    make(map[string][]string),
+	}
+}
+
+func (g *planGraph) add(boosterID string, requested bool) {
+	if _, exists := g.index[boosterID]; exists {
+		return
+	}
+	g.index[boosterID] = len(g.nodes)
+	g.nodes = append(g.nodes, boosterID)
+	g.requested[boosterID] = requested
+}
+
+func (g *planGraph) has(boosterID string) bool {
+	_, exists := g.index[boosterID]
+	return exists
+}
+
+func (g *planGraph) addEdge(first, then string) {
+	g.before[then] = append(g.before[then], first)
+}
+
+// appliedCache evita consultar o repositório de rollback várias vezes por plano
+type appliedCache struct {
+	processor *BoosterProcessor
+	values    map[string]bool
+}
+
+func newAppliedCache(processor *BoosterProcessor) *appliedCache {
+	return &appliedCache{
+		processor: processor,
+		values:    make(map[string]bool),
+	}
+}
+
+func (c *appliedCache) isApplied(ctx context.Context, boosterID string) bool {
+	if applied, exists := c.values[boosterID]; exists {
+		return applied
+	}
+	applied, err := c.processor.IsApplied(ctx, boosterID)
+	if err != nil {
+		applied = false
+	}
+	c.values[boosterID] = applied
+	return applied
+}
+
+// PlanApply ordena topologicamente a aplicação dos boosters, incluindo dependências
+// ausentes e reversões de conflitos conforme a política
+func (r *DependencyResolver) PlanApply(ctx context.Context, ids []string) *BatchPlan {
+	return r.planApply(ctx, ids, newAppliedCache(r.processor))
+}
+
+func (r *DependencyResolver) planApply(ctx context.Context, ids []string, applied *appliedCache) *BatchPlan {
+	plan := newBatchPlan()
+	graph := newPlanGraph()
+
+	for _, id := range ids {
+		if graph.has(id) || plan.isRejected(id) {
+			continue
+		}
+		if _, exists := r.processor.GetBooster(id); !exists {
+			plan.reject(entities.NewBoosterValidationError(id, entities.ValidationUnknownBooster, nil,
+				"booster %s is not registered", id))
+			continue
+		}
+		graph.add(id, true)
+	}
+
+	for i := 0; i < len(graph.nodes); i++ {
+		id := graph.nodes[i]
+		booster, _ := r.processor.GetBooster(id)
+		for _, depID := range booster.GetEntity().Dependencies {
+			if _, exists := r.processor.GetBooster(depID); !exists {
+				plan.reject(entities.NewBoosterValidationError(id, entities.ValidationUnknownDependency,
+					[]string{depID}, "dependency %s is not registered", depID))
+				continue
+			}
+			if graph.has(depID) {
+				graph.addEdge(depID, id)
+				continue
+			}
+			if applied.isApplied(ctx, depID) {
+				continue
+			}
+			if !r.policy.IncludeMissingDependencies {
+				plan.reject(entities.NewBoosterValidationError(id, entities.ValidationMissingDependency,
+					[]string{depID}, "dependency %s is not applied", depID))
+				continue
+			}
+			graph.add(depID, false)
+			graph.addEdge(depID, id)
+		}
+	}
+
+	r.rejectCycles(plan, graph)
+
+	for _, id := range graph.nodes {
+		if plan.isRejected(id) {
+			continue
+		}
+		if err := r.processor.validateExecution(ctx, id, entities.ApplyOperationType); err != nil {
+			plan.reject(entities.NewBoosterValidationError(id, entities.ValidationFailed, nil, "%s", err.Error()))
+		}
+	}
+
+	accepted := make(map[string]bool)
+	applySteps := make([]PlannedStep, 0)
+	revertSteps := make([]PlannedStep, 0)
+	reverting := make(map[string]bool)
+
+	for _, id := range topologicalOrder(graph, plan) {
+		if dep, failed := firstRejected(graph.before[id], plan); failed {
+			plan.reject(entities.NewBoosterValidationError(id, entities.ValidationMissingDependency,
+				[]string{dep}, "dependency %s was rejected", dep))
+			continue
+		}
+
+		dependsOn := append([]string{}, graph.before[id]...)
+		var pendingReverts []PlannedStep
+		rejected := false
+
+		for _, conflictID := range r.processor.conflictingIDs(id) {
+			if accepted[conflictID] {
+				plan.reject(entities.NewBoosterValidationError(id, entities.ValidationConflict,
+					[]string{conflictID}, "conflicts with %s in the same batch", conflictID))
+				rejected = true
+				break
+			}
+			if !applied.isApplied(ctx, conflictID) {
+				continue
+			}
+			if !r.policy.RevertConflicting {
+				plan.reject(entities.NewBoosterValidationError(id, entities.ValidationConflict,
+					[]string{conflictID}, "conflicts with applied booster %s", conflictID))
+				rejected = true
+				break
+			}
+
+			revertPlan := r.planRevert(ctx, []string{conflictID}, applied, false)
+			if err, failed := revertPlan.Errors[conflictID]; failed {
+				plan.reject(entities.NewBoosterValidationError(id, entities.ValidationConflict,
+					[]string{conflictID}, "conflicting booster %s cannot be reverted: %v", conflictID, err))
+				rejected = true
+				break
+			}
+			pendingReverts = append(pendingReverts, revertPlan.Steps...)
+			dependsOn = append(dependsOn, conflictID)
+		}
+
+		if rejected {
+			continue
+		}
+
+		for _, step := range pendingReverts {
+			if !reverting[step.BoosterID] {
+				reverting[step.BoosterID] = true
+				revertSteps = append(revertSteps, step)
+			}
+		}
+		accepted[id] = true
+		applySteps = append(applySteps, PlannedStep{
+			BoosterID: id,
+			Operation: entities.ApplyOperationType,
+			DependsOn: dependsOn,
+			Requested: graph.requested[id],
+		})
+	}
+
+	plan.Steps = append(revertSteps, applySteps...)
+	return plan
+}
+
+// PlanRestore monta o plano que deixa aplicados exatamente os boosters de target.
+// As reversões vêm primeiro e as aplicações esperam por elas; conflitos e
+// dependências das aplicações consideram os boosters revertidos no plano.
+func (r *DependencyResolver) PlanRestore(ctx context.Context, target []string) (*BatchPlan, error) {
+	states, err := r.processor.GetAppliedStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool, len(target))
+	for _, id := range target {
+		wanted[id] = true
+	}
+	applied := newAppliedCache(r.processor)
+	revertIDs := make([]string, 0)
+	for _, state := range states {
+		applied.values[state.ID] = true
+		if !wanted[state.ID] {
+			revertIDs = append(revertIDs, state.ID)
+		}
+	}
+
+	plan := r.planRevert(ctx, revertIDs, applied, true)
+	reverted := make([]string, 0, len(plan.Steps))
+	for _, step := range plan.Steps {
+		applied.values[step.BoosterID] = false
+		reverted = append(reverted, step.BoosterID)
+	}
+
+	// A queue guarda uma operação por booster: um dependente do alvo revertido
+	// junto com a sua dependência não é reaplicado no mesmo plano
+	applyIDs := make([]string, 0, len(target))
+	for _, id := range target {
+		if !applied.isApplied(ctx, id) && !containsString(reverted, id) {
+			applyIDs = append(applyIDs, id)
+		}
+	}
+
+	applyPlan := r.planApply(ctx, applyIDs, applied)
+	for id, err := range applyPlan.Errors {
+		plan.Errors[id] = err
+	}
+	for _, step := range applyPlan.Steps {
+		if step.Operation == entities.ApplyOperationType {
+			step.DependsOn = append(append([]string{}, step.DependsOn...), reverted...)
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+	return plan, nil
+}
+
+// PlanRevert ordena a reversão dos boosters revertendo dependentes antes dos pré-requisitos
+func (r *DependencyResolver) PlanRevert(ctx context.Context, ids []string) *BatchPlan {
+	return r.planRevert(ctx, ids, newAppliedCache(r.processor), true)
+}
+
+func (r *DependencyResolver) planRevert(ctx context.Context, ids []string, applied *appliedCache, requested bool) *BatchPlan {
+	plan := newBatchPlan()
+	graph := newPlanGraph()
+
+	for _, id := range ids {
+		if graph.has(id) || plan.isRejected(id) {
+			continue
+		}
+		if _, exists := r.processor.GetBooster(id); !exists {
+			plan.reject(entities.NewBoosterValidationError(id, entities.ValidationUnknownBooster, nil,
+				"booster %s is not registered", id))
+			continue
+		}
+		graph.add(id, requested)
+	}
+
+	for i := 0; i < len(graph.nodes); i++ {
+		id := graph.nodes[i]
+		for _, dependentID := range r.processor.dependentIDs(id) {
+			if graph.has(dependentID) {
+				graph.addEdge(dependentID, id)
+				continue
+			}
+			if !applied.isApplied(ctx, dependentID) {
+				continue
+			}
+			if !r.policy.IncludeMissingDependencies {
+				plan.reject(entities.NewBoosterValidationError(id, entities.ValidationDependentApplied,
+					[]string{dependentID}, "applied booster %s depends on it", dependentID))
+				continue
+			}
+			graph.add(dependentID, false)
+			graph.addEdge(dependentID, id)
+		}
+	}
+
+	r.rejectCycles(plan, graph)
+
+	for _, id := range graph.nodes {
+		if plan.isRejected(id) {
+			continue
+		}
+		if err := r.processor.validateExecution(ctx, id, entities.RevertOperationType); err != nil {
+			plan.reject(entities.NewBoosterValidationError(id, entities.ValidationFailed, nil, "%s", err.Error()))
+		}
+	}
+
+	for _, id := range topologicalOrder(graph, plan) {
+		if dependentID, failed := firstRejected(graph.before[id], plan); failed && applied.isApplied(ctx, dependentID) {
+			plan.reject(entities.NewBoosterValidationError(id, entities.ValidationDependentApplied,
+				[]string{dependentID}, "applied booster %s depends on it and cannot be reverted", dependentID))
+			continue
+		}
+		// dependentes recusados que não estão aplicados não bloqueiam a reversão
+		dependsOn := make([]string, 0, len(graph.before[id]))
+		for _, dependentID := range graph.before[id] {
+			if !plan.isRejected(dependentID) {
+				dependsOn = append(dependsOn, dependentID)
+			}
+		}
+		plan.Steps = append(plan.Steps, PlannedStep{
+			BoosterID: id,
+			Operation: entities.RevertOperationType,
+			DependsOn: dependsOn,
+			Requested: graph.requested[id],
+		})
+	}
+
+	return plan
+}
+
+// rejectCycles recusa todos os boosters que participam de um ciclo no grafo
+func (r *DependencyResolver) rejectCycles(plan *BatchPlan, graph *planGraph) {
+	for _, cycle := range findCycles(graph) {
+		for _, id := range cycle {
+			plan.reject(entities.NewBoosterValidationError(id, entities.ValidationDependencyCycle,
+				cycle, "dependency cycle detected: %v", cycle))
+		}
+	}
+}
+
+func firstRejected(ids []string, plan *BatchPlan) (string, bool) {
+	for _, id := range ids {
+		if plan.isRejected(id) {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// topologicalOrder aplica Kahn sobre os nós não recusados, mantendo a ordem de
+// entrada como desempate para que o plano seja determinístico
+func topologicalOrder(graph *planGraph, plan *BatchPlan) []string {
+	inDegree := make(map[string]int)
+	next := make(map[string][]string)
+	for _, id := range graph.nodes {
+		if plan.isRejected(id) {
+			continue
+		}
+		inDegree[id] += 0
+		for _, first := range graph.before[id] {
+			if plan.isRejected(first) {
+				continue
+			}
+			inDegree[id]++
+			next[first] = append(next[first], id)
+		}
+	}
+
+	order := make([]string, 0, len(inDegree))
+	done := make(map[string]bool)
+	for len(order) < len(inDegree) {
+		progressed := false
+		for _, id := range graph.nodes {
+			if done[id] || plan.isRejected(id) || inDegree[id] > 0 {
+				continue
+			}
+			done[id] = true
+			order = append(order, id)
+			for _, then := range next[id] {
+				inDegree[then]--
+			}
+			progressed = true
+			break
+		}
+		if !progressed {
+			break
+		}
+	}
+	return order
+}
+
+// findCycles retorna os componentes fortemente conexos com ciclo (Tarjan)
+func findCycles(graph *planGraph) [][]string {
+	index := 0
+	indices := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	stack := make([]string, 0)
+	cycles := make([][]string, 0)
+
+	var visit func(id string)
+	visit = func(id string) {
+		indices[id] = index
+		lowLink[id] = index
+		index++
+		stack = append(stack, id)
+		onStack[id] = true
+
+		for _, first := range graph.before[id] {
+			if _, seen := indices[first]; !seen {
+				visit(first)
+				lowLink[id] = min(lowLink[id], lowLink[first])
+			} else if onStack[first] {
+				lowLink[id] = min(lowLink[id], indices[first])
+			}
+		}
+
+		if lowLink[id] != indices[id] {
+			return
+		}
+
+		component := make([]string, 0)
+		for {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[top] = false
+			component = append(component, top)
+			if top == id {
+				break
+			}
+		}
+		if len(component) > 1 || containsString(graph.before[id], id) {
+			cycles = append(cycles, component)
+		}
+	}
+
+	for _, id := range graph.nodes {
+		if _, seen := indices[id]; !seen {
+			visit(id)
+		}
+	}
+	return cycles
+}
+
+func containsString(values []string, target string) bool {
+	for _, value := range values {
+		if value == target {
+			return true
+		}
+	}
+	return false
+}
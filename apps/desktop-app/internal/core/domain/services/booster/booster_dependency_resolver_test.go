@@ -0,0 +1,220 @@
+package booster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// helper para registrar boosters aplicáveis e reversíveis
+func newResolverProcessor(t *testing.T, boosters ...*testBooster) *BoosterProcessor {
+	rr, _ := setupRollbackRepoForTest(t)
+	proc := NewBoosterProcessor(rr)
+	for _, b := range boosters {
+		b.version = "v1"
+		b.canApply = true
+		b.canRevert = true
+		require.NoError(t, proc.RegisterBooster(b))
+	}
+	return proc
+}
+
+func markApplied(t *testing.T, proc *BoosterProcessor, ids ...string) {
+	for _, id := range ids {
+		require.NoError(t, proc.rollbackRepo.Save(context.Background(), &entities.BoosterRollbackState{
+			ID:      id,
+			Applied: true,
+			Status:  entities.ExecutionApplied,
+			Version: "v1",
+		}))
+	}
+}
+
+func stepIDs(plan *BatchPlan) []string {
+	ids := make([]string, 0, len(plan.Steps))
+	for _, step := range plan.Steps {
+		ids = append(ids, step.BoosterID)
+	}
+	return ids
+}
+
+func validationCode(t *testing.T, plan *BatchPlan, id string) entities.BoosterValidationCode {
+	err, ok := plan.Errors[id]
+	require.True(t, ok, "esperava erro de validação para %s", id)
+	verr, ok := err.(*entities.BoosterValidationError)
+	require.True(t, ok)
+	return verr.Code
+}
+
+func TestPlanApply_OrdersDependenciesFirst(t *testing.T) {
+	proc := newResolverProcessor(t,
+		&testBooster{id: "c", deps: []string{"b"}},
+		&testBooster{id: "b", deps: []string{"a"}},
+		&testBooster{id: "a"},
+	)
+	resolver := NewDependencyResolver(proc, DependencyPolicy{})
+
+	plan := resolver.PlanApply(context.Background(), []string{"c", "b", "a"})
+
+	assert.Empty(t, plan.Errors)
+	assert.Equal(t, []string{"a", "b", "c"}, stepIDs(plan))
+	assert.Equal(t, []string{"b"}, plan.Steps[2].DependsOn)
+}
+
+func TestPlanApply_IncludesMissingDependencies(t *testing.T) {
+	proc := newResolverProcessor(t,
+		&testBooster{id: "b", deps: []string{"a"}},
+		&testBooster{id: "a"},
+	)
+	resolver := NewDependencyResolver(proc, DependencyPolicy{IncludeMissingDependencies: true})
+
+	plan := resolver.PlanApply(context.Background(), []string{"b"})
+
+	assert.Empty(t, plan.Errors)
+	require.Equal(t, []string{"a", "b"}, stepIDs(plan))
+	assert.False(t, plan.Steps[0].Requested)
+	assert.True(t, plan.Steps[1].Requested)
+}
+
+func TestPlanApply_RejectsMissingDependency(t *testing.T) {
+	proc := newResolverProcessor(t,
+		&testBooster{id: "b", deps: []string{"a"}},
+		&testBooster{id: "a"},
+	)
+	resolver := NewDependencyResolver(proc, DependencyPolicy{})
+
+	plan := resolver.PlanApply(context.Background(), []string{"b"})
+
+	assert.Empty(t, plan.Steps)
+	assert.Equal(t, entities.ValidationMissingDependency, validationCode(t, plan, "b"))
+}
+
+func TestPlanApply_SkipsAppliedDependency(t *testing.T) {
+	proc := newResolverProcessor(t,
+		&testBooster{id: "b", deps: []string{"a"}},
+		&testBooster{id: "a"},
+	)
+	markApplied(t, proc, "a")
+	resolver := NewDependencyResolver(proc, DependencyPolicy{})
+
+	plan := resolver.PlanApply(context.Background(), []string{"b"})
+
+	assert.Empty(t, plan.Errors)
+	assert.Equal(t, []string{"b"}, stepIDs(plan))
+}
+
+func TestPlanApply_UnknownBoosterAndDependency(t *testing.T) {
+	proc := newResolverProcessor(t,
+		&testBooster{id: "b", deps: []string{"ghost"}},
+		&testBooster{id: "c"},
+	)
+	resolver := NewDependencyResolver(proc, DependencyPolicy{IncludeMissingDependencies: true})
+
+	plan := resolver.PlanApply(context.Background(), []string{"missing", "b", "c"})
+
+	assert.Equal(t, entities.ValidationUnknownBooster, validationCode(t, plan, "missing"))
+	assert.Equal(t, entities.ValidationUnknownDependency, validationCode(t, plan, "b"))
+	assert.Equal(t, []string{"c"}, stepIDs(plan))
+}
+
+func TestPlanApply_RejectsCycles(t *testing.T) {
+	proc := newResolverProcessor(t,
+		&testBooster{id: "a", deps: []string{"b"}},
+		&testBooster{id: "b", deps: []string{"a"}},
+		&testBooster{id: "c", deps: []string{"a"}},
+		&testBooster{id: "d"},
+	)
+	resolver := NewDependencyResolver(proc, DependencyPolicy{IncludeMissingDependencies: true})
+
+	plan := resolver.PlanApply(context.Background(), []string{"c", "d"})
+
+	assert.Equal(t, entities.ValidationDependencyCycle, validationCode(t, plan, "a"))
+	assert.Equal(t, entities.ValidationDependencyCycle, validationCode(t, plan, "b"))
+	// c depende do ciclo e também é recusado
+	assert.Equal(t, entities.ValidationMissingDependency, validationCode(t, plan, "c"))
+	assert.Equal(t, []string{"d"}, stepIDs(plan))
+}
+
+func TestPlanApply_ConflictInSameBatch(t *testing.T) {
+	proc := newResolverProcessor(t,
+		&testBooster{id: "a", conflicts: []string{"b"}},
+		&testBooster{id: "b"},
+	)
+	resolver := NewDependencyResolver(proc, DependencyPolicy{})
+
+	plan := resolver.PlanApply(context.Background(), []string{"a", "b"})
+
+	assert.Equal(t, []string{"a"}, stepIDs(plan))
+	assert.Equal(t, entities.ValidationConflict, validationCode(t, plan, "b"))
+}
+
+func TestPlanApply_ConflictWithAppliedBooster(t *testing.T) {
+	proc := newResolverProcessor(t,
+		&testBooster{id: "a", conflicts: []string{"b"}},
+		&testBooster{id: "b"},
+	)
+	markApplied(t, proc, "b")
+
+	refuse := NewDependencyResolver(proc, DependencyPolicy{}).PlanApply(context.Background(), []string{"a"})
+	assert.Empty(t, refuse.Steps)
+	assert.Equal(t, entities.ValidationConflict, validationCode(t, refuse, "a"))
+
+	revert := NewDependencyResolver(proc, DependencyPolicy{RevertConflicting: true}).PlanApply(context.Background(), []string{"a"})
+	assert.Empty(t, revert.Errors)
+	require.Len(t, revert.Steps, 2)
+	assert.Equal(t, "b", revert.Steps[0].BoosterID)
+	assert.Equal(t, entities.RevertOperationType, revert.Steps[0].Operation)
+	assert.Equal(t, "a", revert.Steps[1].BoosterID)
+	assert.Equal(t, []string{"b"}, revert.Steps[1].DependsOn)
+}
+
+func TestPlanRevert_RevertsDependentsFirst(t *testing.T) {
+	proc := newResolverProcessor(t,
+		&testBooster{id: "a"},
+		&testBooster{id: "b", deps: []string{"a"}},
+	)
+	markApplied(t, proc, "a", "b")
+	resolver := NewDependencyResolver(proc, DependencyPolicy{})
+
+	plan := resolver.PlanRevert(context.Background(), []string{"a", "b"})
+
+	assert.Empty(t, plan.Errors)
+	assert.Equal(t, []string{"b", "a"}, stepIDs(plan))
+	assert.Equal(t, []string{"b"}, plan.Steps[1].DependsOn)
+}
+
+func TestPlanRevert_AppliedDependent(t *testing.T) {
+	proc := newResolverProcessor(t,
+		&testBooster{id: "a"},
+		&testBooster{id: "b", deps: []string{"a"}},
+	)
+	markApplied(t, proc, "a", "b")
+
+	refuse := NewDependencyResolver(proc, DependencyPolicy{}).PlanRevert(context.Background(), []string{"a"})
+	assert.Empty(t, refuse.Steps)
+	assert.Equal(t, entities.ValidationDependentApplied, validationCode(t, refuse, "a"))
+
+	cascade := NewDependencyResolver(proc, DependencyPolicy{IncludeMissingDependencies: true}).PlanRevert(context.Background(), []string{"a"})
+	assert.Empty(t, cascade.Errors)
+	assert.Equal(t, []string{"b", "a"}, stepIDs(cascade))
+}
+
+func TestValidateBoosterOperation_Relations(t *testing.T) {
+	proc := newResolverProcessor(t,
+		&testBooster{id: "a"},
+		&testBooster{id: "b", deps: []string{"a"}},
+	)
+	ctx := context.Background()
+
+	err := proc.ValidateBoosterOperation(ctx, "b", entities.ApplyOperationType)
+	require.Error(t, err)
+	assert.IsType(t, &entities.BoosterValidationError{}, err)
+
+	markApplied(t, proc, "a", "b")
+	require.NoError(t, proc.ValidateBoosterOperation(ctx, "b", entities.ApplyOperationType))
+	require.Error(t, proc.ValidateBoosterOperation(ctx, "a", entities.RevertOperationType))
+}
@@ -0,0 +1,165 @@
+package booster
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/wailsapp/wails/lib/logger"
+)
+
+// DriftChecker verifica periodicamente se os boosters aplicados continuam em
+// efeito. Boosters cujo efeito foi desfeito (por outro programa, atualização do
+// sistema, etc.) são marcados como drifted e, conforme a política do booster,
+// reaplicados pela fila.
+type DriftChecker struct {
+	processor     *BoosterProcessor
+	queueManager  *Manager
+	eventEmitter  EventEmitter
+	interval      time.Duration
+	defaultPolicy entities.DriftPolicy
+	stopCh        chan struct{}
+	wg            sync.WaitGroup
+	startOnce     sync.Once
+	stopOnce      sync.Once
+	logger        *logger.CustomLogger
+}
+
+// NewDriftChecker cria o verificador; a política padrão vale para boosters sem política própria
+func NewDriftChecker(
+	processor *BoosterProcessor,
+	queueManager *Manager,
+	eventEmitter EventEmitter,
+	interval time.Duration,
+	defaultPolicy entities.DriftPolicy,
+) *DriftChecker {
+	if defaultPolicy == "" {
+		defaultPolicy = entities.DriftPolicyNotify
+	}
+	return &DriftChecker{
+		processor:     processor,
+		queueManager:  queueManager,
+		eventEmitter:  eventEmitter,
+		interval:      interval,
+		defaultPolicy: defaultPolicy,
+		stopCh:        make(chan struct{}),
+		logger:        logger.NewCustomLogger("[DriftChecker]"),
+	}
+}
+
+// Start inicia a verificação periódica; um intervalo <= 0 desativa o verificador
+func (d *DriftChecker) Start() {
+	if d.interval <= 0 {
+		return
+	}
+	d.startOnce.Do(func() {
+		d.wg.Add(1)
+		go d.loop()
+	})
+}
+
+// Stop interrompe a verificação periódica e aguarda a rodada em andamento
+func (d *DriftChecker) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stopCh)
+	})
+	d.wg.Wait()
+}
+
+func (d *DriftChecker) loop() {
+	defer d.wg.Done()
+
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.stopCh:
+			return
+		case <-ticker.C:
+			if _, err := d.CheckAll(context.Background()); err != nil {
+				d.logger.Errorf("drift check failed: %v", err)
+			}
+		}
+	}
+}
+
+// CheckAll verifica todos os boosters aplicados e retorna o resultado de cada um
+func (d *DriftChecker) CheckAll(ctx context.Context) ([]entities.BoostVerifyResult, error) {
+	states, err := d.processor.GetAppliedStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]entities.BoostVerifyResult, 0, len(states))
+	for _, state := range states {
+		if ctx.Err() != nil {
+			return results, ctx.Err()
+		}
+
+		result, err := d.checkBooster(ctx, state.ID)
+		if err != nil {
+			d.logger.Errorf("failed to verify booster %s: %v", state.ID, err)
+			continue
+		}
+		if result != nil {
+			results = append(results, *result)
+		}
+	}
+	return results, nil
+}
+
+// checkBooster verifica um booster aplicado e aplica a política de drift.
+// Retorna nil se o booster foi ignorado.
+func (d *DriftChecker) checkBooster(ctx context.Context, boosterID string) (*entities.BoostVerifyResult, error) {
+	booster, exists := d.processor.GetBooster(boosterID)
+	if !exists {
+		return nil, nil
+	}
+
+	policy := booster.GetEntity().DriftPolicy
+	if policy == "" {
+		policy = d.defaultPolicy
+	}
+	if policy == entities.DriftPolicyIgnore {
+		return nil, nil
+	}
+
+	// Uma operação pendente vai alterar o estado; a verificação fica para a próxima rodada
+	if d.queueManager.IsInQueue(boosterID) {
+		return nil, nil
+	}
+
+	result, err := d.processor.ProcessVerify(ctx, boosterID)
+	if err != nil {
+		return nil, err
+	}
+	result.Policy = policy
+
+	changed, err := d.processor.UpdateDriftState(ctx, boosterID, !result.InEffect)
+	if err != nil {
+		return nil, err
+	}
+	if result.InEffect {
+		return result, nil
+	}
+
+	if policy == entities.DriftPolicyReapply {
+		operationID, err := d.queueManager.AddWithOptions(boosterID, entities.ApplyOperationType, QueueItemOptions{
+			Priority: entities.PriorityScheduled,
+		})
+		if err != nil {
+			d.logger.Errorf("failed to queue re-apply for %s: %v", boosterID, err)
+		} else {
+			result.ReapplyOperationID = operationID
+			d.eventEmitter.EmitQueued(boosterID, operationID, entities.ApplyOperationType, d.queueManager.Size())
+		}
+	}
+
+	// Notifica na transição para drifted e sempre que houver reaplicação
+	if changed || result.ReapplyOperationID != "" {
+		d.eventEmitter.EmitDrifted(boosterID, policy, result.Drifted, result.ReapplyOperationID)
+	}
+	return result, nil
+}
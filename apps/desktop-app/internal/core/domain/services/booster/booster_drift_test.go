@@ -0,0 +1,113 @@
+package booster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// helper para salvar um booster como aplicado com backup
+func saveAppliedWithBackup(t *testing.T, proc *BoosterProcessor, id string, backup entities.BackupData) {
+	require.NoError(t, proc.rollbackRepo.Save(context.Background(), &entities.BoosterRollbackState{
+		ID:         id,
+		Applied:    true,
+		Status:     entities.ExecutionApplied,
+		Version:    "v1",
+		BackupData: backup,
+	}))
+}
+
+func newTestDriftChecker(service *Service, emitter *recordingEmitter) *DriftChecker {
+	return NewDriftChecker(service.processor, service.queueManager, emitter, 0, entities.DriftPolicyNotify)
+}
+
+func TestDriftChecker_MarksDriftedAndRecovers(t *testing.T) {
+	b := &testBooster{id: "a", drifted: []entities.PlannedChange{
+		entities.NewPlannedChange("sysctl x", "1", "0", true),
+	}}
+	service, emitter := newTestService(t, b)
+	saveAppliedWithBackup(t, service.processor, "a", entities.BackupData{"k": "v"})
+	checker := newTestDriftChecker(service, emitter)
+	ctx := context.Background()
+
+	results, err := checker.CheckAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.False(t, results[0].InEffect)
+	assert.Equal(t, entities.DriftPolicyNotify, results[0].Policy)
+	assert.Empty(t, results[0].ReapplyOperationID)
+	assert.Equal(t, "a", <-emitter.drifted)
+
+	state, err := service.processor.GetRollbackState(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, entities.ExecutionDrifted, state.Status)
+	// continua aplicado para que a reversão use o backup original
+	assert.True(t, state.Applied)
+
+	// uma segunda rodada sem mudanças não notifica de novo
+	_, err = checker.CheckAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, emitter.drifted)
+
+	b.drifted = nil
+	results, err = checker.CheckAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.True(t, results[0].InEffect)
+
+	state, err = service.processor.GetRollbackState(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, entities.ExecutionApplied, state.Status)
+}
+
+func TestDriftChecker_ReappliesKeepingOriginalBackup(t *testing.T) {
+	b := &testBooster{
+		id:          "a",
+		driftPolicy: entities.DriftPolicyReapply,
+		execResult:  &entities.BoostApplyResult{Success: true, BackupData: map[string]interface{}{"k": "drifted"}},
+		drifted: []entities.PlannedChange{
+			entities.NewPlannedChange("sysctl x", "1", "0", true),
+		},
+	}
+	service, emitter := newTestService(t, b)
+	saveAppliedWithBackup(t, service.processor, "a", entities.BackupData{"k": "original"})
+	checker := newTestDriftChecker(service, emitter)
+	ctx := context.Background()
+
+	results, err := checker.CheckAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.NotEmpty(t, results[0].ReapplyOperationID)
+	assert.Equal(t, "a", <-emitter.drifted)
+
+	require.NoError(t, service.queueManager.AwaitOperation(ctx, results[0].ReapplyOperationID))
+
+	state, err := service.processor.GetRollbackState(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, entities.ExecutionApplied, state.Status)
+	assert.Equal(t, "original", state.BackupData["k"])
+}
+
+func TestDriftChecker_IgnorePolicyAndNotApplied(t *testing.T) {
+	ignored := &testBooster{id: "a", driftPolicy: entities.DriftPolicyIgnore, drifted: []entities.PlannedChange{
+		entities.NewPlannedChange("sysctl x", "1", "0", true),
+	}}
+	notApplied := &testBooster{id: "b", drifted: []entities.PlannedChange{
+		entities.NewPlannedChange("sysctl y", "1", "0", true),
+	}}
+	service, emitter := newTestService(t, ignored, notApplied)
+	saveAppliedWithBackup(t, service.processor, "a", nil)
+
+	results, err := newTestDriftChecker(service, emitter).CheckAll(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Empty(t, emitter.drifted)
+
+	state, err := service.processor.GetRollbackState(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Equal(t, entities.ExecutionApplied, state.Status)
+}
@@ -34,6 +34,15 @@ type EventDataBuilder struct {
 	totalCount   int
 	queuedCount  int
 	validationErrors map[string]error
+	transactional    bool
+	committed        bool
+	outcomes         []entities.BatchBoosterOutcome
+	rollbacks        []entities.BatchBoosterOutcome
+	driftPolicy      entities.DriftPolicy
+	driftedChanges   []entities.PlannedChange
+	attempt          entities.OperationAttempt
+	expiresAt        time.Time
+	health           entities.HealthStatus
 }
 
 func NewEventBuilder(eventManager *application.EventManager) *EventBuilder {
@@ -108,15 +117,54 @@ func (edb *EventDataBuilder) WithValidationErrors(validationErrors map[string]er
 	return edb
 }
 
+func (edb *EventDataBuilder) WithBatchResult(transactional, committed bool, outcomes, rollbacks []entities.BatchBoosterOutcome) *EventDataBuilder {
+	edb.transactional = transactional
+	edb.committed = committed
+	edb.outcomes = outcomes
+	edb.rollbacks = rollbacks
+	return edb
+}
+
+func (edb *EventDataBuilder) WithDrift(policy entities.DriftPolicy, drifted []entities.PlannedChange) *EventDataBuilder {
+	edb.driftPolicy = policy
+	edb.driftedChanges = drifted
+	return edb
+}
+
+func (edb *EventDataBuilder) WithAttempt(attempt entities.OperationAttempt) *EventDataBuilder {
+	edb.attempt = attempt
+	return edb
+}
+
+func (edb *EventDataBuilder) WithExpiry(expiresAt time.Time) *EventDataBuilder {
+	edb.expiresAt = expiresAt
+	return edb
+}
+
+func (edb *EventDataBuilder) WithHealth(health entities.HealthStatus) *EventDataBuilder {
+	edb.health = health
+	return edb
+}
+
 // Build constrói o evento baseado no tipo
 func (edb *EventDataBuilder) Build() *application.CustomEvent {
 	switch edb.eventType {
 	case entities.EventBatchQueued:
 		return edb.buildBatchEvent()
+	case entities.EventBatchCompleted:
+		return edb.buildBatchCompletedEvent()
 	case entities.EventQueued:
 		return edb.buildQueuedEvent()
 	case entities.EventCancelled:
 		return edb.buildCancelledEvent()
+	case entities.EventDrifted:
+		return edb.buildDriftedEvent()
+	case entities.EventAttempt:
+		return edb.buildAttemptEvent()
+	case entities.EventExpiring, entities.EventExpired:
+		return edb.buildExpiryEvent()
+	case entities.EventHealthChanged:
+		return edb.buildHealthEvent()
 	default:
 		return edb.buildBoosterEvent()
 	}
@@ -199,19 +247,139 @@ func (edb *EventDataBuilder) buildBatchEvent() *application.CustomEvent {
 	return event
 }
 
+// buildBatchCompletedEvent cria o evento final de lote com o resultado de cada booster
+func (edb *EventDataBuilder) buildBatchCompletedEvent() *application.CustomEvent {
+	edb.eventBuilder.logger.InfoFields("Creating Batch Completed Event", logger.Fields{
+		"batchID":   edb.batchID,
+		"operation": edb.operation,
+		"committed": edb.committed,
+		"outcomes":  edb.outcomes,
+		"rollbacks": edb.rollbacks,
+	})
+
+	return &application.CustomEvent{
+		Name: string(entities.EventBatchCompleted),
+		Data: events.BoosterBatchResultEvent{
+			EventType:     entities.EventBatchCompleted,
+			Timestamp:     time.Now(),
+			BatchID:       edb.batchID,
+			OperationType: edb.operation,
+			Transactional: edb.transactional,
+			Committed:     edb.committed,
+			Outcomes:      edb.outcomes,
+			Rollbacks:     edb.rollbacks,
+		},
+		Sender: "booster-service",
+	}
+}
+
+// buildDriftedEvent cria o evento de booster aplicado que deixou de estar em efeito
+func (edb *EventDataBuilder) buildDriftedEvent() *application.CustomEvent {
+	edb.eventBuilder.logger.InfoFields("Creating Drifted Event", logger.Fields{
+		"boosterID":   edb.boosterID,
+		"operationID": edb.operationID,
+		"policy":      edb.driftPolicy,
+		"drifted":     edb.driftedChanges,
+	})
+
+	return &application.CustomEvent{
+		Name: string(entities.EventDrifted),
+		Data: events.BoosterDriftEvent{
+			EventType:          entities.EventDrifted,
+			Timestamp:          time.Now(),
+			BoosterID:          edb.boosterID,
+			Status:             entities.ExecutionDrifted,
+			Policy:             edb.driftPolicy,
+			Drifted:            edb.driftedChanges,
+			ReapplyOperationID: edb.operationID,
+		},
+		Sender: "booster-service",
+	}
+}
+
+// buildExpiryEvent cria os eventos de booster por tempo limitado perto de expirar ou expirado
+func (edb *EventDataBuilder) buildExpiryEvent() *application.CustomEvent {
+	edb.eventBuilder.logger.InfoFields("Creating Expiry Event", logger.Fields{
+		"eventType":   edb.eventType,
+		"boosterID":   edb.boosterID,
+		"operationID": edb.operationID,
+		"expiresAt":   edb.expiresAt,
+	})
+
+	now := time.Now()
+	remaining := edb.expiresAt.Sub(now)
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return &application.CustomEvent{
+		Name: string(edb.eventType),
+		Data: events.BoosterExpiryEvent{
+			EventType:         edb.eventType,
+			Timestamp:         now,
+			BoosterID:         edb.boosterID,
+			ExpiresAt:         edb.expiresAt,
+			Remaining:         remaining,
+			RevertOperationID: edb.operationID,
+		},
+		Sender: "booster-service",
+	}
+}
+
+// buildHealthEvent cria o evento com a nova saúde do serviço
+func (edb *EventDataBuilder) buildHealthEvent() *application.CustomEvent {
+	edb.eventBuilder.logger.InfoFields("Creating Health Event", logger.Fields{
+		"isHealthy": edb.health.IsHealthy,
+		"issues":    edb.health.Issues,
+	})
+
+	return &application.CustomEvent{
+		Name: string(entities.EventHealthChanged),
+		Data: events.BoosterHealthEvent{
+			EventType: entities.EventHealthChanged,
+			Timestamp: time.Now(),
+			Health:    edb.health,
+		},
+		Sender: "booster-service",
+	}
+}
+
+// buildAttemptEvent cria o evento com o resultado de uma tentativa de execução
+func (edb *EventDataBuilder) buildAttemptEvent() *application.CustomEvent {
+	edb.eventBuilder.logger.InfoFields("Creating Attempt Event", logger.Fields{
+		"boosterID":   edb.boosterID,
+		"operationID": edb.operationID,
+		"attempt":     edb.attempt,
+	})
+
+	return &application.CustomEvent{
+		Name: string(entities.EventAttempt),
+		Data: events.BoosterAttemptEvent{
+			EventType:     entities.EventAttempt,
+			Timestamp:     time.Now(),
+			BoosterID:     edb.boosterID,
+			OperationID:   edb.operationID,
+			OperationType: edb.operation,
+			Attempt:       edb.attempt,
+		},
+		Sender: "booster-service",
+	}
+}
+
 // buildCancelledEvent cria evento de cancelamento usando createBoosterEvent
 func (edb *EventDataBuilder) buildCancelledEvent() *application.CustomEvent {
 	edb.eventBuilder.logger.InfoFields("Creating Cancelled Event", logger.Fields{
-		"boosterID": edb.boosterID,
-		"queueSize": edb.queueSize,
+		"boosterID":   edb.boosterID,
+		"operationID": edb.operationID,
+		"queueSize":   edb.queueSize,
 	})
 
 	// Usa createBoosterEvent como base
 	event := edb.eventBuilder.createBoosterEvent(
 		entities.EventCancelled,
 		edb.boosterID,
-		"", // operationID vazio para cancelamento
-		entities.BoosterOperationType(""), // operation vazio para cancelamento
+		edb.operationID,
+		edb.operation,
 		entities.ExecutionNotApplied,
 		nil,
 	)
@@ -383,10 +551,82 @@ func (eb *EventBuilder) EmitBatchQueued(
 	eb.eventManager.EmitEvent(event)
 }
 
-func (eb *EventBuilder) EmitCancelled(boosterID string, queueSize int) {
+func (eb *EventBuilder) EmitBatchCompleted(
+	batchID string,
+	operation entities.BoosterOperationType,
+	transactional, committed bool,
+	outcomes, rollbacks []entities.BatchBoosterOutcome,
+) {
+	event := eb.NewEventDataBuilder().
+		WithEventType(entities.EventBatchCompleted).
+		WithBatchID(batchID).
+		WithOperation(operation).
+		WithBatchResult(transactional, committed, outcomes, rollbacks).
+		Build()
+
+	eb.eventManager.EmitEvent(event)
+}
+
+func (eb *EventBuilder) EmitDrifted(boosterID string, policy entities.DriftPolicy, drifted []entities.PlannedChange, reapplyOperationID string) {
+	event := eb.NewEventDataBuilder().
+		WithEventType(entities.EventDrifted).
+		WithBoosterID(boosterID).
+		WithOperationID(reapplyOperationID).
+		WithDrift(policy, drifted).
+		Build()
+
+	eb.eventManager.EmitEvent(event)
+}
+
+func (eb *EventBuilder) EmitAttempt(boosterID, operationID string, operation entities.BoosterOperationType, attempt entities.OperationAttempt) {
+	event := eb.NewEventDataBuilder().
+		WithEventType(entities.EventAttempt).
+		WithBoosterID(boosterID).
+		WithOperationID(operationID).
+		WithOperation(operation).
+		WithAttempt(attempt).
+		Build()
+
+	eb.eventManager.EmitEvent(event)
+}
+
+func (eb *EventBuilder) EmitExpiring(boosterID string, expiresAt time.Time) {
+	event := eb.NewEventDataBuilder().
+		WithEventType(entities.EventExpiring).
+		WithBoosterID(boosterID).
+		WithExpiry(expiresAt).
+		Build()
+
+	eb.eventManager.EmitEvent(event)
+}
+
+func (eb *EventBuilder) EmitExpired(boosterID string, expiresAt time.Time, revertOperationID string) {
+	event := eb.NewEventDataBuilder().
+		WithEventType(entities.EventExpired).
+		WithBoosterID(boosterID).
+		WithOperationID(revertOperationID).
+		WithOperation(entities.RevertOperationType).
+		WithExpiry(expiresAt).
+		Build()
+
+	eb.eventManager.EmitEvent(event)
+}
+
+func (eb *EventBuilder) EmitHealthChanged(status entities.HealthStatus) {
+	event := eb.NewEventDataBuilder().
+		WithEventType(entities.EventHealthChanged).
+		WithHealth(status).
+		Build()
+
+	eb.eventManager.EmitEvent(event)
+}
+
+func (eb *EventBuilder) EmitCancelled(boosterID, operationID string, operation entities.BoosterOperationType, queueSize int) {
 	event := eb.NewEventDataBuilder().
 		WithEventType(entities.EventCancelled).
 		WithBoosterID(boosterID).
+		WithOperationID(operationID).
+		WithOperation(operation).
 		WithQueueSize(queueSize).
 		Build()
 	
@@ -1,6 +1,8 @@
 package booster
 
 import (
+	"time"
+
 	"github.com/oLenador/mulltbost/internal/core/domain/entities"
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
@@ -39,6 +41,30 @@ func (e *BoosterEventEmitter) EmitBatchQueued(batchID string, operation entities
 	e.builder.EmitBatchQueued(batchID, operation, totalCount, queuedCount, validationErrors, queueSize)
 }
 
-func (e *BoosterEventEmitter) EmitCancelled(boosterID string, queueSize int) {
-	e.builder.EmitCancelled(boosterID, queueSize)
+func (e *BoosterEventEmitter) EmitBatchCompleted(batchID string, operation entities.BoosterOperationType, transactional, committed bool, outcomes, rollbacks []entities.BatchBoosterOutcome) {
+	e.builder.EmitBatchCompleted(batchID, operation, transactional, committed, outcomes, rollbacks)
+}
+
+func (e *BoosterEventEmitter) EmitDrifted(boosterID string, policy entities.DriftPolicy, drifted []entities.PlannedChange, reapplyOperationID string) {
+	e.builder.EmitDrifted(boosterID, policy, drifted, reapplyOperationID)
+}
+
+func (e *BoosterEventEmitter) EmitAttempt(boosterID string, operationID string, operation entities.BoosterOperationType, attempt entities.OperationAttempt) {
+	e.builder.EmitAttempt(boosterID, operationID, operation, attempt)
+}
+
+func (e *BoosterEventEmitter) EmitExpiring(boosterID string, expiresAt time.Time) {
+	e.builder.EmitExpiring(boosterID, expiresAt)
+}
+
+func (e *BoosterEventEmitter) EmitExpired(boosterID string, expiresAt time.Time, revertOperationID string) {
+	e.builder.EmitExpired(boosterID, expiresAt, revertOperationID)
+}
+
+func (e *BoosterEventEmitter) EmitHealthChanged(status entities.HealthStatus) {
+	e.builder.EmitHealthChanged(status)
+}
+
+func (e *BoosterEventEmitter) EmitCancelled(boosterID, operationID string, operation entities.BoosterOperationType, queueSize int) {
+	e.builder.EmitCancelled(boosterID, operationID, operation, queueSize)
 }
\ No newline at end of file
@@ -0,0 +1,305 @@
+package booster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/wailsapp/wails/lib/logger"
+)
+
+// ExpiryStore persiste os timers dos boosters aplicados por tempo limitado
+type ExpiryStore interface {
+	Save(ctx context.Context, expiry *entities.BoosterExpiry) error
+	GetByBoosterID(ctx context.Context, boosterID string) (*entities.BoosterExpiry, error)
+	GetAll(ctx context.Context) ([]entities.BoosterExpiry, error)
+	Delete(ctx context.Context, boosterID string) error
+	MarkWarned(ctx context.Context, boosterID string, warnedAt time.Time) error
+}
+
+var ErrExpiryNotFound = errors.New("booster expiry not found")
+
+// ExpiryScheduler reverte pela fila os boosters cujo tempo de aplicação acabou.
+// Os timers ficam no banco: um timer que venceu com o app fechado dispara na
+// primeira verificação após a inicialização.
+type ExpiryScheduler struct {
+	processor    *BoosterProcessor
+	queueManager *Manager
+	eventEmitter EventEmitter
+	store        ExpiryStore
+	interval     time.Duration
+	warnBefore   time.Duration
+	now          func() time.Time
+	stopCh       chan struct{}
+	wg           sync.WaitGroup
+	startOnce    sync.Once
+	stopOnce     sync.Once
+	logger       *logger.CustomLogger
+}
+
+// NewExpiryScheduler cria o agendador; warnBefore define a antecedência do aviso de expiração
+func NewExpiryScheduler(
+	processor *BoosterProcessor,
+	queueManager *Manager,
+	eventEmitter EventEmitter,
+	store ExpiryStore,
+	interval time.Duration,
+	warnBefore time.Duration,
+) *ExpiryScheduler {
+	return &ExpiryScheduler{
+		processor:    processor,
+		queueManager: queueManager,
+		eventEmitter: eventEmitter,
+		store:        store,
+		interval:     interval,
+		warnBefore:   warnBefore,
+		now:          time.Now,
+		stopCh:       make(chan struct{}),
+		logger:       logger.NewCustomLogger("[ExpiryScheduler]"),
+	}
+}
+
+// Start inicia a verificação periódica; um intervalo <= 0 desativa o agendador
+func (e *ExpiryScheduler) Start() {
+	if e.interval <= 0 {
+		return
+	}
+	e.startOnce.Do(func() {
+		e.wg.Add(1)
+		go e.loop()
+	})
+}
+
+// Stop interrompe a verificação periódica e aguarda a rodada em andamento
+func (e *ExpiryScheduler) Stop() {
+	e.stopOnce.Do(func() {
+		close(e.stopCh)
+	})
+	e.wg.Wait()
+}
+
+func (e *ExpiryScheduler) loop() {
+	defer e.wg.Done()
+
+	// Timers que venceram enquanto o app estava fechado disparam já na inicialização
+	if err := e.CheckDue(context.Background()); err != nil {
+		e.logger.Errorf("expiry check failed: %v", err)
+	}
+
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.stopCh:
+			return
+		case <-ticker.C:
+			if err := e.CheckDue(context.Background()); err != nil {
+				e.logger.Errorf("expiry check failed: %v", err)
+			}
+		}
+	}
+}
+
+// Schedule cria ou substitui o timer do booster
+func (e *ExpiryScheduler) Schedule(ctx context.Context, boosterID string, expiresIn time.Duration) (*entities.BoosterExpiry, error) {
+	if expiresIn <= 0 {
+		return nil, fmt.Errorf("expiry duration must be positive, got %s", expiresIn)
+	}
+
+	now := e.now()
+	expiry := &entities.BoosterExpiry{
+		BoosterID: boosterID,
+		ExpiresAt: now.Add(expiresIn),
+		CreatedAt: now,
+	}
+	if err := e.store.Save(ctx, expiry); err != nil {
+		return nil, fmt.Errorf("failed to save expiry for %s: %w", boosterID, err)
+	}
+	return expiry, nil
+}
+
+// Extend adia o timer do booster; o aviso de expiração volta a ser emitido
+func (e *ExpiryScheduler) Extend(ctx context.Context, boosterID string, extra time.Duration) (*entities.BoosterExpiry, error) {
+	if extra <= 0 {
+		return nil, fmt.Errorf("expiry extension must be positive, got %s", extra)
+	}
+
+	expiry, err := e.store.GetByBoosterID(ctx, boosterID)
+	if err != nil {
+		return nil, err
+	}
+	if expiry == nil {
+		return nil, fmt.Errorf("%w: %s", ErrExpiryNotFound, boosterID)
+	}
+
+	// Um timer vencido que ainda não disparou conta a partir de agora
+	base := expiry.ExpiresAt
+	if now := e.now(); base.Before(now) {
+		base = now
+	}
+	expiry.ExpiresAt = base.Add(extra)
+	expiry.WarnedAt = nil
+
+	if err := e.store.Save(ctx, expiry); err != nil {
+		return nil, fmt.Errorf("failed to save expiry for %s: %w", boosterID, err)
+	}
+	return expiry, nil
+}
+
+// Cancel remove o timer; o booster continua aplicado
+func (e *ExpiryScheduler) Cancel(ctx context.Context, boosterID string) error {
+	expiry, err := e.store.GetByBoosterID(ctx, boosterID)
+	if err != nil {
+		return err
+	}
+	if expiry == nil {
+		return fmt.Errorf("%w: %s", ErrExpiryNotFound, boosterID)
+	}
+	return e.store.Delete(ctx, boosterID)
+}
+
+// Clear remove o timer do booster se existir
+func (e *ExpiryScheduler) Clear(ctx context.Context, boosterID string) error {
+	return e.store.Delete(ctx, boosterID)
+}
+
+func (e *ExpiryScheduler) List(ctx context.Context) ([]entities.BoosterExpiry, error) {
+	return e.store.GetAll(ctx)
+}
+
+// CheckDue enfileira a reversão dos boosters com timer vencido e avisa os que
+// estão perto de expirar
+func (e *ExpiryScheduler) CheckDue(ctx context.Context) error {
+	expiries, err := e.store.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load booster expiries: %w", err)
+	}
+
+	now := e.now()
+	for _, expiry := range expiries {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		remaining := expiry.Remaining(now)
+		switch {
+		case remaining == 0:
+			if err := e.expire(ctx, expiry); err != nil {
+				e.logger.Errorf("failed to expire booster %s: %v", expiry.BoosterID, err)
+			}
+		case remaining <= e.warnBefore && expiry.WarnedAt == nil:
+			e.eventEmitter.EmitExpiring(expiry.BoosterID, expiry.ExpiresAt)
+			if err := e.store.MarkWarned(ctx, expiry.BoosterID, now); err != nil {
+				e.logger.Errorf("failed to mark expiry warning for %s: %v", expiry.BoosterID, err)
+			}
+		}
+	}
+	return nil
+}
+
+// expire enfileira a reversão do booster e remove o timer. Se a fila recusar a
+// operação o timer é mantido para a próxima rodada.
+func (e *ExpiryScheduler) expire(ctx context.Context, expiry entities.BoosterExpiry) error {
+	// A aplicação ainda está na fila; a reversão fica para depois que ela terminar
+	if queued := e.queueManager.GetQueuedItem(expiry.BoosterID); queued != nil && queued.Operation == entities.ApplyOperationType {
+		return nil
+	}
+
+	applied, err := e.processor.IsApplied(ctx, expiry.BoosterID)
+	if err != nil {
+		return err
+	}
+	if !applied {
+		// Revertido por outro caminho ou a aplicação falhou: não há o que reverter
+		return e.store.Delete(ctx, expiry.BoosterID)
+	}
+
+	operationID, err := e.queueManager.AddWithOptions(expiry.BoosterID, entities.RevertOperationType, QueueItemOptions{
+		Priority: entities.PriorityScheduled,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to queue revert: %w", err)
+	}
+	e.eventEmitter.EmitQueued(expiry.BoosterID, operationID, entities.RevertOperationType, e.queueManager.Size())
+
+	if err := e.store.Delete(ctx, expiry.BoosterID); err != nil {
+		e.logger.Errorf("failed to delete expiry for %s: %v", expiry.BoosterID, err)
+	}
+	e.eventEmitter.EmitExpired(expiry.BoosterID, expiry.ExpiresAt, operationID)
+	return nil
+}
+
+// scheduleExpiries cria os timers dos boosters pedidos que vão ser aplicados.
+// Uma aplicação sem expiração torna o booster permanente e remove o timer anterior.
+func (s *Service) scheduleExpiries(ctx context.Context, ids []string, plan *BatchPlan, opts []entities.ApplyOptions) error {
+	var options entities.ApplyOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.ExpiresIn < 0 {
+		return fmt.Errorf("expiry duration must be positive, got %s", options.ExpiresIn)
+	}
+	if s.expiryScheduler == nil {
+		if options.ExpiresIn > 0 {
+			return fmt.Errorf("booster expiry is not configured")
+		}
+		return nil
+	}
+
+	for _, id := range ids {
+		if _, rejected := plan.Errors[id]; rejected {
+			continue
+		}
+		if options.ExpiresIn == 0 {
+			if err := s.expiryScheduler.Clear(ctx, id); err != nil {
+				return fmt.Errorf("failed to clear expiry for %s: %w", id, err)
+			}
+			continue
+		}
+		if _, err := s.expiryScheduler.Schedule(ctx, id, options.ExpiresIn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expiryFailedResult(err error) entities.InitResult {
+	return entities.InitResult{
+		SubmittedAt: time.Now(),
+		Success:     false,
+		Status:      entities.OperationFailed,
+		Message:     "failed to schedule booster expiry",
+		Error:       err,
+	}
+}
+
+// clearExpiries remove os timers dos boosters que o usuário pediu para reverter
+func (s *Service) clearExpiries(ctx context.Context, ids []string) {
+	if s.expiryScheduler == nil {
+		return
+	}
+	for _, id := range ids {
+		if err := s.expiryScheduler.Clear(ctx, id); err != nil {
+			s.expiryScheduler.logger.Warnf("failed to clear expiry for %s: %v", id, err)
+		}
+	}
+}
+
+// ExtendBoosterExpiry adia a reversão automática de um booster aplicado por tempo limitado
+func (s *Service) ExtendBoosterExpiry(ctx context.Context, boosterID string, extra time.Duration) (*entities.BoosterExpiry, error) {
+	return s.expiryScheduler.Extend(ctx, boosterID, extra)
+}
+
+// CancelBoosterExpiry remove a reversão automática; o booster continua aplicado
+func (s *Service) CancelBoosterExpiry(ctx context.Context, boosterID string) error {
+	return s.expiryScheduler.Cancel(ctx, boosterID)
+}
+
+// GetBoosterExpiries retorna os timers ativos, do que expira primeiro para o último
+func (s *Service) GetBoosterExpiries(ctx context.Context) ([]entities.BoosterExpiry, error) {
+	return s.expiryScheduler.List(ctx)
+}
@@ -0,0 +1,127 @@
+package booster
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	storagemodels "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+	repos "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/repositories"
+)
+
+// helper para criar o repositório de timers num banco em memória compartilhado
+func setupExpiryRepoForTest(t *testing.T) *repos.BoosterExpiryRepo {
+	dsn := fmt.Sprintf("file:%s-%d?mode=memory&cache=shared", t.Name(), time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&storagemodels.BoosterExpiry{}))
+	return repos.NewBoosterExpiryRepo(db)
+}
+
+// newExpiryScheduler cria um agendador sem loop, com o relógio fixo em now
+func newExpiryScheduler(service *Service, emitter *recordingEmitter, store ExpiryStore, now time.Time) *ExpiryScheduler {
+	scheduler := NewExpiryScheduler(service.processor, service.queueManager, emitter, store, 0, 5*time.Minute)
+	scheduler.now = func() time.Time { return now }
+	return scheduler
+}
+
+func TestExpiry_RevertsBoosterWhenPersistedTimerFires(t *testing.T) {
+	service, emitter := newTestService(t, &testBooster{
+		id:         "a",
+		execResult: okResult(),
+		revertRes:  &entities.BoostRevertResult{Success: true},
+	})
+	store := setupExpiryRepoForTest(t)
+	start := time.Now()
+	service.expiryScheduler = newExpiryScheduler(service, emitter, store, start)
+	ctx := context.Background()
+
+	res, err := service.InitBoosterApply(ctx, "a", entities.ApplyOptions{ExpiresIn: time.Hour})
+	require.NoError(t, err)
+	require.NoError(t, service.queueManager.AwaitOperation(ctx, res.OperationID))
+
+	expiries, err := service.GetBoosterExpiries(ctx)
+	require.NoError(t, err)
+	require.Len(t, expiries, 1)
+	assert.WithinDuration(t, start.Add(time.Hour), expiries[0].ExpiresAt, time.Second)
+
+	// um agendador novo sobre o mesmo banco simula o app reiniciado depois do vencimento
+	restarted := newExpiryScheduler(service, emitter, store, start.Add(2*time.Hour))
+	require.NoError(t, restarted.CheckDue(ctx))
+
+	select {
+	case id := <-emitter.expired:
+		assert.Equal(t, "a", id)
+	case <-time.After(2 * time.Second):
+		t.Fatal("expired event not emitted")
+	}
+	require.Eventually(t, func() bool {
+		applied, err := service.processor.IsApplied(ctx, "a")
+		return err == nil && !applied
+	}, 2*time.Second, 10*time.Millisecond)
+
+	expiries, err = service.GetBoosterExpiries(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, expiries)
+}
+
+func TestExpiry_WarnsOnceAndSupportsExtendAndCancel(t *testing.T) {
+	service, emitter := newTestService(t, &testBooster{id: "a"})
+	store := setupExpiryRepoForTest(t)
+	start := time.Now()
+	service.expiryScheduler = newExpiryScheduler(service, emitter, store, start)
+	ctx := context.Background()
+	markApplied(t, service.processor, "a")
+
+	_, err := service.expiryScheduler.Schedule(ctx, "a", 10*time.Minute)
+	require.NoError(t, err)
+
+	service.expiryScheduler.now = func() time.Time { return start.Add(6 * time.Minute) }
+	require.NoError(t, service.expiryScheduler.CheckDue(ctx))
+	require.NoError(t, service.expiryScheduler.CheckDue(ctx))
+	assert.Equal(t, "a", <-emitter.expiring)
+	assert.Empty(t, emitter.expiring, "warning is emitted once")
+
+	extended, err := service.ExtendBoosterExpiry(ctx, "a", 10*time.Minute)
+	require.NoError(t, err)
+	assert.WithinDuration(t, start.Add(20*time.Minute), extended.ExpiresAt, time.Second)
+	assert.Nil(t, extended.WarnedAt)
+
+	require.NoError(t, service.CancelBoosterExpiry(ctx, "a"))
+	assert.ErrorIs(t, service.CancelBoosterExpiry(ctx, "a"), ErrExpiryNotFound)
+	_, err = service.ExtendBoosterExpiry(ctx, "a", time.Minute)
+	assert.ErrorIs(t, err, ErrExpiryNotFound)
+
+	applied, err := service.processor.IsApplied(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, applied, "cancelling the timer keeps the booster applied")
+}
+
+func TestExpiry_ManualRevertClearsTimer(t *testing.T) {
+	service, emitter := newTestService(t, &testBooster{
+		id:         "a",
+		execResult: okResult(),
+		revertRes:  &entities.BoostRevertResult{Success: true},
+	})
+	service.expiryScheduler = newExpiryScheduler(service, emitter, setupExpiryRepoForTest(t), time.Now())
+	ctx := context.Background()
+
+	res, err := service.InitBoosterApply(ctx, "a", entities.ApplyOptions{ExpiresIn: time.Hour})
+	require.NoError(t, err)
+	require.NoError(t, service.queueManager.AwaitOperation(ctx, res.OperationID))
+
+	res, err = service.InitRevertBooster(ctx, "a")
+	require.NoError(t, err)
+	require.NoError(t, service.queueManager.AwaitOperation(ctx, res.OperationID))
+
+	expiries, err := service.GetBoosterExpiries(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, expiries)
+}
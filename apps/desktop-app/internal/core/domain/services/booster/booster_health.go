@@ -0,0 +1,301 @@
+package booster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/wailsapp/wails/lib/logger"
+)
+
+var (
+	ErrWorkerPanic       = errors.New("worker panicked")
+	ErrOperationPanicked = errors.New("operation panicked")
+)
+
+// queueNearlyFull é o tamanho de fila a partir do qual o serviço é reportado como não saudável
+const queueNearlyFull = 80
+
+// HealthPolicy define quando um worker ou uma operação deixam de ser saudáveis
+type HealthPolicy struct {
+	// StuckAfter é o tempo de execução a partir do qual a operação é considerada travada
+	StuckAfter time.Duration
+	// HeartbeatInterval é a frequência do heartbeat de um worker executando uma operação
+	HeartbeatInterval time.Duration
+	// HeartbeatTimeout é o tempo sem heartbeat até o worker ser considerado sem resposta
+	HeartbeatTimeout time.Duration
+}
+
+func DefaultHealthPolicy() HealthPolicy {
+	return HealthPolicy{
+		StuckAfter:        5 * time.Minute,
+		HeartbeatInterval: 5 * time.Second,
+		HeartbeatTimeout:  30 * time.Second,
+	}
+}
+
+type workerPhase int
+
+const (
+	phaseIdle workerPhase = iota
+	// phaseWaiting: aguardando dependências ou recursos; o worker não envia heartbeat
+	phaseWaiting
+	phaseRunning
+	phaseStopped
+)
+
+// workerState é o que o worker publica sobre si mesmo para a verificação de saúde
+type workerState struct {
+	mu        sync.Mutex
+	id        int
+	phase     workerPhase
+	heartbeat time.Time
+	item      *entities.QueueItem
+	startedAt time.Time
+	restarts  int
+	lastPanic string
+}
+
+func newWorkerState(id int) *workerState {
+	return &workerState{id: id, heartbeat: time.Now()}
+}
+
+func (w *workerState) set(update func(w *workerState)) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	update(w)
+	w.heartbeat = time.Now()
+}
+
+func (w *workerState) beat() {
+	w.set(func(*workerState) {})
+}
+
+func (w *workerState) idle() {
+	w.set(func(w *workerState) {
+		w.phase = phaseIdle
+		w.item = nil
+		w.startedAt = time.Time{}
+	})
+}
+
+// pick registra o item retirado da fila; ele ainda pode aguardar dependências
+func (w *workerState) pick(item entities.QueueItem) {
+	w.set(func(w *workerState) {
+		w.phase = phaseWaiting
+		w.item = &item
+	})
+}
+
+// run marca o início da execução do item
+func (w *workerState) run() {
+	w.set(func(w *workerState) {
+		w.phase = phaseRunning
+		if w.startedAt.IsZero() {
+			w.startedAt = time.Now()
+		}
+	})
+}
+
+// wait marca uma espera por recursos durante a execução
+func (w *workerState) wait() {
+	w.set(func(w *workerState) { w.phase = phaseWaiting })
+}
+
+func (w *workerState) stop() {
+	w.set(func(w *workerState) {
+		w.phase = phaseStopped
+		w.item = nil
+	})
+}
+
+func (w *workerState) panicked(recovered interface{}) {
+	w.set(func(w *workerState) {
+		w.restarts++
+		w.lastPanic = fmt.Sprint(recovered)
+	})
+}
+
+// health classifica o worker conforme a política
+func (w *workerState) health(now time.Time, policy HealthPolicy) entities.WorkerHealth {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	health := entities.WorkerHealth{
+		ID:            w.id,
+		State:         entities.WorkerIdle,
+		LastHeartbeat: w.heartbeat,
+		Restarts:      w.restarts,
+		LastPanic:     w.lastPanic,
+	}
+	if w.phase == phaseStopped {
+		health.State = entities.WorkerStopped
+		return health
+	}
+	if w.item == nil {
+		return health
+	}
+
+	health.State = entities.WorkerBusy
+	health.BoosterID = w.item.BoosterID
+	health.OperationID = w.item.OperationID
+	health.Operation = w.item.Operation
+	if !w.startedAt.IsZero() {
+		startedAt := w.startedAt
+		health.StartedAt = &startedAt
+		health.RunningFor = now.Sub(startedAt)
+	}
+
+	switch {
+	case w.phase == phaseRunning && policy.HeartbeatTimeout > 0 && now.Sub(w.heartbeat) > policy.HeartbeatTimeout:
+		health.State = entities.WorkerUnresponsive
+	case policy.StuckAfter > 0 && health.RunningFor > policy.StuckAfter:
+		health.State = entities.WorkerStuck
+	}
+	return health
+}
+
+// HealthMonitor calcula a saúde do serviço e emite um evento quando ela muda,
+// tanto nas verificações periódicas quanto nas pedidas pela interface
+type HealthMonitor struct {
+	compute      func(now time.Time) *entities.HealthStatus
+	eventEmitter EventEmitter
+	interval     time.Duration
+	mu           sync.Mutex
+	lastKey      string
+	stopCh       chan struct{}
+	wg           sync.WaitGroup
+	startOnce    sync.Once
+	stopOnce     sync.Once
+	logger       *logger.CustomLogger
+}
+
+func NewHealthMonitor(compute func(now time.Time) *entities.HealthStatus, eventEmitter EventEmitter, interval time.Duration) *HealthMonitor {
+	return &HealthMonitor{
+		compute:      compute,
+		eventEmitter: eventEmitter,
+		interval:     interval,
+		stopCh:       make(chan struct{}),
+		logger:       logger.NewCustomLogger("[HealthMonitor]"),
+	}
+}
+
+// Start inicia a verificação periódica; um intervalo <= 0 desativa o monitor
+func (m *HealthMonitor) Start() {
+	if m.interval <= 0 {
+		return
+	}
+	m.startOnce.Do(func() {
+		m.wg.Add(1)
+		go m.loop()
+	})
+}
+
+// Stop interrompe a verificação periódica
+func (m *HealthMonitor) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stopCh)
+	})
+	m.wg.Wait()
+}
+
+func (m *HealthMonitor) loop() {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stopCh:
+			return
+		case <-ticker.C:
+			m.Check(context.Background())
+		}
+	}
+}
+
+// Check calcula a saúde atual e emite EventHealthChanged se ela mudou desde a
+// última verificação. A primeira verificação só é emitida se houver problemas.
+func (m *HealthMonitor) Check(ctx context.Context) *entities.HealthStatus {
+	status := m.compute(time.Now())
+	key := healthKey(status)
+
+	m.mu.Lock()
+	changed := key != m.lastKey && (m.lastKey != "" || !status.IsHealthy)
+	m.lastKey = key
+	m.mu.Unlock()
+
+	if changed {
+		if !status.IsHealthy {
+			m.logger.Warnf("booster service is unhealthy: %s", strings.Join(status.Issues, "; "))
+		}
+		m.eventEmitter.EmitHealthChanged(*status)
+	}
+	return status
+}
+
+// healthKey resume o que caracteriza uma mudança de saúde: o resultado, os
+// estados dos workers e as operações travadas. Tempos de execução não entram.
+func healthKey(status *entities.HealthStatus) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%t", status.IsHealthy)
+	for _, worker := range status.Workers {
+		fmt.Fprintf(&b, "|%d:%s:%d", worker.ID, worker.State, worker.Restarts)
+	}
+	for _, stuck := range status.StuckOperations {
+		fmt.Fprintf(&b, "|stuck:%s", stuck.OperationID)
+	}
+	if status.QueueSize > queueNearlyFull {
+		b.WriteString("|queue_full")
+	}
+	return b.String()
+}
+
+// buildHealthStatus reúne o estado dos workers, da fila e do registro de boosters
+func buildHealthStatus(now time.Time, workers []entities.WorkerHealth, queueSize, registeredBoosters int) *entities.HealthStatus {
+	status := &entities.HealthStatus{
+		IsHealthy:          true,
+		QueueSize:          queueSize,
+		RegisteredBoosters: registeredBoosters,
+		Workers:            workers,
+		CheckedAt:          now,
+	}
+
+	for _, worker := range workers {
+		switch worker.State {
+		case entities.WorkerStopped:
+			continue
+		case entities.WorkerStuck:
+			status.StuckOperations = append(status.StuckOperations, entities.StuckOperation{
+				WorkerID:    worker.ID,
+				BoosterID:   worker.BoosterID,
+				OperationID: worker.OperationID,
+				Operation:   worker.Operation,
+				RunningFor:  worker.RunningFor,
+			})
+			status.Issues = append(status.Issues, fmt.Sprintf("Operation %s of booster %s has been running for %s",
+				worker.OperationID, worker.BoosterID, worker.RunningFor.Round(time.Second)))
+		case entities.WorkerUnresponsive:
+			status.Issues = append(status.Issues, fmt.Sprintf("Worker %d has not sent a heartbeat since %s",
+				worker.ID, worker.LastHeartbeat.Format(time.RFC3339)))
+		}
+		status.ActiveWorkers++
+	}
+
+	if status.ActiveWorkers == 0 {
+		status.Issues = append(status.Issues, "No workers are running")
+	}
+	if queueSize > queueNearlyFull {
+		status.Issues = append(status.Issues, "Queue is nearly full")
+	}
+	status.IsHealthy = len(status.Issues) == 0
+	return status
+}
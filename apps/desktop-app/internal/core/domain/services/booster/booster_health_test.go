@@ -0,0 +1,202 @@
+package booster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// panickingBooster simula um executor com bug
+type panickingBooster struct{ *testBooster }
+
+func (panickingBooster) Execute(ctx context.Context) (*entities.BoostApplyResult, error) {
+	panic("nil map write")
+}
+
+// panickingRecorder faz o próprio worker entrar em pânico na primeira operação
+type panickingRecorder struct {
+	noopRecorder
+	panics chan struct{}
+}
+
+func (r panickingRecorder) RecordStarted(item entities.QueueItem) error {
+	select {
+	case <-r.panics:
+		panic("recorder bug")
+	default:
+		return nil
+	}
+}
+
+func newHealthFixture(t *testing.T, recorder HistoryRecorder, boosters ...*testBooster) (*Pool, *Manager, *HealthMonitor, *recordingEmitter) {
+	proc := newResolverProcessor(t, boosters...)
+	queueManager := NewManager(10)
+	emitter := newRecordingEmitter()
+
+	pool := NewPool(2, proc, emitter, recorder, queueManager)
+	pool.SetDefaultPolicy(ExecutionPolicy{Timeout: 5 * time.Second, Retry: entities.RetryPolicy{MaxAttempts: 1}})
+	pool.SetHealthPolicy(HealthPolicy{StuckAfter: 50 * time.Millisecond, HeartbeatInterval: 10 * time.Millisecond, HeartbeatTimeout: time.Second})
+	pool.Start()
+	t.Cleanup(pool.Stop)
+
+	monitor := NewHealthMonitor(func(now time.Time) *entities.HealthStatus {
+		return buildHealthStatus(now, pool.WorkerHealth(now), queueManager.Size(), proc.GetBoosterCount())
+	}, emitter, 0)
+	return pool, queueManager, monitor, emitter
+}
+
+func TestHealth_FlagsStuckOperation(t *testing.T) {
+	block := make(chan struct{})
+	started := make(chan string, 1)
+	_, queueManager, monitor, emitter := newHealthFixture(t, noopRecorder{},
+		&testBooster{id: "slow", execResult: okResult(), execBlock: block, started: started})
+	ctx := context.Background()
+
+	healthy := monitor.Check(ctx)
+	assert.True(t, healthy.IsHealthy)
+	assert.Equal(t, 2, healthy.ActiveWorkers)
+	assert.Empty(t, emitter.health, "a healthy first check is not a change")
+
+	opID, err := queueManager.Add("slow", entities.ApplyOperationType)
+	require.NoError(t, err)
+	<-started
+	time.Sleep(80 * time.Millisecond)
+
+	status := monitor.Check(ctx)
+	assert.False(t, status.IsHealthy)
+	require.Len(t, status.StuckOperations, 1)
+	assert.Equal(t, opID, status.StuckOperations[0].OperationID)
+	assert.Equal(t, "slow", status.StuckOperations[0].BoosterID)
+	assert.GreaterOrEqual(t, status.StuckOperations[0].RunningFor, 50*time.Millisecond)
+
+	var busy *entities.WorkerHealth
+	for i := range status.Workers {
+		if status.Workers[i].OperationID == opID {
+			busy = &status.Workers[i]
+		}
+	}
+	require.NotNil(t, busy)
+	assert.Equal(t, entities.WorkerStuck, busy.State)
+	require.NotNil(t, busy.StartedAt)
+
+	changed := <-emitter.health
+	assert.False(t, changed.IsHealthy)
+
+	// Verificar de novo sem mudança não emite outro evento
+	monitor.Check(ctx)
+	assert.Empty(t, emitter.health)
+
+	close(block)
+	require.NoError(t, queueManager.AwaitOperation(ctx, opID))
+	assert.True(t, monitor.Check(ctx).IsHealthy)
+	assert.True(t, (<-emitter.health).IsHealthy)
+}
+
+func TestGetQueueStats_ReportsServiceHealth(t *testing.T) {
+	block := make(chan struct{})
+	started := make(chan string, 1)
+	pool, queueManager, monitor, _ := newHealthFixture(t, noopRecorder{},
+		&testBooster{id: "slow", execResult: okResult(), execBlock: block, started: started})
+	service := &Service{queueManager: queueManager, workerPool: pool, healthMonitor: monitor}
+	ctx := context.Background()
+
+	stats := service.GetQueueStats()
+	assert.True(t, stats.IsHealthy)
+	assert.Equal(t, 2, stats.ActiveWorkers)
+
+	// a fila está vazia, mas uma operação travada deixa o serviço não saudável
+	opID, err := queueManager.Add("slow", entities.ApplyOperationType)
+	require.NoError(t, err)
+	<-started
+	time.Sleep(80 * time.Millisecond)
+	stats = service.GetQueueStats()
+	assert.Equal(t, 0, stats.Size)
+	assert.False(t, stats.IsHealthy)
+
+	close(block)
+	require.NoError(t, queueManager.AwaitOperation(ctx, opID))
+	assert.True(t, service.GetQueueStats().IsHealthy)
+}
+
+func TestHealth_RecoversPanickingExecutor(t *testing.T) {
+	rr, _ := setupRollbackRepoForTest(t)
+	proc := NewBoosterProcessor(rr)
+	require.NoError(t, proc.RegisterBooster(panickingBooster{&testBooster{id: "buggy", version: "v1", canApply: true}}))
+	require.NoError(t, proc.RegisterBooster(&testBooster{id: "ok", version: "v1", canApply: true, execResult: okResult()}))
+
+	queueManager := NewManager(10)
+	pool := NewPool(1, proc, newRecordingEmitter(), noopRecorder{}, queueManager)
+	pool.SetDefaultPolicy(ExecutionPolicy{Timeout: time.Second, Retry: entities.RetryPolicy{MaxAttempts: 1}})
+	pool.Start()
+	t.Cleanup(pool.Stop)
+	ctx := context.Background()
+
+	opID, err := queueManager.Add("buggy", entities.ApplyOperationType)
+	require.NoError(t, err)
+	assert.ErrorIs(t, queueManager.AwaitOperation(ctx, opID), ErrOperationPanicked)
+
+	opID, err = queueManager.Add("ok", entities.ApplyOperationType)
+	require.NoError(t, err)
+	assert.NoError(t, queueManager.AwaitOperation(ctx, opID))
+}
+
+func TestHealth_ReplacesPanickingWorker(t *testing.T) {
+	recorder := panickingRecorder{panics: make(chan struct{}, 1)}
+	recorder.panics <- struct{}{}
+	pool, queueManager, monitor, emitter := newHealthFixture(t, recorder,
+		&testBooster{id: "a", execResult: okResult()},
+		&testBooster{id: "b", execResult: okResult()},
+	)
+	ctx := context.Background()
+
+	opID, err := queueManager.Add("a", entities.ApplyOperationType)
+	require.NoError(t, err)
+	assert.ErrorIs(t, queueManager.AwaitOperation(ctx, opID), ErrWorkerPanic)
+
+	require.Eventually(t, func() bool { return pool.GetActiveWorkerCount() == 2 }, time.Second, 5*time.Millisecond)
+	status := monitor.Check(ctx)
+	assert.True(t, status.IsHealthy)
+	restarts := 0
+	for _, worker := range status.Workers {
+		restarts += worker.Restarts
+		if worker.Restarts > 0 {
+			assert.Equal(t, "recorder bug", worker.LastPanic)
+		}
+	}
+	assert.Equal(t, 1, restarts)
+	assert.Len(t, emitter.health, 0, "a replaced worker keeps the service healthy")
+
+	// O substituto continua processando a fila
+	for _, id := range []string{"a", "b"} {
+		opID, err := queueManager.Add(id, entities.ApplyOperationType)
+		require.NoError(t, err)
+		assert.NoError(t, queueManager.AwaitOperation(ctx, opID))
+	}
+}
+
+func TestWorkerHealth_FlagsMissingHeartbeat(t *testing.T) {
+	policy := HealthPolicy{StuckAfter: time.Hour, HeartbeatTimeout: time.Second}
+	w := newWorkerState(0)
+	w.pick(entities.QueueItem{BoosterID: "a", OperationID: "op"})
+	w.run()
+
+	now := time.Now()
+	assert.Equal(t, entities.WorkerBusy, w.health(now, policy).State)
+	assert.Equal(t, entities.WorkerUnresponsive, w.health(now.Add(2*time.Second), policy).State)
+
+	// Esperando recursos o worker não envia heartbeat e não é considerado sem resposta
+	w.wait()
+	assert.Equal(t, entities.WorkerBusy, w.health(time.Now().Add(2*time.Second), policy).State)
+
+	status := buildHealthStatus(now, []entities.WorkerHealth{w.health(now.Add(-time.Minute), policy)}, 0, 1)
+	assert.True(t, status.IsHealthy)
+	w.stop()
+	status = buildHealthStatus(now, []entities.WorkerHealth{w.health(now, policy)}, 0, 1)
+	assert.False(t, status.IsHealthy)
+	assert.Contains(t, status.Issues, "No workers are running")
+}
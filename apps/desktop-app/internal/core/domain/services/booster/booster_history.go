@@ -3,38 +3,129 @@ package booster
 
 import (
 	"context"
+	"errors"
 	"time"
 
-	repos "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/repositories"
+	"github.com/oLenador/mulltbost/internal/core/application/ports/outbound"
 	"github.com/oLenador/mulltbost/internal/core/domain/entities"
 )
 
 // Recorder gerencia a gravação do histórico de operações
 type Recorder struct {
-	operationsRepo *repos.BoostOperationsRepo
+	operationsRepo outbound.OperationHistoryRepository
+	versionOf      func(boosterID string) string
 }
 
 // NewRecorder cria um novo recorder de histórico
-func NewRecorder(operationsRepo *repos.BoostOperationsRepo) *Recorder {
+func NewRecorder(operationsRepo outbound.OperationHistoryRepository) *Recorder {
 	return &Recorder{
 		operationsRepo: operationsRepo,
 	}
 }
 
-// RecordOperation registra uma operação no histórico
+// SetVersionLookup define como obter a versão do booster gravada em cada operação
+func (r *Recorder) SetVersionLookup(versionOf func(boosterID string) string) {
+	r.versionOf = versionOf
+}
+
+// RecordQueued grava a operação assim que ela entra na queue
+func (r *Recorder) RecordQueued(item entities.QueueItem) error {
+	return r.operationsRepo.Save(context.Background(), r.newOperationRecord(item))
+}
+
+// RecordStarted marca o início da execução da operação
+func (r *Recorder) RecordStarted(item entities.QueueItem) error {
+	operation := r.load(item)
+	now := time.Now()
+	operation.Status = entities.OperationProcessing
+	operation.StartedAt = &now
+	return r.operationsRepo.Save(context.Background(), operation)
+}
+
+// RecordCancelled encerra uma operação retirada da queue antes de executar
+func (r *Recorder) RecordCancelled(item entities.QueueItem) error {
+	return r.RecordOperation(item, nil, ErrCancelledInQueue)
+}
+
+// RecordOperation registra o resultado final de uma operação no histórico
 func (r *Recorder) RecordOperation(item entities.QueueItem, result *entities.BoostOperation, err error) error {
-	operation := &entities.BoostOperation{
+	operation := r.load(item)
+	now := time.Now()
+	operation.CompletedAt = &now
+	if operation.StartedAt != nil {
+		operation.Duration = now.Sub(*operation.StartedAt)
+	}
+
+	switch opErr := operationError(result, err); {
+	case opErr == nil:
+		operation.Status = entities.OperationCompleted
+		operation.ErrorMsg = ""
+	case errors.Is(opErr, context.DeadlineExceeded):
+		// Timeout é falha, não cancelamento pedido pelo usuário
+		operation.Status = entities.OperationFailed
+		operation.ErrorMsg = opErr.Error()
+	case errors.Is(opErr, context.Canceled), errors.Is(opErr, entities.ErrOperationCancelled):
+		operation.Status = entities.OperationCancelled
+		operation.ErrorMsg = opErr.Error()
+	default:
+		operation.Status = entities.OperationFailed
+		operation.ErrorMsg = opErr.Error()
+	}
+
+	if result != nil {
+		operation.Message = result.Message
+		if !result.RevertedAt.IsZero() {
+			operation.RevertedAt = result.RevertedAt
+		}
+		if result.Attempts > 0 {
+			operation.Attempts = result.Attempts
+		}
+	}
+
+	return r.operationsRepo.Save(context.Background(), operation)
+}
+
+// load retorna o registro gravado da operação, ou um novo se ele não existir
+func (r *Recorder) load(item entities.QueueItem) *entities.BoostOperation {
+	operation, err := r.operationsRepo.GetByID(context.Background(), item.OperationID)
+	if err != nil || operation == nil {
+		return r.newOperationRecord(item)
+	}
+	return operation
+}
+
+func (r *Recorder) newOperationRecord(item entities.QueueItem) *entities.BoostOperation {
+	version := ""
+	if r.versionOf != nil {
+		version = r.versionOf(item.BoosterID)
+	}
+	return &entities.BoostOperation{
 		ID:        item.OperationID,
 		BoosterID: item.BoosterID,
 		Type:      item.Operation,
+		BatchID:   item.BatchID,
+		Status:    entities.OperationQueued,
 		AppliedAt: item.SubmittedAt,
+		QueuedAt:  item.SubmittedAt,
+		Attempts:  1,
+		Version:   version,
 	}
+}
 
-	// save and ignore error
-	if saveErr := r.operationsRepo.Save(context.Background(), operation); saveErr != nil {
-		return err
-	}
-	return nil
+// GetOperation retorna o registro da operação; nil se ela não existe
+func (r *Recorder) GetOperation(ctx context.Context, operationID string) (*entities.BoostOperation, error) {
+	return r.operationsRepo.GetByID(ctx, operationID)
+}
+
+// GetBatchOperations retorna as operações de um lote
+func (r *Recorder) GetBatchOperations(ctx context.Context, batchID string) ([]entities.BoostOperation, error) {
+	return r.operationsRepo.GetByBatchID(ctx, batchID)
+}
+
+// CloseInterrupted cancela os registros de operações que não terminaram na
+// execução anterior do app
+func (r *Recorder) CloseInterrupted(ctx context.Context) (int64, error) {
+	return r.operationsRepo.CloseUnfinished(ctx, entities.OperationCancelled, ErrOperationInterrupted.Error(), time.Now())
 }
 
 func (r *Recorder) GetOperationsHistory(ctx context.Context, boosterID string) (*[]entities.BoostOperation, error) {
@@ -45,7 +136,7 @@ func (r *Recorder) GetAllOperations(ctx context.Context) (*[]entities.BoostOpera
 	return r.operationsRepo.GetAll(ctx)
 }
 
-func (r *Recorder) GetOperationsByStatus(ctx context.Context, status entities.BoosterExecutionStatus) (*[]entities.BoostOperation, error) {
+func (r *Recorder) GetOperationsByStatus(ctx context.Context, status entities.OperationStatus) (*[]entities.BoostOperation, error) {
 	return r.operationsRepo.GetByStatus(ctx, status)
 }
 
@@ -65,67 +156,11 @@ func (r *Recorder) DeleteOldOperations(ctx context.Context, olderThan time.Time)
 	return r.operationsRepo.DeleteOlderThan(ctx, olderThan)
 }
 
-func (r *Recorder) GetOperationStats(ctx context.Context) (*OperationStats, error) {
-	allOps, err := r.GetAllOperations(ctx)
+// GetOperationStats agrega as operações do filtro, no total e por booster
+func (r *Recorder) GetOperationStats(ctx context.Context, filter entities.OperationStatsFilter) (*entities.OperationStats, error) {
+	ops, err := r.operationsRepo.Query(ctx, filter)
 	if err != nil {
 		return nil, err
 	}
-
-	if allOps == nil || len(*allOps) == 0 {
-		return &OperationStats{}, nil
-	}
-
-	ops := *allOps
-	stats := &OperationStats{
-		Total: len(ops),
-	}
-
-	var totalDuration time.Duration
-	var completedOps int
-
-	for _, op := range ops {
-		updateTypeStats(stats, op)
-	}
-
-	calculateRates(stats)
-	calculateAverageDuration(stats, totalDuration, completedOps)
-
-	return stats, nil
-}
-
-func updateTypeStats(stats *OperationStats, op entities.BoostOperation) {
-	switch op.Type {
-	case entities.BoosterOperationType(entities.ExecutionApplied):
-		stats.ApplyOperations++
-	case entities.BoosterOperationType(entities.RevertOperationType):
-		stats.RevertOperations++
-	}
+	return entities.NewOperationStats(filter, ops), nil
 }
-
-func calculateRates(stats *OperationStats) {
-	if stats.Total == 0 {
-		return
-	}
-	stats.SuccessRate = float64(stats.Successful) / float64(stats.Total) * 100
-	stats.FailureRate = float64(stats.Failed) / float64(stats.Total) * 100
-}
-
-func calculateAverageDuration(stats *OperationStats, totalDuration time.Duration, completedOps int) {
-	if completedOps == 0 {
-		return
-	}
-	stats.AverageDuration = totalDuration / time.Duration(completedOps)
-}
-
-type OperationStats struct {
-	Total            int           `json:"total"`
-	Successful       int           `json:"successful"`
-	Failed           int           `json:"failed"`
-	Processing       int           `json:"processing"`
-	Pending          int           `json:"pending"`
-	ApplyOperations  int           `json:"applyOperations"`
-	RevertOperations int           `json:"revertOperations"`
-	SuccessRate      float64       `json:"successRate"`
-	FailureRate      float64       `json:"failureRate"`
-	AverageDuration  time.Duration `json:"averageDuration"`
-}
\ No newline at end of file
@@ -0,0 +1,203 @@
+package booster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/wailsapp/wails/lib/logger"
+)
+
+// OperationJournal é o journal write-ahead das operações da queue. Cada operação
+// passa por queued → started → executor_finished → state_saved; uma operação que
+// não chegou a uma fase terminal foi interrompida (crash, kill) e é recuperada
+// na próxima inicialização.
+type OperationJournal interface {
+	Save(ctx context.Context, entry *entities.JournalEntry) error
+	UpdatePhase(ctx context.Context, operationID string, phase entities.JournalPhase, errMsg string) error
+	RecordExecutorResult(ctx context.Context, operationID string, success bool, message string, backupData entities.BackupData) error
+	GetUnfinished(ctx context.Context) ([]entities.JournalEntry, error)
+	DeleteFinishedBefore(ctx context.Context, cutoff time.Time) error
+}
+
+// journalRetention define por quanto tempo operações terminadas ficam no journal
+const journalRetention = 7 * 24 * time.Hour
+
+type operationIDKey struct{}
+
+// withOperationID associa o ID da operação ao contexto de execução do item
+func withOperationID(ctx context.Context, operationID string) context.Context {
+	return context.WithValue(ctx, operationIDKey{}, operationID)
+}
+
+func operationIDFromContext(ctx context.Context) string {
+	operationID, _ := ctx.Value(operationIDKey{}).(string)
+	return operationID
+}
+
+// JournalRecoverer decide, na inicialização, o destino das operações interrompidas:
+//   - queued: reenfileirada, a não ser que dependa de uma operação que não foi retomada
+//   - started: revisão manual. O executor altera o sistema sem atomicidade e não
+//     gravou backup; repetir o apply capturaria o sistema alterado pela metade
+//     como valor original, e os valores reais do usuário se perderiam.
+//   - executor_finished com sucesso: o estado é salvo com o resultado do journal
+//   - executor_finished de apply sem sucesso: desfeita com o backup gravado no journal
+//   - executor_finished de revert sem sucesso: marcada como falha, o backup de rollback continua válido
+type JournalRecoverer struct {
+	journal      OperationJournal
+	processor    *BoosterProcessor
+	queueManager *Manager
+	eventEmitter EventEmitter
+	logger       *logger.CustomLogger
+}
+
+func NewJournalRecoverer(journal OperationJournal, processor *BoosterProcessor, queueManager *Manager, eventEmitter EventEmitter) *JournalRecoverer {
+	return &JournalRecoverer{
+		journal:      journal,
+		processor:    processor,
+		queueManager: queueManager,
+		eventEmitter: eventEmitter,
+		logger:       logger.NewCustomLogger("[JournalRecoverer]"),
+	}
+}
+
+// Recover trata as operações interrompidas e remove do journal as terminadas há mais tempo
+func (r *JournalRecoverer) Recover(ctx context.Context) ([]entities.JournalRecovery, error) {
+	entries, err := r.journal.GetUnfinished(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load operation journal: %w", err)
+	}
+
+	// Operações reenfileiradas ganham novos IDs; as dependências são traduzidas.
+	// halted são as que não foram retomadas nem concluídas.
+	resumedIDs := make(map[string]string)
+	halted := make(map[string]bool)
+	recoveries := make([]entities.JournalRecovery, 0, len(entries))
+
+	for _, entry := range entries {
+		recovery := entities.JournalRecovery{
+			OperationID: entry.OperationID,
+			BoosterID:   entry.BoosterID,
+			Operation:   entry.Operation,
+			Phase:       entry.Phase,
+		}
+
+		var err error
+		switch entry.Phase {
+		case entities.JournalExecutorFinished:
+			recovery.Action, err = r.finish(ctx, entry)
+		case entities.JournalQueued:
+			recovery.Action = entities.JournalActionResumed
+			recovery.NewOperationID, err = r.resume(entry, resumedIDs, halted)
+		default:
+			recovery.Action = entities.JournalActionManualReview
+			recovery.Error = fmt.Sprintf("%s was interrupted while running; the system may be partially changed", entry.Operation)
+			r.logger.Warnf("%s of %s needs manual review: %s", entry.OperationID, entry.BoosterID, recovery.Error)
+		}
+		if err != nil {
+			recovery.Action = entities.JournalActionFailed
+			recovery.Error = err.Error()
+		}
+		if recovery.Action != entities.JournalActionResumed && recovery.Action != entities.JournalActionCompleted {
+			halted[entry.OperationID] = true
+		}
+
+		if err := r.journal.UpdatePhase(ctx, entry.OperationID, journalPhaseFor(recovery.Action), recovery.Error); err != nil {
+			r.logger.Errorf("failed to update journal for %s: %v", entry.OperationID, err)
+		}
+		recoveries = append(recoveries, recovery)
+	}
+
+	if err := r.journal.DeleteFinishedBefore(ctx, time.Now().Add(-journalRetention)); err != nil {
+		r.logger.Errorf("failed to prune operation journal: %v", err)
+	}
+	return recoveries, nil
+}
+
+// resume reenfileira a operação mantendo o lote e a ordem de dependências
+func (r *JournalRecoverer) resume(entry entities.JournalEntry, resumedIDs map[string]string, halted map[string]bool) (string, error) {
+	if _, exists := r.processor.GetBooster(entry.BoosterID); !exists {
+		return "", fmt.Errorf("booster with ID %s not found", entry.BoosterID)
+	}
+
+	dependsOn := make([]string, 0, len(entry.DependsOn))
+	for _, depID := range entry.DependsOn {
+		if halted[depID] {
+			return "", fmt.Errorf("dependency %s was not resumed", depID)
+		}
+		if newID, ok := resumedIDs[depID]; ok {
+			dependsOn = append(dependsOn, newID)
+		}
+	}
+
+	// Operações retomadas não foram pedidas agora pelo usuário: vão para a faixa de trabalho agendado
+	operationID, err := r.queueManager.AddWithOptions(entry.BoosterID, entry.Operation, QueueItemOptions{
+		BatchID:   entry.BatchID,
+		DependsOn: dependsOn,
+		Priority:  entities.PriorityScheduled,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	resumedIDs[entry.OperationID] = operationID
+	r.eventEmitter.EmitQueued(entry.BoosterID, operationID, entry.Operation, r.queueManager.Size())
+	return operationID, nil
+}
+
+// finish conclui uma operação cujo executor terminou mas cujo estado não foi salvo
+func (r *JournalRecoverer) finish(ctx context.Context, entry entities.JournalEntry) (entities.JournalRecoveryAction, error) {
+	booster, exists := r.processor.GetBooster(entry.BoosterID)
+	if !exists {
+		return entities.JournalActionFailed, fmt.Errorf("booster with ID %s not found", entry.BoosterID)
+	}
+
+	switch entry.Operation {
+	case entities.ApplyOperationType:
+		if entry.Success {
+			result := &entities.BoostApplyResult{Success: true, Message: entry.Message, BackupData: entry.BackupData}
+			if err := r.processor.saveRollbackState(ctx, entry.BoosterID, result, booster); err != nil {
+				return entities.JournalActionFailed, err
+			}
+			return entities.JournalActionCompleted, nil
+		}
+
+		// A aplicação parou no meio: desfaz o que o executor chegou a alterar
+		if len(entry.BackupData) == 0 {
+			return entities.JournalActionFailed, fmt.Errorf("apply did not finish and no backup was recorded")
+		}
+		result, err := booster.Revert(ctx, entry.BackupData)
+		if err != nil {
+			return entities.JournalActionFailed, err
+		}
+		if result != nil && !result.Success {
+			return entities.JournalActionFailed, fmt.Errorf("rollback failed: %s", result.Message)
+		}
+		return entities.JournalActionRolledBack, nil
+
+	case entities.RevertOperationType:
+		if !entry.Success {
+			return entities.JournalActionFailed, fmt.Errorf("revert did not finish: %s", entry.Message)
+		}
+		result := &entities.BoostRevertResult{Success: true, Message: entry.Message}
+		if err := r.processor.updateRollbackState(ctx, entry.BoosterID, result); err != nil {
+			return entities.JournalActionFailed, err
+		}
+		return entities.JournalActionCompleted, nil
+
+	default:
+		return entities.JournalActionFailed, fmt.Errorf("invalid operation type: %s", entry.Operation)
+	}
+}
+
+func journalPhaseFor(action entities.JournalRecoveryAction) entities.JournalPhase {
+	switch action {
+	case entities.JournalActionCompleted:
+		return entities.JournalStateSaved
+	case entities.JournalActionFailed, entities.JournalActionManualReview:
+		return entities.JournalFailed
+	default:
+		return entities.JournalRecovered
+	}
+}
@@ -0,0 +1,141 @@
+package booster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	storagemodels "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+	repos "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/repositories"
+)
+
+// helper para criar o journal num banco em memória compartilhado entre as conexões
+func setupJournalForTest(t *testing.T) *repos.OperationJournalRepo {
+	dsn := fmt.Sprintf("file:%s-%d?mode=memory&cache=shared", t.Name(), time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&storagemodels.OperationJournalEntry{}))
+	return repos.NewOperationJournalRepo(db)
+}
+
+func newJournaledService(t *testing.T, boosters ...*testBooster) (*Service, *repos.OperationJournalRepo) {
+	service, _ := newTestService(t, boosters...)
+	journal := setupJournalForTest(t)
+	service.processor.SetJournal(journal)
+	service.queueManager.SetJournal(journal)
+	return service, journal
+}
+
+func TestJournal_RecordsPhasesUntilStateSaved(t *testing.T) {
+	service, journal := newJournaledService(t, &testBooster{id: "a", execResult: okResult()})
+	ctx := context.Background()
+
+	res, err := service.InitBoosterApply(ctx, "a")
+	require.NoError(t, err)
+	require.NoError(t, service.queueManager.AwaitOperation(ctx, res.OperationID))
+
+	entry, err := journal.GetByID(ctx, res.OperationID)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, entities.JournalStateSaved, entry.Phase)
+	assert.True(t, entry.Success)
+	assert.Equal(t, "v", entry.BackupData["k"])
+
+	unfinished, err := journal.GetUnfinished(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, unfinished)
+}
+
+func TestJournal_RecordsExecutorFailure(t *testing.T) {
+	service, journal := newJournaledService(t, &testBooster{id: "a", execErr: errors.New("exec failed")})
+	ctx := context.Background()
+
+	res, err := service.InitBoosterApply(ctx, "a")
+	require.NoError(t, err)
+	require.Error(t, service.queueManager.AwaitOperation(ctx, res.OperationID))
+
+	entry, err := journal.GetByID(ctx, res.OperationID)
+	require.NoError(t, err)
+	require.NotNil(t, entry)
+	assert.Equal(t, entities.JournalFailed, entry.Phase)
+	assert.Equal(t, "exec failed", entry.Error)
+}
+
+func TestJournalRecoverer_RecoversInterruptedOperations(t *testing.T) {
+	partial := &testBooster{id: "partial", revertRes: &entities.BoostRevertResult{Success: true}}
+	proc := newResolverProcessor(t,
+		&testBooster{id: "queued"},
+		&testBooster{id: "dependent"},
+		&testBooster{id: "started"},
+		&testBooster{id: "after-started"},
+		&testBooster{id: "finished"},
+		partial,
+	)
+	journal := setupJournalForTest(t)
+	queueManager := NewManager(20)
+	queueManager.SetJournal(journal)
+	ctx := context.Background()
+
+	now := time.Now()
+	for i, entry := range []entities.JournalEntry{
+		{OperationID: "op-queued", BoosterID: "queued", Phase: entities.JournalQueued},
+		{OperationID: "op-dependent", BoosterID: "dependent", Phase: entities.JournalQueued, DependsOn: []string{"op-queued"}},
+		{OperationID: "op-started", BoosterID: "started", Phase: entities.JournalStarted},
+		{OperationID: "op-after-started", BoosterID: "after-started", Phase: entities.JournalQueued, DependsOn: []string{"op-started"}},
+		{OperationID: "op-finished", BoosterID: "finished", Phase: entities.JournalExecutorFinished, Success: true, BackupData: entities.BackupData{"k": "finished"}},
+		{OperationID: "op-partial", BoosterID: "partial", Phase: entities.JournalExecutorFinished, BackupData: entities.BackupData{"k": "partial"}},
+		{OperationID: "op-done", BoosterID: "queued", Phase: entities.JournalStateSaved},
+	} {
+		entry.Operation = entities.ApplyOperationType
+		entry.QueuedAt = now.Add(time.Duration(i) * time.Second)
+		require.NoError(t, journal.Save(ctx, &entry))
+	}
+
+	recoveries, err := NewJournalRecoverer(journal, proc, queueManager, newRecordingEmitter()).Recover(ctx)
+	require.NoError(t, err)
+	require.Len(t, recoveries, 6)
+
+	actions := make(map[string]entities.JournalRecovery)
+	for _, recovery := range recoveries {
+		actions[recovery.OperationID] = recovery
+	}
+	assert.Equal(t, entities.JournalActionResumed, actions["op-queued"].Action)
+	assert.Equal(t, entities.JournalActionResumed, actions["op-dependent"].Action)
+	assert.Equal(t, entities.JournalActionCompleted, actions["op-finished"].Action)
+	assert.Equal(t, entities.JournalActionRolledBack, actions["op-partial"].Action)
+
+	// uma operação que estava executando não é repetida, nem as que dependem dela
+	assert.Equal(t, entities.JournalActionManualReview, actions["op-started"].Action)
+	assert.NotEmpty(t, actions["op-started"].Error)
+	assert.Nil(t, queueManager.GetQueuedItem("started"))
+	assert.Equal(t, entities.JournalActionFailed, actions["op-after-started"].Action)
+	assert.Nil(t, queueManager.GetQueuedItem("after-started"))
+
+	// a dependência aponta para a operação reenfileirada
+	dependent := queueManager.GetQueuedItem("dependent")
+	require.NotNil(t, dependent)
+	assert.Equal(t, []string{actions["op-queued"].NewOperationID}, dependent.DependsOn)
+
+	state, err := proc.GetRollbackState(ctx, "finished")
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.True(t, state.Applied)
+	assert.Equal(t, "finished", state.BackupData["k"])
+
+	assert.Equal(t, "partial", partial.revertBackup["k"])
+
+	// as entradas antigas ficam terminais; só as reenfileiradas estão pendentes
+	unfinished, err := journal.GetUnfinished(ctx)
+	require.NoError(t, err)
+	require.Len(t, unfinished, 2)
+	assert.Equal(t, actions["op-queued"].NewOperationID, unfinished[0].OperationID)
+	assert.Equal(t, actions["op-dependent"].NewOperationID, unfinished[1].OperationID)
+}
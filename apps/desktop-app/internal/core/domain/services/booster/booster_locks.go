@@ -0,0 +1,83 @@
+package booster
+
+import (
+	"context"
+	"sync"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// resourceLocks serializa as operações que alteram os mesmos recursos do sistema.
+// Todos os recursos de uma operação são obtidos de uma vez, então não há ordem de
+// aquisição a respeitar nem risco de deadlock entre operações.
+type resourceLocks struct {
+	mu       sync.Mutex
+	held     map[string]bool
+	released chan struct{}
+}
+
+func newResourceLocks() *resourceLocks {
+	return &resourceLocks{
+		held:     make(map[string]bool),
+		released: make(chan struct{}),
+	}
+}
+
+// acquire bloqueia até todos os recursos estarem livres e os trava. A função
+// retornada libera os recursos e deve ser chamada exatamente uma vez.
+func (l *resourceLocks) acquire(ctx context.Context, stopCh <-chan struct{}, resources []string) (func(), error) {
+	for {
+		l.mu.Lock()
+		if l.freeUnsafe(resources) {
+			for _, resource := range resources {
+				l.held[resource] = true
+			}
+			l.mu.Unlock()
+			return func() { l.release(resources) }, nil
+		}
+		released := l.released
+		l.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-stopCh:
+			return nil, ErrQueueStopped
+		}
+	}
+}
+
+func (l *resourceLocks) freeUnsafe(resources []string) bool {
+	for _, resource := range resources {
+		if l.held[resource] {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *resourceLocks) release(resources []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, resource := range resources {
+		delete(l.held, resource)
+	}
+	// Acorda quem está esperando para tentar de novo
+	close(l.released)
+	l.released = make(chan struct{})
+}
+
+// lockResources retorna os recursos declarados mais o recurso implícito do booster, sem duplicatas
+func lockResources(boosterID string, declared []string) []string {
+	resources := []string{entities.BoosterResource(boosterID)}
+	seen := map[string]bool{resources[0]: true}
+	for _, resource := range declared {
+		if resource != "" && !seen[resource] {
+			seen[resource] = true
+			resources = append(resources, resource)
+		}
+	}
+	return resources
+}
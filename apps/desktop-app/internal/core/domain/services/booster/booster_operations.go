@@ -0,0 +1,42 @@
+package booster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// GetOperation retorna o ciclo de vida persistido de uma operação
+func (s *Service) GetOperation(ctx context.Context, operationID string) (*entities.OperationDetails, error) {
+	operation, err := s.historyRecorder.GetOperation(ctx, operationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load operation %s: %w", operationID, err)
+	}
+	if operation == nil {
+		return nil, fmt.Errorf("%w: %s", ErrOperationNotFound, operationID)
+	}
+
+	details := entities.NewOperationDetails(*operation)
+	return &details, nil
+}
+
+// GetBatch retorna o estado agregado de um lote e de cada uma das suas operações
+func (s *Service) GetBatch(ctx context.Context, batchID string) (*entities.BatchDetails, error) {
+	operations, err := s.historyRecorder.GetBatchOperations(ctx, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load batch %s: %w", batchID, err)
+	}
+	if len(operations) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrBatchNotFound, batchID)
+	}
+
+	batch := entities.NewBatchDetails(batchID, operations)
+	return &batch, nil
+}
+
+var (
+	ErrOperationNotFound    = fmt.Errorf("operation not found")
+	ErrBatchNotFound        = fmt.Errorf("batch not found")
+	ErrOperationInterrupted = fmt.Errorf("operation interrupted before completion")
+)
@@ -0,0 +1,178 @@
+package booster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	storagemodels "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+	repos "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/repositories"
+)
+
+// helper para criar o histórico num banco em memória compartilhado entre as conexões
+func setupRecorderForTest(t *testing.T) *Recorder {
+	dsn := fmt.Sprintf("file:%s-%d?mode=memory&cache=shared", t.Name(), time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&storagemodels.BoostOperation{}))
+	return NewRecorder(repos.NewBoostOperationsRepo(db))
+}
+
+func newRecordedService(t *testing.T, boosters ...*testBooster) (*Service, *recordingEmitter) {
+	service, emitter := newTestService(t, boosters...)
+	recorder := setupRecorderForTest(t)
+	service.historyRecorder = recorder
+	service.workerPool.historyRecorder = recorder
+	service.queueManager.SetRecorder(recorder)
+	return service, emitter
+}
+
+func TestGetOperation_ReturnsPersistedLifecycle(t *testing.T) {
+	service, _ := newRecordedService(t,
+		&testBooster{id: "ok", execResult: &entities.BoostApplyResult{Success: true, Message: "dns updated"}},
+		&testBooster{id: "broken", execErr: errors.New("exec failed")},
+	)
+	ctx := context.Background()
+
+	ok, err := service.InitBoosterApply(ctx, "ok")
+	require.NoError(t, err)
+	require.NoError(t, service.queueManager.AwaitOperation(ctx, ok.OperationID))
+
+	details, err := service.GetOperation(ctx, ok.OperationID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OperationCompleted, details.Status)
+	assert.Equal(t, "ok", details.BoosterID)
+	assert.Equal(t, entities.ApplyOperationType, details.Operation)
+	assert.Equal(t, "dns updated", details.Message)
+	assert.Equal(t, 1, details.Attempts)
+	require.NotNil(t, details.StartedAt)
+	require.NotNil(t, details.CompletedAt)
+	assert.False(t, details.StartedAt.Before(details.QueuedAt))
+	// a duração é persistida em milissegundos
+	assert.InDelta(t, details.CompletedAt.Sub(*details.StartedAt), details.Duration, float64(time.Millisecond))
+
+	broken, err := service.InitBoosterApply(ctx, "broken")
+	require.NoError(t, err)
+	require.Error(t, service.queueManager.AwaitOperation(ctx, broken.OperationID))
+
+	details, err = service.GetOperation(ctx, broken.OperationID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OperationFailed, details.Status)
+	assert.Equal(t, "exec failed", details.Error)
+
+	_, err = service.GetOperation(ctx, "missing")
+	assert.ErrorIs(t, err, ErrOperationNotFound)
+}
+
+func TestGetBatch_AggregatesOperations(t *testing.T) {
+	service, emitter := newRecordedService(t,
+		&testBooster{id: "a", execResult: okResult(), revertRes: &entities.BoostRevertResult{Success: true}},
+		&testBooster{id: "b", execErr: errors.New("exec failed")},
+	)
+	ctx := context.Background()
+
+	res, err := service.InitBoosterApplyTransaction(ctx, []string{"a", "b"})
+	require.NoError(t, err)
+	emitter.wait(t)
+
+	batch, err := service.GetBatch(ctx, res.OperationID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OperationFailed, batch.Status)
+	assert.Equal(t, 3, batch.Total)
+	assert.Equal(t, 2, batch.Completed)
+	assert.Equal(t, 1, batch.Failed)
+	require.Len(t, batch.Operations, 3)
+	assert.Equal(t, entities.RevertOperationType, batch.Operations[2].Operation)
+	require.NotNil(t, batch.CompletedAt)
+
+	_, err = service.GetBatch(ctx, "missing")
+	assert.ErrorIs(t, err, ErrBatchNotFound)
+}
+
+func TestRecorder_RecordsQueuedAndCancelledOperations(t *testing.T) {
+	recorder := setupRecorderForTest(t)
+	queueManager := NewManager(10)
+	queueManager.SetRecorder(recorder)
+	ctx := context.Background()
+
+	applyID, err := queueManager.Add("a", entities.ApplyOperationType)
+	require.NoError(t, err)
+
+	operation, err := recorder.GetOperation(ctx, applyID)
+	require.NoError(t, err)
+	require.NotNil(t, operation)
+	assert.Equal(t, entities.OperationQueued, operation.Status)
+
+	// a operação contrária substitui a que ainda estava na queue
+	revertID, err := queueManager.Add("a", entities.RevertOperationType)
+	require.NoError(t, err)
+
+	operation, err = recorder.GetOperation(ctx, applyID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OperationCancelled, operation.Status)
+	assert.NotNil(t, operation.CompletedAt)
+
+	// na inicialização, registros abertos da execução anterior são encerrados
+	closed, err := recorder.CloseInterrupted(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), closed)
+
+	operation, err = recorder.GetOperation(ctx, revertID)
+	require.NoError(t, err)
+	assert.Equal(t, entities.OperationCancelled, operation.Status)
+	assert.Equal(t, ErrOperationInterrupted.Error(), operation.ErrorMsg)
+}
+
+func TestRecorder_OperationStatsPerBoosterAndWindow(t *testing.T) {
+	recorder := setupRecorderForTest(t)
+	recorder.SetVersionLookup(func(boosterID string) string { return "v2" })
+	ctx := context.Background()
+	now := time.Now()
+
+	record := func(id, boosterID string, submittedAt time.Time, result *entities.BoostOperation, err error) {
+		item := entities.QueueItem{OperationID: id, BoosterID: boosterID, Operation: entities.ApplyOperationType, SubmittedAt: submittedAt}
+		require.NoError(t, recorder.RecordQueued(item))
+		require.NoError(t, recorder.RecordStarted(item))
+		require.NoError(t, recorder.RecordOperation(item, result, err))
+	}
+	record("a1", "a", now, &entities.BoostOperation{Message: "ok", Attempts: 2}, nil)
+	record("a2", "a", now, nil, errors.New("exec failed"))
+	record("b1", "b", now, &entities.BoostOperation{}, nil)
+	record("old", "b", now.Add(-48*time.Hour), nil, errors.New("exec failed"))
+
+	operation, err := recorder.GetOperation(ctx, "a1")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", operation.Version)
+	assert.Equal(t, "ok", operation.Message)
+	assert.Equal(t, 2, operation.Attempts)
+
+	since := now.Add(-time.Hour)
+	stats, err := recorder.GetOperationStats(ctx, entities.OperationStatsFilter{Since: &since})
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Total)
+	assert.Equal(t, 2, stats.Successful)
+	assert.Equal(t, 1, stats.Failed)
+	assert.Equal(t, 1, stats.RetriedOperations)
+	assert.InDelta(t, 66.67, stats.SuccessRate, 0.01)
+	assert.Equal(t, 3, stats.ApplyOperations)
+	assert.Positive(t, stats.AverageDuration)
+
+	require.Contains(t, stats.ByBooster, "a")
+	assert.Equal(t, 2, stats.ByBooster["a"].Total)
+	assert.InDelta(t, 50, stats.ByBooster["a"].FailureRate, 0.01)
+	assert.Equal(t, 1, stats.ByBooster["b"].Total)
+
+	stats, err = recorder.GetOperationStats(ctx, entities.OperationStatsFilter{BoosterID: "b"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Total)
+	assert.Equal(t, 1, stats.Failed)
+	require.NotNil(t, stats.LastOperationAt)
+}
@@ -0,0 +1,141 @@
+package booster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// ParamStore persiste os valores dos parâmetros configurados pelo usuário
+type ParamStore interface {
+	Get(ctx context.Context, boosterID string) (entities.BoosterParams, error)
+	Save(ctx context.Context, boosterID string, params entities.BoosterParams) error
+}
+
+// SetParamStore ativa os parâmetros configuráveis; sem ele os executores usam os padrões
+func (p *BoosterProcessor) SetParamStore(store ParamStore) {
+	p.params = store
+}
+
+// BoosterParams retorna os valores que o booster usa na próxima execução:
+// os salvos pelo usuário sobre os padrões do schema
+func (p *BoosterProcessor) BoosterParams(ctx context.Context, boosterID string) (entities.BoosterParams, error) {
+	booster, exists := p.GetBooster(boosterID)
+	if !exists {
+		return nil, fmt.Errorf("booster with ID %s not found", boosterID)
+	}
+	return p.resolveParams(ctx, booster)
+}
+
+func (p *BoosterProcessor) resolveParams(ctx context.Context, booster inbound.BoosterUseCase) (entities.BoosterParams, error) {
+	schema := booster.GetEntity().Params
+	if len(schema) == 0 || p.params == nil {
+		return schema.Defaults(), nil
+	}
+	stored, err := p.params.Get(ctx, booster.GetEntity().ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load booster parameters: %w", err)
+	}
+	return schema.Resolve(stored), nil
+}
+
+// withParams entrega ao executor os valores configurados. Se não for possível
+// carregá-los, o executor usa os padrões.
+func (p *BoosterProcessor) withParams(ctx context.Context, booster inbound.BoosterUseCase) context.Context {
+	if len(booster.GetEntity().Params) == 0 {
+		return ctx
+	}
+	params, err := p.resolveParams(ctx, booster)
+	if err != nil {
+		p.logger.Warnf("using default parameters for %s: %v", booster.GetEntity().ID, err)
+		return ctx
+	}
+	return entities.WithBoosterParams(ctx, params)
+}
+
+// GetBoosterParams retorna o schema e os valores atuais dos parâmetros do booster
+func (s *Service) GetBoosterParams(ctx context.Context, boosterID string) (*entities.BoosterSettings, error) {
+	booster, exists := s.processor.GetBooster(boosterID)
+	if !exists {
+		return nil, fmt.Errorf("booster with ID %s not found", boosterID)
+	}
+	params, err := s.processor.resolveParams(ctx, booster)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.BoosterSettings{
+		BoosterID: boosterID,
+		Schema:    booster.GetEntity().Params,
+		Params:    params,
+	}, nil
+}
+
+// SetBoosterParams valida e salva os valores dos parâmetros; os ausentes voltam
+// ao padrão. Se o booster estiver aplicado e os valores mudaram, a reaplicação é
+// enfileirada mantendo o backup original para a reversão.
+func (s *Service) SetBoosterParams(ctx context.Context, boosterID string, values map[string]interface{}) (*entities.BoosterSettings, error) {
+	booster, exists := s.processor.GetBooster(boosterID)
+	if !exists {
+		return nil, fmt.Errorf("booster with ID %s not found", boosterID)
+	}
+	schema := booster.GetEntity().Params
+	if len(schema) == 0 {
+		return nil, fmt.Errorf("%w: booster %s has no parameters", entities.ErrInvalidParam, boosterID)
+	}
+	if s.processor.params == nil {
+		return nil, fmt.Errorf("booster parameters are not configured")
+	}
+
+	params, err := schema.Validate(values)
+	if err != nil {
+		return nil, err
+	}
+	current, err := s.processor.resolveParams(ctx, booster)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.processor.params.Save(ctx, boosterID, params); err != nil {
+		return nil, fmt.Errorf("failed to save booster parameters: %w", err)
+	}
+
+	settings := &entities.BoosterSettings{
+		BoosterID: boosterID,
+		Schema:    schema,
+		Params:    params,
+	}
+	if params.Equal(current) {
+		return settings, nil
+	}
+
+	settings.ReapplyOperationID, err = s.reapplyWithParams(ctx, boosterID)
+	if err != nil {
+		return settings, err
+	}
+	return settings, nil
+}
+
+// reapplyWithParams enfileira a reaplicação de um booster aplicado. Uma operação
+// já na fila lê os valores novos quando executar, então nada é enfileirado.
+func (s *Service) reapplyWithParams(ctx context.Context, boosterID string) (string, error) {
+	if s.queueManager.IsInQueue(boosterID) {
+		return "", nil
+	}
+	applied, err := s.processor.IsApplied(ctx, boosterID)
+	if err != nil || !applied {
+		return "", err
+	}
+	if err := s.processor.ValidateBoosterOperation(ctx, boosterID, entities.ApplyOperationType); err != nil {
+		return "", fmt.Errorf("parameters saved but re-apply was rejected: %w", err)
+	}
+
+	operationID, err := s.queueManager.AddWithOptions(boosterID, entities.ApplyOperationType, QueueItemOptions{
+		Priority: entities.PriorityInteractive,
+	})
+	if err != nil {
+		return "", fmt.Errorf("parameters saved but re-apply could not be queued: %w", err)
+	}
+	s.eventEmitter.EmitQueued(boosterID, operationID, entities.ApplyOperationType, s.queueManager.Size())
+	return operationID, nil
+}
@@ -0,0 +1,116 @@
+package booster
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	storagemodels "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+	repos "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/repositories"
+)
+
+// helper para criar o repositório de parâmetros num banco em memória compartilhado
+func setupSettingsRepoForTest(t *testing.T) *repos.BoosterSettingsRepo {
+	dsn := fmt.Sprintf("file:%s-%d?mode=memory&cache=shared", t.Name(), time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&storagemodels.BoosterSettings{}))
+	return repos.NewBoosterSettingsRepo(db)
+}
+
+var testParamSchema = entities.ParamSchema{
+	{Key: "mode", Type: entities.ParamEnum, Default: "auto", Options: []string{"auto", "fast"}},
+	{Key: "level", Type: entities.ParamInt, Default: 3, Min: 1, Max: 5},
+	{Key: "servers", Type: entities.ParamIPList, Default: []string{"1.1.1.1"}, MaxItems: 2},
+	{Key: "label", Type: entities.ParamString, Default: "", MaxLength: 8},
+}
+
+func newParamsService(t *testing.T, boosters ...*testBooster) *Service {
+	service, _ := newTestService(t, boosters...)
+	service.processor.SetParamStore(setupSettingsRepoForTest(t))
+	return service
+}
+
+func TestSetBoosterParams_ValidatesAndPersists(t *testing.T) {
+	service := newParamsService(t, &testBooster{id: "a", params: testParamSchema})
+	ctx := context.Background()
+
+	settings, err := service.GetBoosterParams(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, entities.BoosterParams{"mode": "auto", "level": 3, "servers": []string{"1.1.1.1"}, "label": ""}, settings.Params)
+
+	for name, values := range map[string]map[string]interface{}{
+		"enum option":   {"mode": "slow"},
+		"int range":     {"level": 9},
+		"int type":      {"level": 2.5},
+		"ip address":    {"servers": []interface{}{"1.1.1.1", "not-an-ip"}},
+		"ip list size":  {"servers": []string{"1.1.1.1", "8.8.8.8", "9.9.9.9"}},
+		"string length": {"label": "much too long"},
+		"unknown key":   {"other": "x"},
+	} {
+		_, err := service.SetBoosterParams(ctx, "a", values)
+		assert.ErrorIs(t, err, entities.ErrInvalidParam, name)
+	}
+
+	// valores vindos do frontend chegam como JSON: número float64 e lista []interface{}
+	settings, err = service.SetBoosterParams(ctx, "a", map[string]interface{}{
+		"mode":    "fast",
+		"level":   float64(5),
+		"servers": []interface{}{" 9.9.9.9", "2606:4700:4700::1111"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, settings.ReapplyOperationID, "booster is not applied")
+
+	settings, err = service.GetBoosterParams(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, entities.BoosterParams{
+		"mode":    "fast",
+		"level":   5,
+		"servers": []string{"9.9.9.9", "2606:4700:4700::1111"},
+		"label":   "",
+	}, settings.Params)
+
+	_, err = service.SetBoosterParams(ctx, "a", map[string]interface{}{"mode": "fast"})
+	require.NoError(t, err)
+	settings, err = service.GetBoosterParams(ctx, "a")
+	require.NoError(t, err)
+	assert.Equal(t, 3, settings.Params.Int("level"), "omitted values go back to the default")
+}
+
+func TestSetBoosterParams_ReappliesAppliedBoosterKeepingOriginalBackup(t *testing.T) {
+	b := &testBooster{id: "a", params: testParamSchema, execResult: okResult()}
+	service := newParamsService(t, b)
+	ctx := context.Background()
+
+	res, err := service.InitBoosterApply(ctx, "a")
+	require.NoError(t, err)
+	require.NoError(t, service.queueManager.AwaitOperation(ctx, res.OperationID))
+	assert.Equal(t, "auto", b.lastParams.String("mode"))
+
+	// na reaplicação o executor lê os valores já alterados pela primeira aplicação
+	b.execResult = &entities.BoostApplyResult{Success: true, BackupData: entities.BackupData{"k": "boosted", "new": "x"}}
+	settings, err := service.SetBoosterParams(ctx, "a", map[string]interface{}{"mode": "fast"})
+	require.NoError(t, err)
+	require.NotEmpty(t, settings.ReapplyOperationID)
+	require.NoError(t, service.queueManager.AwaitOperation(ctx, settings.ReapplyOperationID))
+	assert.Equal(t, "fast", b.lastParams.String("mode"))
+
+	state, err := service.processor.GetRollbackState(ctx, "a")
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.True(t, state.Applied)
+	assert.Equal(t, "v", state.BackupData["k"], "revert must restore the values found before the first apply")
+	assert.Equal(t, "x", state.BackupData["new"])
+
+	settings, err = service.SetBoosterParams(ctx, "a", map[string]interface{}{"mode": "fast"})
+	require.NoError(t, err)
+	assert.Empty(t, settings.ReapplyOperationID, "unchanged values do not re-apply")
+	assert.Equal(t, 2, b.execCalls)
+}
@@ -3,24 +3,89 @@ package booster
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+	"github.com/oLenador/mulltbost/internal/core/application/ports/outbound"
 	"github.com/oLenador/mulltbost/internal/core/domain/entities"
-	repos "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/repositories"
+	"github.com/wailsapp/wails/lib/logger"
 )
 
 type BoosterProcessor struct {
-	rollbackRepo *repos.RollbackRepo
+	rollbackRepo outbound.BoosterStateRepository
+	activation   outbound.BoosterActivationRepository
+	journal      OperationJournal
+	params       ParamStore
+	capabilities *CapabilityProber
 	boosters     map[string]inbound.BoosterUseCase
 	boostersMu   sync.RWMutex
+	logger       *logger.CustomLogger
 }
 
-func NewBoosterProcessor(rollbackRepo *repos.RollbackRepo) *BoosterProcessor {
+func NewBoosterProcessor(rollbackRepo outbound.BoosterStateRepository) *BoosterProcessor {
 	return &BoosterProcessor{
 		rollbackRepo: rollbackRepo,
 		boosters:     make(map[string]inbound.BoosterUseCase),
+		logger:       logger.NewCustomLogger("[BoosterProcessor]"),
+	}
+}
+
+// SetJournal ativa o registro das fases executor_finished e state_saved no journal
+func (p *BoosterProcessor) SetJournal(journal OperationJournal) {
+	p.journal = journal
+}
+
+// SetActivationRepo faz cada aplicação ou reversão concluída atualizar o estado de ativação
+func (p *BoosterProcessor) SetActivationRepo(activation outbound.BoosterActivationRepository) {
+	p.activation = activation
+}
+
+// SetCapabilityProber faz a aplicação recusar boosters com requisitos não atendidos
+func (p *BoosterProcessor) SetCapabilityProber(prober *CapabilityProber) {
+	p.capabilities = prober
+}
+
+func (p *BoosterProcessor) checkRequirements(ctx context.Context, booster inbound.BoosterUseCase) error {
+	if p.capabilities == nil {
+		return nil
+	}
+	return p.capabilities.Check(ctx, booster.GetEntity())
+}
+
+// recordExecutorResult grava o resultado do executor antes de salvar o estado; se o
+// processo morrer entre os dois, a inicialização conclui ou desfaz a operação
+func (p *BoosterProcessor) recordExecutorResult(ctx context.Context, success bool, message string, backupData entities.BackupData) {
+	operationID := operationIDFromContext(ctx)
+	if p.journal == nil || operationID == "" {
+		return
+	}
+	if err := p.journal.RecordExecutorResult(ctx, operationID, success, message, backupData); err != nil {
+		p.logger.Warnf("failed to journal executor result for %s: %v", operationID, err)
+	}
+}
+
+// recordActivation leva uma transição já salva no estado de rollback para o estado
+// de ativação. Uma falha só é registrada: o estado de rollback é a referência e a
+// reconciliação da próxima inicialização corrige a divergência.
+func (p *BoosterProcessor) recordActivation(ctx context.Context, boosterID string, applied bool) {
+	if p.activation == nil {
+		return
+	}
+	if err := p.activation.SetAppliedState(ctx, boosterID, applied, ""); err != nil {
+		p.logger.Warnf("failed to update activation state for %s: %v", boosterID, err)
+	}
+}
+
+func (p *BoosterProcessor) recordStateSaved(ctx context.Context) {
+	operationID := operationIDFromContext(ctx)
+	if p.journal == nil || operationID == "" {
+		return
+	}
+	if err := p.journal.UpdatePhase(ctx, operationID, entities.JournalStateSaved, ""); err != nil {
+		p.logger.Warnf("failed to journal saved state for %s: %v", operationID, err)
 	}
 }
 
@@ -30,6 +95,9 @@ func (p *BoosterProcessor) RegisterBooster(booster inbound.BoosterUseCase) error
 	defer p.boostersMu.Unlock()
 	
 	info := booster.GetEntity()
+	if _, exists := p.boosters[info.ID]; exists {
+		return fmt.Errorf("%w: %s", ErrDuplicateBooster, info.ID)
+	}
 	p.boosters[info.ID] = booster
 	return nil
 }
@@ -62,12 +130,33 @@ func (p *BoosterProcessor) GetAllBoostersEntities() []entities.Booster {
 	return result
 }
 
+// BoosterVersion retorna a versão do booster registrado; vazio se ele não existe
+func (p *BoosterProcessor) BoosterVersion(boosterID string) string {
+	booster, exists := p.GetBooster(boosterID)
+	if !exists {
+		return ""
+	}
+	return booster.GetEntity().Version
+}
+
+// ExecutionPolicy retorna o timeout, a política de retry e os recursos declarados pelo booster
+func (p *BoosterProcessor) ExecutionPolicy(boosterID string) ExecutionPolicy {
+	booster, exists := p.GetBooster(boosterID)
+	if !exists {
+		return ExecutionPolicy{}
+	}
+	entity := booster.GetEntity()
+	return ExecutionPolicy{Timeout: entity.Timeout, Retry: entity.Retry, Resources: entity.Resources}
+}
+
 // ProcessApply processa a aplicação de um booster
 func (p *BoosterProcessor) ProcessApply(ctx context.Context, boosterID string) (*entities.BoostApplyResult, error) {
 	booster, exists := p.GetBooster(boosterID)
 	if !exists {
 		return nil, fmt.Errorf("booster with ID %s not found", boosterID)
 	}
+	// A verificação, a validação e a execução usam os parâmetros configurados
+	ctx = p.withParams(ctx, booster)
 
 	// Verifica se pode ser aplicado
 	if !booster.CanApply(ctx) {
@@ -77,6 +166,14 @@ func (p *BoosterProcessor) ProcessApply(ctx context.Context, boosterID string) (
 		}, nil
 	}
 
+	if err := p.checkRequirements(ctx, booster); err != nil {
+		return &entities.BoostApplyResult{
+			Success: false,
+			Message: err.Error(),
+			Error:   err,
+		}, nil
+	}
+
 	// Valida o booster
 	if err := booster.Validate(ctx); err != nil {
 		return &entities.BoostApplyResult{
@@ -86,18 +183,122 @@ func (p *BoosterProcessor) ProcessApply(ctx context.Context, boosterID string) (
 		}, nil
 	}
 
-	result, err := booster.Execute(ctx)
+	// Guarda o backup do último checkpoint para desfazer alterações parciais se a
+	// operação for cancelada e o executor não devolver o backup no resultado
+	var checkpointMu sync.Mutex
+	var checkpointBackup entities.BackupData
+	execCtx := entities.WithCheckpointRecorder(ctx, func(step string, backup entities.BackupData) {
+		checkpointMu.Lock()
+		defer checkpointMu.Unlock()
+		checkpointBackup = copyBackup(backup)
+	})
+
+	result, err := booster.Execute(execCtx)
+
+	// Depois que o executor retorna, o resultado precisa ser persistido mesmo que
+	// a operação tenha sido cancelada nesse meio tempo
+	persistCtx := context.WithoutCancel(ctx)
+	succeeded := result != nil && result.Success && err == nil
+	if !succeeded && (ctx.Err() != nil || entities.IsCancellation(err)) {
+		checkpointMu.Lock()
+		backup := checkpointBackup
+		checkpointMu.Unlock()
+		return result, p.compensate(persistCtx, booster, result, backup, cancellationCause(ctx, err))
+	}
+
+	if result != nil {
+		p.recordExecutorResult(persistCtx, succeeded, result.Message, result.BackupData)
+	}
 	if err != nil {
 		return result, err
 	}
 
-	if err := p.saveRollbackState(ctx, boosterID, result, booster); err != nil {
+	if err := p.saveRollbackState(persistCtx, boosterID, result, booster); err != nil {
 		return result, fmt.Errorf("failed to save booster state: %w", err)
 	}
+	p.recordStateSaved(persistCtx)
+	if result.Success {
+		p.recordActivation(persistCtx, boosterID, true)
+	}
 
 	return result, nil
 }
 
+// compensate desfaz as alterações parciais de uma aplicação cancelada. Usa o backup
+// devolvido pelo executor ou, na falta dele, o do último checkpoint.
+func (p *BoosterProcessor) compensate(ctx context.Context, booster inbound.BoosterUseCase, result *entities.BoostApplyResult, checkpoint entities.BackupData, cause error) error {
+	backup := checkpoint
+	message := cause.Error()
+	if result != nil {
+		if len(result.BackupData) > 0 {
+			backup = result.BackupData
+		}
+		if result.Message != "" {
+			message = result.Message
+		}
+	}
+	if len(backup) == 0 {
+		return cause
+	}
+
+	// Se o processo morrer durante a compensação, a inicialização desfaz com este backup
+	p.recordExecutorResult(ctx, false, message, backup)
+
+	revert, err := booster.Revert(ctx, backup)
+	if err == nil && revert != nil && !revert.Success {
+		err = fmt.Errorf("%s", revert.Message)
+	}
+	if err != nil {
+		return fmt.Errorf("%w; compensating revert failed: %v", cause, err)
+	}
+	return fmt.Errorf("%w; partial changes reverted", cause)
+}
+
+// cancellationCause normaliza o erro de uma execução interrompida pelo contexto
+func cancellationCause(ctx context.Context, err error) error {
+	if entities.IsCancellation(err) {
+		return err
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w: %v", entities.ErrOperationCancelled, ctx.Err(), err)
+	}
+	return fmt.Errorf("%w: %w", entities.ErrOperationCancelled, ctx.Err())
+}
+
+func copyBackup(backup entities.BackupData) entities.BackupData {
+	if backup == nil {
+		return nil
+	}
+	copied := make(entities.BackupData, len(backup))
+	for k, v := range backup {
+		copied[k] = v
+	}
+	return copied
+}
+
+// ProcessPlan gera a prévia das alterações que a aplicação do booster faria, sem executá-la
+func (p *BoosterProcessor) ProcessPlan(ctx context.Context, boosterID string) (*entities.BoostPlan, error) {
+	booster, exists := p.GetBooster(boosterID)
+	if !exists {
+		return nil, fmt.Errorf("booster with ID %s not found", boosterID)
+	}
+
+	plan, err := booster.Plan(p.withParams(ctx, booster))
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan booster %s: %w", boosterID, err)
+	}
+	if plan == nil {
+		plan = &entities.BoostPlan{}
+	}
+
+	plan.BoosterID = boosterID
+	plan.Operation = entities.ApplyOperationType
+	if plan.GeneratedAt.IsZero() {
+		plan.GeneratedAt = time.Now()
+	}
+	return plan, nil
+}
+
 // ProcessRevert processa a reversão de um booster
 func (p *BoosterProcessor) ProcessRevert(ctx context.Context, boosterID string) (*entities.BoostRevertResult, error) {
 	booster, exists := p.GetBooster(boosterID)
@@ -114,20 +315,80 @@ func (p *BoosterProcessor) ProcessRevert(ctx context.Context, boosterID string)
 		}, nil
 	}
 
+	// Carrega o backup salvo na aplicação
+	backupData, err := p.loadRevertBackup(ctx, booster)
+	if err != nil {
+		return &entities.BoostRevertResult{
+			Error:   err,
+			Success: false,
+			Message: err.Error(),
+		}, nil
+	}
+
 	// Executa a reversão
-	result, err := booster.Revert(ctx)
+	result, err := booster.Revert(ctx, backupData)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := p.updateRollbackState(ctx, boosterID, result); err != nil {
+	// Assim como na aplicação, o resultado é persistido mesmo que a operação
+	// tenha sido cancelada depois que o executor retornou
+	persistCtx := context.WithoutCancel(ctx)
+	if result != nil {
+		p.recordExecutorResult(persistCtx, result.Success, result.Message, nil)
+	}
+
+	if err := p.updateRollbackState(persistCtx, boosterID, result); err != nil {
 		// Log o erro mas não falha a operação
 		fmt.Printf("Warning: failed to update rollback state for %s: %v\n", boosterID, err)
+	} else {
+		p.recordStateSaved(persistCtx)
+		if result != nil && result.Success {
+			p.recordActivation(persistCtx, boosterID, false)
+		}
 	}
 
 	return result, nil
 }
 
+// loadRevertBackup retorna o BackupData persistido na aplicação do booster.
+// A reversão é recusada se não houver backup ou se ele foi gerado por uma
+// versão incompatível do booster.
+func (p *BoosterProcessor) loadRevertBackup(ctx context.Context, booster inbound.BoosterUseCase) (entities.BackupData, error) {
+	entity := booster.GetEntity()
+	state, err := p.rollbackRepo.GetByID(ctx, entity.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load rollback state: %w", err)
+	}
+	if state == nil || !state.Applied {
+		return nil, fmt.Errorf("%w for booster %s", ErrRollbackBackupMissing, entity.ID)
+	}
+	if !isBackupVersionCompatible(state.Version, entity.Version) {
+		return nil, fmt.Errorf("%w: backup %s, booster %s", ErrRollbackVersionIncompatible, state.Version, entity.Version)
+	}
+	if state.BackupData == nil {
+		return entities.BackupData{}, nil
+	}
+	return state.BackupData, nil
+}
+
+// isBackupVersionCompatible aceita backups da mesma versão major do booster;
+// backups sem versão registrada são aceitos
+func isBackupVersionCompatible(backupVersion, boosterVersion string) bool {
+	if backupVersion == "" || backupVersion == boosterVersion {
+		return true
+	}
+	return majorVersion(backupVersion) == majorVersion(boosterVersion)
+}
+
+func majorVersion(version string) string {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.Index(version, "."); i >= 0 {
+		return version[:i]
+	}
+	return version
+}
+
 // saveRollbackState salva o estado de rollback após aplicação
 func (p *BoosterProcessor) saveRollbackState(ctx context.Context, boosterID string, result *entities.BoostApplyResult, booster inbound.BoosterUseCase) error {
 	state := &entities.BoosterRollbackState{
@@ -137,6 +398,25 @@ func (p *BoosterProcessor) saveRollbackState(ctx context.Context, boosterID stri
 		Version:    booster.GetEntity().Version,
 	}
 
+	// Reaplicação de um booster já aplicado (ex.: após drift ou mudança de
+	// parâmetros): o backup original é mantido, senão a reversão restauraria os
+	// valores encontrados no drift. Só entram os valores que ele ainda não tinha.
+	previous, err := p.rollbackRepo.GetByID(ctx, boosterID)
+	if err != nil {
+		return err
+	}
+	if previous != nil && previous.Applied {
+		state.BackupData = mergeBackup(previous.BackupData, result.BackupData)
+		state.Version = previous.Version
+		state.AppliedAt = previous.AppliedAt
+		if !result.Success {
+			state.Applied = true
+			state.Status = entities.ExecutionDrifted
+			state.ErrorMsg = result.Message
+			return p.rollbackRepo.Save(ctx, state)
+		}
+	}
+
 	if result.Success {
 		now := time.Now()
 		state.AppliedAt = &now
@@ -149,6 +429,84 @@ func (p *BoosterProcessor) saveRollbackState(ctx context.Context, boosterID stri
 	return p.rollbackRepo.Save(ctx, state)
 }
 
+// mergeBackup completa o backup original com as chaves que só o novo backup tem
+func mergeBackup(original, latest entities.BackupData) entities.BackupData {
+	if original == nil {
+		return latest
+	}
+	merged := copyBackup(original)
+	for key, value := range latest {
+		if _, exists := merged[key]; !exists {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// ProcessVerify verifica se as alterações de um booster aplicado continuam em efeito
+func (p *BoosterProcessor) ProcessVerify(ctx context.Context, boosterID string) (*entities.BoostVerifyResult, error) {
+	booster, exists := p.GetBooster(boosterID)
+	if !exists {
+		return nil, fmt.Errorf("booster with ID %s not found", boosterID)
+	}
+
+	result, err := booster.Verify(p.withParams(ctx, booster))
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify booster %s: %w", boosterID, err)
+	}
+	if result == nil {
+		result = &entities.BoostVerifyResult{InEffect: true}
+	}
+
+	result.BoosterID = boosterID
+	if result.CheckedAt.IsZero() {
+		result.CheckedAt = time.Now()
+	}
+	return result, nil
+}
+
+// UpdateDriftState marca o estado de rollback de um booster aplicado como drifted
+// ou de volta a applied. Retorna true se o status mudou.
+func (p *BoosterProcessor) UpdateDriftState(ctx context.Context, boosterID string, drifted bool) (bool, error) {
+	state, err := p.rollbackRepo.GetByID(ctx, boosterID)
+	if err != nil {
+		return false, err
+	}
+	if state == nil || !state.Applied {
+		return false, nil
+	}
+
+	status := entities.ExecutionApplied
+	if drifted {
+		status = entities.ExecutionDrifted
+	}
+	if state.Status == status {
+		return false, nil
+	}
+
+	state.Status = status
+	if !drifted {
+		state.ErrorMsg = ""
+	}
+	return true, p.rollbackRepo.Save(ctx, state)
+}
+
+// GetAppliedStates retorna os estados de rollback dos boosters aplicados
+func (p *BoosterProcessor) GetAppliedStates(ctx context.Context) ([]entities.BoosterRollbackState, error) {
+	states, err := p.rollbackRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make([]entities.BoosterRollbackState, 0, len(states))
+	for _, state := range states {
+		if state.Applied {
+			applied = append(applied, state)
+		}
+	}
+	return applied, nil
+}
+
 // updateRollbackState atualiza o estado de rollback após reversão
 func (p *BoosterProcessor) updateRollbackState(ctx context.Context, boosterID string, result *entities.BoostRevertResult) error {
 	rollbackEntity, err := p.rollbackRepo.GetByID(ctx, boosterID)
@@ -180,6 +538,14 @@ func (p *BoosterProcessor) GetRollbackState(ctx context.Context, boosterID strin
 
 // ValidateBooster validate if a booster operationcan be applied
 func (p *BoosterProcessor) ValidateBoosterOperation(ctx context.Context, boosterID string, operation entities.BoosterOperationType) error {
+	if err := p.validateExecution(ctx, boosterID, operation); err != nil {
+		return err
+	}
+	return p.validateRelations(ctx, boosterID, operation)
+}
+
+// validateExecution verifica apenas se o próprio booster pode executar a operação
+func (p *BoosterProcessor) validateExecution(ctx context.Context, boosterID string, operation entities.BoosterOperationType) error {
 	booster, exists := p.GetBooster(boosterID)
 	if !exists {
 		return fmt.Errorf("booster with ID %s not found", boosterID)
@@ -187,23 +553,135 @@ func (p *BoosterProcessor) ValidateBoosterOperation(ctx context.Context, booster
 
 	switch operation {
 	case entities.BoosterOperationType(entities.ApplyOperationType):
+		ctx = p.withParams(ctx, booster)
 		if !booster.CanApply(ctx) {
 			return fmt.Errorf("booster cannot be applied at this time")
 		}
+		if err := p.checkRequirements(ctx, booster); err != nil {
+			return err
+		}
 		return booster.Validate(ctx)
 	case entities.BoosterOperationType(entities.RevertOperationType):
 		if !booster.CanRevert(ctx) {
 			return fmt.Errorf("booster cannot be reverted at this time")
 		}
-		return nil
+		_, err := p.loadRevertBackup(ctx, booster)
+		return err
 	default:
 		return fmt.Errorf("invalid operation type: %s", operation)
 	}
 }
 
+// validateRelations verifica dependências e conflitos contra o estado aplicado atual
+func (p *BoosterProcessor) validateRelations(ctx context.Context, boosterID string, operation entities.BoosterOperationType) error {
+	booster, exists := p.GetBooster(boosterID)
+	if !exists {
+		return fmt.Errorf("booster with ID %s not found", boosterID)
+	}
+
+	switch operation {
+	case entities.ApplyOperationType:
+		for _, depID := range booster.GetEntity().Dependencies {
+			applied, err := p.IsApplied(ctx, depID)
+			if err != nil {
+				return err
+			}
+			if !applied {
+				return entities.NewBoosterValidationError(boosterID, entities.ValidationMissingDependency,
+					[]string{depID}, "dependency %s is not applied", depID)
+			}
+		}
+		for _, conflictID := range p.conflictingIDs(boosterID) {
+			applied, err := p.IsApplied(ctx, conflictID)
+			if err != nil {
+				return err
+			}
+			if applied {
+				return entities.NewBoosterValidationError(boosterID, entities.ValidationConflict,
+					[]string{conflictID}, "conflicts with applied booster %s", conflictID)
+			}
+		}
+	case entities.RevertOperationType:
+		for _, dependentID := range p.dependentIDs(boosterID) {
+			applied, err := p.IsApplied(ctx, dependentID)
+			if err != nil {
+				return err
+			}
+			if applied {
+				return entities.NewBoosterValidationError(boosterID, entities.ValidationDependentApplied,
+					[]string{dependentID}, "applied booster %s depends on it", dependentID)
+			}
+		}
+	}
+	return nil
+}
+
+// IsApplied informa se o booster está aplicado segundo o estado de rollback persistido
+func (p *BoosterProcessor) IsApplied(ctx context.Context, boosterID string) (bool, error) {
+	state, err := p.rollbackRepo.GetByID(ctx, boosterID)
+	if err != nil {
+		return false, err
+	}
+	return state != nil && state.Applied, nil
+}
+
+// conflictingIDs retorna os conflitos declarados pelo booster e pelos boosters que o declaram
+func (p *BoosterProcessor) conflictingIDs(boosterID string) []string {
+	p.boostersMu.RLock()
+	defer p.boostersMu.RUnlock()
+
+	seen := make(map[string]bool)
+	result := make([]string, 0)
+	if booster, exists := p.boosters[boosterID]; exists {
+		for _, id := range booster.GetEntity().Conflicts {
+			if id != boosterID && !seen[id] {
+				seen[id] = true
+				result = append(result, id)
+			}
+		}
+	}
+	for id, booster := range p.boosters {
+		if id == boosterID || seen[id] {
+			continue
+		}
+		for _, conflictID := range booster.GetEntity().Conflicts {
+			if conflictID == boosterID {
+				seen[id] = true
+				result = append(result, id)
+				break
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// dependentIDs retorna os boosters registrados que declaram dependência do booster
+func (p *BoosterProcessor) dependentIDs(boosterID string) []string {
+	p.boostersMu.RLock()
+	defer p.boostersMu.RUnlock()
+
+	result := make([]string, 0)
+	for id, booster := range p.boosters {
+		for _, depID := range booster.GetEntity().Dependencies {
+			if depID == boosterID {
+				result = append(result, id)
+				break
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
 // GetBoosterCount retorna o número de boosters registrados
 func (p *BoosterProcessor) GetBoosterCount() int {
 	p.boostersMu.RLock()
 	defer p.boostersMu.RUnlock()
 	return len(p.boosters)
-}
\ No newline at end of file
+}
+
+var (
+	ErrRollbackBackupMissing       = fmt.Errorf("no rollback backup found")
+	ErrRollbackVersionIncompatible = fmt.Errorf("rollback backup version is incompatible")
+)
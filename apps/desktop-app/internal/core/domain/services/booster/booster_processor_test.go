@@ -3,6 +3,8 @@ package booster
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -30,12 +32,91 @@ type testBooster struct {
 	execErr     error
 	revertRes   *entities.BoostRevertResult
 	revertErr   error
+	deps        []string
+	conflicts   []string
+
+	planChanges []entities.PlannedChange
+	planErr     error
+
+	revertBackup entities.BackupData
+	// onRevert roda dentro de Revert, antes do retorno
+	onRevert func()
+
+	drifted     []entities.PlannedChange
+	verifyErr   error
+	driftPolicy entities.DriftPolicy
+
+	// execFailures faz as primeiras chamadas de Execute retornarem execErr
+	execFailures int
+	execCalls    int
+	execBlock    chan struct{}
+	timeout      time.Duration
+	retry        entities.RetryPolicy
+	resources    []string
+	// started recebe o ID do booster quando Execute começa
+	started chan<- string
+	// steps faz Execute alterar um valor por passo, com um checkpoint antes de cada
+	// um; depois do primeiro passo espera o cancelamento do contexto
+	steps []string
+	// params é o schema declarado; lastParams recebe os valores da última execução
+	params     entities.ParamSchema
+	lastParams entities.BoosterParams
+	platforms  []entities.Platform
+	// requirements são avaliados pelo CapabilityProber
+	requirements entities.Requirements
 }
 
 func (b *testBooster) Execute(ctx context.Context) (*entities.BoostApplyResult, error) {
+	b.execCalls++
+	if len(b.params) > 0 {
+		b.lastParams = entities.ParamsFromContext(ctx, b.params)
+	}
+	if b.started != nil {
+		b.started <- b.id
+	}
+	if b.execBlock != nil {
+		<-b.execBlock
+	}
+	if len(b.steps) > 0 {
+		return b.executeSteps(ctx)
+	}
+	if b.execFailures > 0 {
+		if b.execCalls <= b.execFailures {
+			return nil, b.execErr
+		}
+		return b.execResult, nil
+	}
 	return b.execResult, b.execErr
 }
 
+func (b *testBooster) executeSteps(ctx context.Context) (*entities.BoostApplyResult, error) {
+	backup := entities.BackupData{}
+	for i, step := range b.steps {
+		if err := entities.Checkpoint(ctx, step, backup); err != nil {
+			return nil, err
+		}
+		backup[step] = "original"
+		if i == 0 {
+			<-ctx.Done()
+		}
+	}
+	return &entities.BoostApplyResult{Success: true, BackupData: backup}, nil
+}
+
+func (b *testBooster) Plan(ctx context.Context) (*entities.BoostPlan, error) {
+	if b.planErr != nil {
+		return nil, b.planErr
+	}
+	return &entities.BoostPlan{Changes: b.planChanges}, nil
+}
+
+func (b *testBooster) Verify(ctx context.Context) (*entities.BoostVerifyResult, error) {
+	if b.verifyErr != nil {
+		return nil, b.verifyErr
+	}
+	return &entities.BoostVerifyResult{InEffect: len(b.drifted) == 0, Drifted: b.drifted}, nil
+}
+
 func (b *testBooster) Validate(ctx context.Context) error {
 	return b.validateErr
 }
@@ -50,17 +131,30 @@ func (b *testBooster) CanRevert(ctx context.Context) bool {
 
 func (b *testBooster) GetEntity() entities.Booster {
 	return entities.Booster{
-		ID:      b.id,
-		Version: b.version,
+		ID:           b.id,
+		Version:      b.version,
+		Dependencies: b.deps,
+		Conflicts:    b.conflicts,
+		DriftPolicy:  b.driftPolicy,
+		Timeout:      b.timeout,
+		Retry:        b.retry,
+		Resources:    b.resources,
+		Params:       b.params,
+		Platform:     b.platforms,
+		Requirements: b.requirements,
 	}
 }
 
 // Correção: retornar zero value (struct) em vez de nil
 func (b *testBooster) GetEntityDto(lang i18n.Language) dto.BoosterDto {
-	return dto.BoosterDto{}
+	return dto.BoosterDto{ID: b.id, Platform: b.platforms}
 }
 
-func (b *testBooster) Revert(ctx context.Context) (*entities.BoostRevertResult, error) {
+func (b *testBooster) Revert(ctx context.Context, backupData entities.BackupData) (*entities.BoostRevertResult, error) {
+	b.revertBackup = backupData
+	if b.onRevert != nil {
+		b.onRevert()
+	}
 	return b.revertRes, b.revertErr
 }
 
@@ -69,6 +163,11 @@ func setupRollbackRepoForTest(t *testing.T) (*repos.RollbackRepo, *gorm.DB) {
 	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
 	require.NoError(t, err)
 
+	// cada conexão de ":memory:" é um banco novo; os workers precisam ver as mesmas tabelas
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+
 	// migrar tabela necessária (apenas rollback state)
 	require.NoError(t, db.AutoMigrate(&storagemodels.BoosterRollbackState{}))
 
@@ -252,6 +351,12 @@ func TestProcessRevert_RevertError(t *testing.T) {
 		revertErr: errors.New("revert fail"),
 	}
 	require.NoError(t, proc.RegisterBooster(tb))
+	require.NoError(t, rr.Save(context.Background(), &entities.BoosterRollbackState{
+		ID:      "b-revert-err",
+		Applied: true,
+		Version: "v1",
+		Status:  entities.ExecutionApplied,
+	}))
 
 	res, err := proc.ProcessRevert(context.Background(), "b-revert-err")
 	require.Error(t, err)
@@ -266,11 +371,12 @@ func TestProcessRevert_Success_UpdatesRollback(t *testing.T) {
 	// criar rollback previamente aplicado
 	appliedAt := time.Now().Add(-1 * time.Hour)
 	initial := &entities.BoosterRollbackState{
-		ID:        "b-to-revert",
-		Applied:   true,
-		AppliedAt: &appliedAt,
-		Version:   "v1",
-		Status:    entities.ExecutionApplied,
+		ID:         "b-to-revert",
+		Applied:    true,
+		AppliedAt:  &appliedAt,
+		Version:    "v1",
+		Status:     entities.ExecutionApplied,
+		BackupData: entities.BackupData{"original": "value"},
 	}
 	require.NoError(t, rr.Save(context.Background(), initial))
 
@@ -290,6 +396,7 @@ func TestProcessRevert_Success_UpdatesRollback(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, res)
 	assert.True(t, res.Success)
+	assert.Equal(t, "value", tb.revertBackup["original"])
 
 	// verificar rollback atualizado
 	updated, err := rr.GetByID(context.Background(), "b-to-revert")
@@ -299,3 +406,212 @@ func TestProcessRevert_Success_UpdatesRollback(t *testing.T) {
 	assert.Equal(t, entities.ExecutionReverted, updated.Status)
 	require.NotNil(t, updated.RevertedAt)
 }
+
+func TestProcessRevert_PersistsWhenCancelledAfterRevert(t *testing.T) {
+	rr, _ := setupRollbackRepoForTest(t)
+	proc := NewBoosterProcessor(rr)
+	require.NoError(t, rr.Save(context.Background(), &entities.BoosterRollbackState{
+		ID:         "b-cancelled",
+		Applied:    true,
+		Version:    "v1",
+		Status:     entities.ExecutionApplied,
+		BackupData: entities.BackupData{"original": "value"},
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, proc.RegisterBooster(&testBooster{
+		id:        "b-cancelled",
+		version:   "v1",
+		canRevert: true,
+		revertRes: &entities.BoostRevertResult{Success: true},
+		// o cancelamento chega depois que o sistema já foi revertido
+		onRevert: cancel,
+	}))
+
+	res, err := proc.ProcessRevert(ctx, "b-cancelled")
+	require.NoError(t, err)
+	assert.True(t, res.Success)
+
+	updated, err := rr.GetByID(context.Background(), "b-cancelled")
+	require.NoError(t, err)
+	assert.False(t, updated.Applied)
+	assert.Equal(t, entities.ExecutionReverted, updated.Status)
+}
+
+// Aplicações e reversões concorrentes pelos workers (rodar com -race): cada
+// transição atualiza o estado de ativação, que os leitores veem sem esperar resync
+func TestProcessApplyRevert_ConcurrentUpdatesActivationState(t *testing.T) {
+	var boosters []*testBooster
+	for i := 0; i < 6; i++ {
+		boosters = append(boosters, &testBooster{
+			id:         fmt.Sprintf("b-%d", i),
+			canApply:   true,
+			canRevert:  true,
+			execResult: okResult(),
+			revertRes:  &entities.BoostRevertResult{Success: true},
+		})
+	}
+	proc, activation := newReconcileFixture(t, boosters...)
+	proc.SetActivationRepo(activation)
+
+	const rounds = 3
+	emitter := newRecordingEmitter()
+	// cada operação emite uma tentativa; o buffer comporta todas
+	emitter.attempts = make(chan entities.OperationAttempt, 2*rounds*len(boosters))
+
+	queueManager := NewManager(20)
+	pool := NewPool(3, proc, emitter, noopRecorder{}, queueManager)
+	pool.SetDefaultPolicy(ExecutionPolicy{Timeout: time.Second, Retry: entities.RetryPolicy{MaxAttempts: 1}})
+	pool.Start()
+	t.Cleanup(pool.Stop)
+
+	ctx := context.Background()
+	done := make(chan struct{})
+	var readers sync.WaitGroup
+	readers.Add(1)
+	defer func() {
+		close(done)
+		readers.Wait()
+	}()
+	go func() {
+		defer readers.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			for _, b := range boosters {
+				state, err := activation.GetBoostState(ctx, b.id)
+				if assert.NoError(t, err) {
+					assert.Equal(t, state.IsApplied, state.Status == entities.StatusActive)
+				}
+			}
+		}
+	}()
+
+	run := func(operation entities.BoosterOperationType) {
+		var ids []string
+		for _, b := range boosters {
+			id, err := queueManager.Add(b.id, operation)
+			require.NoError(t, err)
+			ids = append(ids, id)
+		}
+		for _, id := range ids {
+			require.NoError(t, queueManager.AwaitOperation(ctx, id))
+		}
+	}
+
+	for round := 0; round < rounds; round++ {
+		run(entities.ApplyOperationType)
+		for _, b := range boosters {
+			state, err := activation.GetBoostState(ctx, b.id)
+			require.NoError(t, err)
+			assert.True(t, state.IsApplied, b.id)
+		}
+
+		run(entities.RevertOperationType)
+		for _, b := range boosters {
+			state, err := activation.GetBoostState(ctx, b.id)
+			require.NoError(t, err)
+			assert.False(t, state.IsApplied, b.id)
+			assert.NotNil(t, state.RevertedAt, b.id)
+		}
+	}
+}
+
+func TestProcessRevert_NoBackup(t *testing.T) {
+	rr, _ := setupRollbackRepoForTest(t)
+	proc := NewBoosterProcessor(rr)
+
+	tb := &testBooster{
+		id:        "b-no-backup",
+		version:   "v1",
+		canRevert: true,
+		revertRes: &entities.BoostRevertResult{Success: true},
+	}
+	require.NoError(t, proc.RegisterBooster(tb))
+
+	res, err := proc.ProcessRevert(context.Background(), "b-no-backup")
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.False(t, res.Success)
+	assert.ErrorIs(t, res.Error, ErrRollbackBackupMissing)
+	// o executor não deve ser chamado sem backup
+	assert.Nil(t, tb.revertBackup)
+}
+
+func TestProcessRevert_IncompatibleBackupVersion(t *testing.T) {
+	rr, _ := setupRollbackRepoForTest(t)
+	proc := NewBoosterProcessor(rr)
+
+	require.NoError(t, rr.Save(context.Background(), &entities.BoosterRollbackState{
+		ID:         "b-old-backup",
+		Applied:    true,
+		Version:    "1.4.0",
+		Status:     entities.ExecutionApplied,
+		BackupData: entities.BackupData{"k": "v"},
+	}))
+
+	tb := &testBooster{
+		id:        "b-old-backup",
+		version:   "2.0.0",
+		canRevert: true,
+		revertRes: &entities.BoostRevertResult{Success: true},
+	}
+	require.NoError(t, proc.RegisterBooster(tb))
+
+	res, err := proc.ProcessRevert(context.Background(), "b-old-backup")
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.False(t, res.Success)
+	assert.ErrorIs(t, res.Error, ErrRollbackVersionIncompatible)
+
+	// mesma versão major é compatível
+	assert.True(t, isBackupVersionCompatible("1.0.0", "v1.2.3"))
+	assert.False(t, isBackupVersionCompatible("1.0.0", "2.0.0"))
+}
+
+func TestProcessPlan_ReturnsChanges(t *testing.T) {
+	rr, _ := setupRollbackRepoForTest(t)
+	proc := NewBoosterProcessor(rr)
+
+	tb := &testBooster{
+		id:      "b-plan",
+		version: "v1",
+		planChanges: []entities.PlannedChange{
+			entities.NewPlannedChange("sysctl net.core.somaxconn", "128", "65535", true),
+			entities.NewPlannedChange("dns cache", nil, "flushed", false),
+		},
+	}
+	require.NoError(t, proc.RegisterBooster(tb))
+
+	plan, err := proc.ProcessPlan(context.Background(), "b-plan")
+	require.NoError(t, err)
+	assert.Equal(t, "b-plan", plan.BoosterID)
+	assert.Equal(t, entities.ApplyOperationType, plan.Operation)
+	require.Len(t, plan.Changes, 2)
+	assert.Equal(t, "128", plan.Changes[0].CurrentValue)
+	assert.Equal(t, "", plan.Changes[1].CurrentValue)
+	assert.False(t, plan.Changes[1].Reversible)
+
+	// a prévia não altera o estado de rollback
+	state, err := rr.GetByID(context.Background(), "b-plan")
+	require.NoError(t, err)
+	assert.Nil(t, state)
+}
+
+func TestProcessPlan_Errors(t *testing.T) {
+	rr, _ := setupRollbackRepoForTest(t)
+	proc := NewBoosterProcessor(rr)
+	require.NoError(t, proc.RegisterBooster(&testBooster{id: "b-plan-err", planErr: errors.New("no access")}))
+
+	_, err := proc.ProcessPlan(context.Background(), "b-plan-err")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no access")
+
+	_, err = proc.ProcessPlan(context.Background(), "nope")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not found")
+}
@@ -2,6 +2,7 @@ package booster
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -13,25 +14,58 @@ import (
 type Manager struct {
 	items    []entities.QueueItem
 	itemsMap map[string]*entities.QueueItem
-	mu       sync.RWMutex
-	workCh   chan entities.QueueItem
-	stopCh   chan struct{}
+	// running guarda, por OperationID, os itens retirados da queue que estão em execução
+	running     map[string]entities.QueueItem
+	completions map[string]*operationCompletion
+	journal     OperationJournal
+	recorder    HistoryRecorder
+	mu          sync.RWMutex
+	// lanes tem um canal por faixa de prioridade; os workers esvaziam a mais prioritária primeiro
+	lanes  [entities.PriorityLanes]chan entities.QueueItem
+	stopCh chan struct{}
 
 	totalProcessed int
 	inProgress     int
 }
 
+// QueueItemOptions carrega metadados opcionais de um item enfileirado
+type QueueItemOptions struct {
+	BatchID   string
+	DependsOn []string
+	// Priority vazio equivale a PriorityInteractive
+	Priority entities.OperationPriority
+}
+
+// operationCompletion sinaliza o término de uma operação para quem depende dela
+type operationCompletion struct {
+	done        chan struct{}
+	err         error
+	completedAt time.Time
+}
+
+const completionRetention = 10 * time.Minute
+
 func NewManager(bufferSize int) *Manager {
-	return &Manager{
-		items:    make([]entities.QueueItem, 0),
-		itemsMap: make(map[string]*entities.QueueItem),
-		workCh:   make(chan entities.QueueItem, bufferSize),
-		stopCh:   make(chan struct{}),
+	m := &Manager{
+		items:       make([]entities.QueueItem, 0),
+		itemsMap:    make(map[string]*entities.QueueItem),
+		running:     make(map[string]entities.QueueItem),
+		completions: make(map[string]*operationCompletion),
+		stopCh:      make(chan struct{}),
+	}
+	for i := range m.lanes {
+		m.lanes[i] = make(chan entities.QueueItem, bufferSize)
 	}
+	return m
 }
 
 // Add adiciona um item à queue, removendo duplicatas e conflitos
 func (m *Manager) Add(boosterID string, operation entities.BoosterOperationType) (string, error) {
+	return m.AddWithOptions(boosterID, operation, QueueItemOptions{})
+}
+
+// AddWithOptions adiciona um item à queue com lote, prioridade e dependências de outras operações
+func (m *Manager) AddWithOptions(boosterID string, operation entities.BoosterOperationType, opts QueueItemOptions) (string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -48,8 +82,8 @@ func (m *Manager) Add(boosterID string, operation entities.BoosterOperationType)
 	}
 
 	// Cria novo item
-	ctx, cancel := context.WithCancel(context.Background())
 	operationID := uuid.New().String()
+	ctx, cancel := context.WithCancel(withOperationID(context.Background(), operationID))
 
 	item := entities.QueueItem{
 		ID:          uuid.New().String(),
@@ -57,28 +91,183 @@ func (m *Manager) Add(boosterID string, operation entities.BoosterOperationType)
 		Operation:   operation,
 		OperationID: operationID,
 		SubmittedAt: time.Now(),
+		BatchID:     opts.BatchID,
+		DependsOn:   opts.DependsOn,
+		Priority:    opts.Priority,
 		Context:     ctx,
 		Cancel:      cancel,
 	}
 
-	// Adiciona à queue
-	m.items = append(m.items, item)
-	m.itemsMap[boosterID] = &m.items[len(m.items)-1]
+	if item.Priority == "" {
+		item.Priority = entities.PriorityInteractive
+	}
+
+	// Adiciona à queue na posição da sua faixa
+	// O map guarda sua própria cópia: ponteiros para o slice ficam inválidos após remoções
+	m.insertUnsafe(item)
+	mapped := item
+	m.itemsMap[boosterID] = &mapped
+	m.completions[operationID] = &operationCompletion{done: make(chan struct{})}
 
 	m.inProgress++
 
+	// Registra no journal antes de o item ficar visível para os workers
+	if err := m.journalQueued(item); err != nil {
+		m.detachUnsafe(boosterID)
+		delete(m.completions, operationID)
+		cancel()
+		return "", fmt.Errorf("failed to journal operation: %w", err)
+	}
+	m.record(func(r HistoryRecorder) error { return r.RecordQueued(item) })
+
 	// Envia para processamento
 	select {
-	case m.workCh <- item:
+	case m.lanes[item.Priority.Lane()] <- item:
 		return operationID, nil
 	default:
 		// Queue cheia, remove item e retorna erro
-		m.removeUnsafe(boosterID)
+		m.detachUnsafe(boosterID)
+		delete(m.completions, operationID)
 		cancel()
+		m.UpdateJournal(operationID, entities.JournalFailed, ErrQueueFull)
+		m.record(func(r HistoryRecorder) error { return r.RecordOperation(item, nil, ErrQueueFull) })
 		return "", ErrQueueFull
 	}
 }
 
+// insertUnsafe insere o item depois dos itens da mesma faixa ou de faixas mais
+// prioritárias, mantendo a ordem de chegada dentro da faixa (sem lock)
+func (m *Manager) insertUnsafe(item entities.QueueItem) {
+	lane := item.Priority.Lane()
+	pos := len(m.items)
+	for i, queued := range m.items {
+		if queued.Priority.Lane() > lane {
+			pos = i
+			break
+		}
+	}
+	m.items = append(m.items, entities.QueueItem{})
+	copy(m.items[pos+1:], m.items[pos:])
+	m.items[pos] = item
+}
+
+// SetJournal ativa o journal write-ahead das operações
+func (m *Manager) SetJournal(journal OperationJournal) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.journal = journal
+}
+
+func (m *Manager) journalQueued(item entities.QueueItem) error {
+	if m.journal == nil {
+		return nil
+	}
+	return m.journal.Save(context.Background(), &entities.JournalEntry{
+		OperationID: item.OperationID,
+		BoosterID:   item.BoosterID,
+		Operation:   item.Operation,
+		BatchID:     item.BatchID,
+		DependsOn:   item.DependsOn,
+		Phase:       entities.JournalQueued,
+		QueuedAt:    item.SubmittedAt,
+	})
+}
+
+// SetRecorder ativa a gravação do ciclo de vida das operações no histórico
+func (m *Manager) SetRecorder(recorder HistoryRecorder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recorder = recorder
+}
+
+// record grava no histórico quando há recorder; assim como no journal, falhas de
+// escrita não interrompem a queue
+func (m *Manager) record(write func(HistoryRecorder) error) {
+	if m.recorder == nil {
+		return
+	}
+	_ = write(m.recorder)
+}
+
+// UpdateJournal registra a nova fase de uma operação; falhas de escrita são
+// ignoradas para não interromper a execução
+func (m *Manager) UpdateJournal(operationID string, phase entities.JournalPhase, err error) {
+	if m.journal == nil {
+		return
+	}
+	errMsg := ""
+	if err != nil {
+		errMsg = err.Error()
+	}
+	_ = m.journal.UpdatePhase(context.Background(), operationID, phase, errMsg)
+}
+
+// MarkCompleted sinaliza o fim de uma operação, liberando as operações que dependem dela.
+// err nil indica sucesso.
+func (m *Manager) MarkCompleted(operationID string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.markCompletedUnsafe(operationID, err)
+}
+
+func (m *Manager) markCompletedUnsafe(operationID string, err error) {
+	delete(m.running, operationID)
+
+	now := time.Now()
+	if completion, exists := m.completions[operationID]; exists && completion.completedAt.IsZero() {
+		completion.err = err
+		completion.completedAt = now
+		close(completion.done)
+	}
+
+	for id, completion := range m.completions {
+		if !completion.completedAt.IsZero() && now.Sub(completion.completedAt) > completionRetention {
+			delete(m.completions, id)
+		}
+	}
+}
+
+// AwaitOperation bloqueia até a operação terminar e retorna o erro dela.
+// Retorna ErrNotFound se a operação não é conhecida (ou já expirou).
+func (m *Manager) AwaitOperation(ctx context.Context, operationID string) error {
+	m.mu.RLock()
+	completion, exists := m.completions[operationID]
+	m.mu.RUnlock()
+	if !exists {
+		return ErrNotFound
+	}
+
+	select {
+	case <-completion.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-m.stopCh:
+		return ErrQueueStopped
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return completion.err
+}
+
+// WaitForDependencies bloqueia até que as operações informadas terminem.
+// Operações desconhecidas (já expiradas) são ignoradas; a validação do
+// processor continua sendo a garantia final de que as dependências estão aplicadas.
+func (m *Manager) WaitForDependencies(ctx context.Context, operationIDs []string) error {
+	for _, operationID := range operationIDs {
+		err := m.AwaitOperation(ctx, operationID)
+		switch {
+		case err == nil, errors.Is(err, ErrNotFound):
+			continue
+		case errors.Is(err, ErrQueueStopped), ctx.Err() != nil:
+			return err
+		default:
+			return fmt.Errorf("%w: %s", ErrDependencyFailed, operationID)
+		}
+	}
+	return nil
+}
+
 // Remove remove um item da queue
 func (m *Manager) Remove(boosterID string) {
 	m.mu.Lock()
@@ -86,9 +275,87 @@ func (m *Manager) Remove(boosterID string) {
 	m.removeUnsafe(boosterID)
 }
 
+// Dequeue retira da queue o item que um worker começou a processar, sem
+// cancelar o contexto usado na execução. Retorna false se o item já tinha saído da queue
+func (m *Manager) Dequeue(item entities.QueueItem) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	queued, exists := m.itemsMap[item.BoosterID]
+	if !exists || queued.OperationID != item.OperationID {
+		return false
+	}
+	m.detachUnsafe(item.BoosterID)
+	m.running[item.OperationID] = item
+	return true
+}
+
+// CancelOperation cancela uma operação pelo ID. Se ela ainda está na queue, é
+// removida; se já está em execução, o contexto dela é cancelado e o executor
+// para no próximo checkpoint.
+func (m *Manager) CancelOperation(operationID string) (entities.QueueItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, item := range m.items {
+		if item.OperationID == operationID {
+			m.removeUnsafe(item.BoosterID)
+			return item, nil
+		}
+	}
+	if item, exists := m.running[operationID]; exists {
+		item.Cancel()
+		return item, nil
+	}
+	return entities.QueueItem{}, ErrNotFound
+}
+
+// CancelBatch cancela todas as operações pendentes ou em execução de um lote
+func (m *Manager) CancelBatch(batchID string) ([]entities.QueueItem, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var cancelled []entities.QueueItem
+	// Copia os itens: removeUnsafe altera o slice
+	queued := make([]entities.QueueItem, len(m.items))
+	copy(queued, m.items)
+	for _, item := range queued {
+		if item.BatchID == batchID {
+			m.removeUnsafe(item.BoosterID)
+			cancelled = append(cancelled, item)
+		}
+	}
+	for _, item := range m.running {
+		if item.BatchID == batchID {
+			item.Cancel()
+			cancelled = append(cancelled, item)
+		}
+	}
+
+	if len(cancelled) == 0 {
+		return nil, ErrNotFound
+	}
+	return cancelled, nil
+}
+
 // removeUnsafe remove um item da queue (sem lock)
 func (m *Manager) removeUnsafe(boosterID string) {
 	if item, exists := m.itemsMap[boosterID]; exists {
+		if item.Cancel != nil {
+			item.Cancel()
+		}
+		m.UpdateJournal(item.OperationID, entities.JournalCancelled, nil)
+		cancelled := *item
+		m.record(func(r HistoryRecorder) error { return r.RecordCancelled(cancelled) })
+		m.detachUnsafe(boosterID)
+		// Libera quem aguarda a operação, inclusive as que dependem dela
+		m.markCompletedUnsafe(cancelled.OperationID, ErrCancelledInQueue)
+	}
+}
+
+// detachUnsafe tira o item do slice e do map e atualiza as estatísticas (sem lock)
+func (m *Manager) detachUnsafe(boosterID string) {
+	if _, exists := m.itemsMap[boosterID]; exists {
 		// Remove do slice
 		for i, qItem := range m.items {
 			if qItem.BoosterID == boosterID {
@@ -100,10 +367,6 @@ func (m *Manager) removeUnsafe(boosterID string) {
 		// Remove do map
 		delete(m.itemsMap, boosterID)
 
-		if item.Cancel != nil {
-			item.Cancel()
-		}
-
 		// Atualiza estatísticas
 		m.inProgress--
 		m.totalProcessed++
@@ -174,9 +437,27 @@ func (m *Manager) GetAllItems() []entities.QueueItem {
 	return items
 }
 
-// GetWorkChannel retorna o canal de trabalho para os workers
-func (m *Manager) GetWorkChannel() <-chan entities.QueueItem {
-	return m.workCh
+// Next bloqueia até haver um item para processar, sempre escolhendo a faixa
+// mais prioritária disponível. Retorna false quando a queue é parada.
+func (m *Manager) Next() (entities.QueueItem, bool) {
+	for _, lane := range m.lanes {
+		select {
+		case item := <-lane:
+			return item, true
+		default:
+		}
+	}
+
+	select {
+	case <-m.stopCh:
+		return entities.QueueItem{}, false
+	case item := <-m.lanes[0]:
+		return item, true
+	case item := <-m.lanes[1]:
+		return item, true
+	case item := <-m.lanes[2]:
+		return item, true
+	}
 }
 
 // GetStopChannel retorna o canal de parada
@@ -215,6 +496,9 @@ func (m *Manager) Stop() {
 }
 
 var (
-	ErrQueueFull = fmt.Errorf("execution queue is full")
-	ErrNotFound  = fmt.Errorf("item not found in queue")
+	ErrQueueFull        = fmt.Errorf("execution queue is full")
+	ErrNotFound         = fmt.Errorf("item not found in queue")
+	ErrQueueStopped     = fmt.Errorf("execution queue is stopped")
+	ErrDependencyFailed = fmt.Errorf("dependency operation failed")
+	ErrCancelledInQueue = fmt.Errorf("%w before it started: %w", entities.ErrOperationCancelled, context.Canceled)
 )
@@ -0,0 +1,162 @@
+package booster
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/oLenador/mulltbost/internal/core/application/ports/outbound"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// reconcileKey identifica uma linha da tabela de decisão: estado de ativação,
+// estado de rollback e estado reportado pelo executor
+type reconcileKey struct {
+	activation bool
+	rollback   bool
+	live       entities.LiveState
+}
+
+type reconcileDecision struct {
+	action entities.ReconciliationAction
+	reason string
+}
+
+// reconcileTable é a tabela de decisão da reconciliação. O estado de rollback é
+// a referência porque guarda o backup: um backup nunca é descartado
+// automaticamente, e sem backup o booster não é considerado aplicado.
+var reconcileTable = map[reconcileKey]reconcileDecision{
+	{true, true, entities.LiveInEffect}:    {entities.ReconcileNone, "consistent"},
+	{true, true, entities.LiveNotInEffect}: {entities.ReconcileMarkDrifted, "applied but no longer in effect"},
+	{true, true, entities.LiveUnknown}:     {entities.ReconcileNone, "consistent, live state unknown"},
+
+	{false, true, entities.LiveInEffect}:    {entities.ReconcileActivate, "rollback state applied, activation state out of date"},
+	{false, true, entities.LiveNotInEffect}: {entities.ReconcileActivateDrifted, "rollback state applied but not in effect; backup kept for revert"},
+	{false, true, entities.LiveUnknown}:     {entities.ReconcileActivate, "rollback state applied, activation state out of date"},
+
+	{true, false, entities.LiveInEffect}:    {entities.ReconcileManualReview, "in effect without rollback backup; revert is not possible"},
+	{true, false, entities.LiveNotInEffect}: {entities.ReconcileDeactivate, "activation state applied but not in effect and no backup"},
+	{true, false, entities.LiveUnknown}:     {entities.ReconcileDeactivate, "activation state applied without rollback backup"},
+
+	{false, false, entities.LiveInEffect}:    {entities.ReconcileManualReview, "in effect but not applied by the app"},
+	{false, false, entities.LiveNotInEffect}: {entities.ReconcileNone, "consistent"},
+	{false, false, entities.LiveUnknown}:     {entities.ReconcileNone, "consistent, live state unknown"},
+}
+
+// Reconciler compara, na inicialização, o estado de ativação, o estado de
+// rollback e o estado real do sistema, e repara as divergências
+type Reconciler struct {
+	processor      *BoosterProcessor
+	activationRepo outbound.BoosterActivationRepository
+	// review são os boosters, com o motivo, que vão para revisão manual sem reparo
+	review map[string]string
+}
+
+func NewReconciler(processor *BoosterProcessor, activationRepo outbound.BoosterActivationRepository) *Reconciler {
+	return &Reconciler{
+		processor:      processor,
+		activationRepo: activationRepo,
+		review:         make(map[string]string),
+	}
+}
+
+// ReviewInterrupted manda para revisão manual os boosters cuja operação foi
+// interrompida durante a execução: o estado deles não é confiável para a
+// tabela de decisão, e um reparo automático poderia piorar o que ficou pela metade
+func (r *Reconciler) ReviewInterrupted(recoveries []entities.JournalRecovery) {
+	for _, recovery := range recoveries {
+		if recovery.Action == entities.JournalActionManualReview {
+			r.review[recovery.BoosterID] = recovery.Error
+		}
+	}
+}
+
+// Reconcile percorre os boosters registrados e aplica a tabela de decisão
+func (r *Reconciler) Reconcile(ctx context.Context) (*entities.ReconciliationReport, error) {
+	report := &entities.ReconciliationReport{StartedAt: time.Now()}
+
+	boosterIDs := make([]string, 0, r.processor.GetBoosterCount())
+	for id := range r.processor.GetAllBoosters() {
+		boosterIDs = append(boosterIDs, id)
+	}
+	sort.Strings(boosterIDs)
+
+	for _, boosterID := range boosterIDs {
+		if ctx.Err() != nil {
+			return report, ctx.Err()
+		}
+
+		entry, err := r.reconcileBooster(ctx, boosterID)
+		if err != nil {
+			entry.Error = err.Error()
+		}
+
+		switch {
+		case entry.Action == entities.ReconcileManualReview || entry.Error != "":
+			report.NeedsAttention++
+		case entry.Action != entities.ReconcileNone:
+			report.Repaired++
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+
+	report.CompletedAt = time.Now()
+	return report, nil
+}
+
+func (r *Reconciler) reconcileBooster(ctx context.Context, boosterID string) (entities.BoosterReconciliation, error) {
+	entry := entities.BoosterReconciliation{BoosterID: boosterID, Action: entities.ReconcileNone}
+
+	// Sem estado de ativação o booster é considerado não aplicado
+	if activation, err := r.activationRepo.GetBoostState(ctx, boosterID); err == nil && activation != nil {
+		entry.ActivationApplied = activation.IsApplied
+	}
+
+	rollbackApplied, err := r.processor.IsApplied(ctx, boosterID)
+	if err != nil {
+		return entry, fmt.Errorf("failed to load rollback state: %w", err)
+	}
+	entry.RollbackApplied = rollbackApplied
+
+	entry.Live = entities.LiveUnknown
+	if verify, err := r.processor.ProcessVerify(ctx, boosterID); err == nil {
+		entry.Live = entities.LiveNotInEffect
+		if verify.InEffect {
+			entry.Live = entities.LiveInEffect
+		}
+	}
+
+	if reason, ok := r.review[boosterID]; ok {
+		entry.Action = entities.ReconcileManualReview
+		entry.Reason = reason
+		return entry, nil
+	}
+
+	decision := reconcileTable[reconcileKey{entry.ActivationApplied, entry.RollbackApplied, entry.Live}]
+	entry.Action = decision.action
+	entry.Reason = decision.reason
+
+	return entry, r.apply(ctx, boosterID, entry.Action)
+}
+
+// apply executa o reparo correspondente à ação decidida
+func (r *Reconciler) apply(ctx context.Context, boosterID string, action entities.ReconciliationAction) error {
+	switch action {
+	case entities.ReconcileActivate:
+		return r.activationRepo.SetAppliedState(ctx, boosterID, true, "")
+	case entities.ReconcileActivateDrifted:
+		if err := r.activationRepo.SetAppliedState(ctx, boosterID, true, ""); err != nil {
+			return err
+		}
+		_, err := r.processor.UpdateDriftState(ctx, boosterID, true)
+		return err
+	case entities.ReconcileDeactivate:
+		return r.activationRepo.SetAppliedState(ctx, boosterID, false, "")
+	case entities.ReconcileMarkDrifted:
+		_, err := r.processor.UpdateDriftState(ctx, boosterID, true)
+		return err
+	default:
+		return nil
+	}
+}
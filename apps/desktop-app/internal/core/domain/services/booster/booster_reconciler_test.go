@@ -0,0 +1,124 @@
+package booster
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	storagemodels "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+	repos "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/repositories"
+)
+
+// helper para montar processor e repositório de ativação sobre o mesmo banco
+func newReconcileFixture(t *testing.T, boosters ...*testBooster) (*BoosterProcessor, *repos.BoostConfigRepository) {
+	rr, db := setupRollbackRepoForTest(t)
+	require.NoError(t, db.AutoMigrate(&storagemodels.BoostActivationState{}))
+
+	proc := NewBoosterProcessor(rr)
+	for _, b := range boosters {
+		b.version = "v1"
+		require.NoError(t, proc.RegisterBooster(b))
+	}
+
+	activation := repos.NewBoostConfigRepository(db, nil)
+	require.NoError(t, activation.SyncWithAvailableBoosts(context.Background(), proc.GetAllBoostersEntities()))
+	return proc, activation
+}
+
+func TestReconcile_DecisionTable(t *testing.T) {
+	notInEffect := []entities.PlannedChange{entities.NewPlannedChange("sysctl x", "1", "0", true)}
+
+	proc, activation := newReconcileFixture(t,
+		&testBooster{id: "activate"},
+		&testBooster{id: "activate-drifted", drifted: notInEffect},
+		&testBooster{id: "consistent", drifted: notInEffect},
+		&testBooster{id: "deactivate", drifted: notInEffect},
+		&testBooster{id: "deactivate-unknown", verifyErr: errors.New("no access")},
+		&testBooster{id: "untracked"},
+		&testBooster{id: "mark-drifted", drifted: notInEffect},
+	)
+	ctx := context.Background()
+
+	markApplied(t, proc, "activate", "activate-drifted", "mark-drifted")
+	for _, id := range []string{"deactivate", "deactivate-unknown", "mark-drifted"} {
+		require.NoError(t, activation.SetAppliedState(ctx, id, true, ""))
+	}
+
+	report, err := NewReconciler(proc, activation).Reconcile(ctx)
+	require.NoError(t, err)
+
+	actions := make(map[string]entities.ReconciliationAction)
+	for _, entry := range report.Entries {
+		assert.Empty(t, entry.Error)
+		actions[entry.BoosterID] = entry.Action
+	}
+	assert.Equal(t, map[string]entities.ReconciliationAction{
+		"activate":           entities.ReconcileActivate,
+		"activate-drifted":   entities.ReconcileActivateDrifted,
+		"consistent":         entities.ReconcileNone,
+		"deactivate":         entities.ReconcileDeactivate,
+		"deactivate-unknown": entities.ReconcileDeactivate,
+		"untracked":          entities.ReconcileManualReview,
+		"mark-drifted":       entities.ReconcileMarkDrifted,
+	}, actions)
+	assert.Equal(t, 5, report.Repaired)
+	assert.Equal(t, 1, report.NeedsAttention)
+
+	for id, applied := range map[string]bool{"activate": true, "activate-drifted": true, "deactivate": false, "deactivate-unknown": false} {
+		state, err := activation.GetBoostState(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, applied, state.IsApplied, id)
+	}
+
+	for _, id := range []string{"activate-drifted", "mark-drifted"} {
+		state, err := proc.GetRollbackState(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, entities.ExecutionDrifted, state.Status, id)
+		assert.True(t, state.Applied, id)
+	}
+}
+
+func TestReconcile_IsIdempotent(t *testing.T) {
+	proc, activation := newReconcileFixture(t, &testBooster{id: "a"})
+	markApplied(t, proc, "a")
+	reconciler := NewReconciler(proc, activation)
+
+	first, err := reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.Repaired)
+
+	second, err := reconciler.Reconcile(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, second.Repaired)
+	require.Len(t, second.Entries, 1)
+	assert.Equal(t, entities.ReconcileNone, second.Entries[0].Action)
+}
+
+func TestReconcile_InterruptedOperationGoesToManualReview(t *testing.T) {
+	notInEffect := []entities.PlannedChange{entities.NewPlannedChange("sysctl x", "1", "0", true)}
+	proc, activation := newReconcileFixture(t, &testBooster{id: "a", drifted: notInEffect})
+	ctx := context.Background()
+	require.NoError(t, activation.SetAppliedState(ctx, "a", true, ""))
+
+	reconciler := NewReconciler(proc, activation)
+	reconciler.ReviewInterrupted([]entities.JournalRecovery{
+		{OperationID: "op-1", BoosterID: "a", Action: entities.JournalActionManualReview, Error: "revert was interrupted while running"},
+	})
+	report, err := reconciler.Reconcile(ctx)
+	require.NoError(t, err)
+
+	require.Len(t, report.Entries, 1)
+	assert.Equal(t, entities.ReconcileManualReview, report.Entries[0].Action)
+	assert.Equal(t, "revert was interrupted while running", report.Entries[0].Reason)
+	assert.Equal(t, 0, report.Repaired)
+	assert.Equal(t, 1, report.NeedsAttention)
+
+	// sem reparo: o estado de ativação continua como estava
+	state, err := activation.GetBoostState(ctx, "a")
+	require.NoError(t, err)
+	assert.True(t, state.IsApplied)
+}
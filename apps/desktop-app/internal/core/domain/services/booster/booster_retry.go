@@ -0,0 +1,208 @@
+package booster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// ExecutionPolicy reúne o timeout por tentativa, a política de retry e os
+// recursos que a operação precisa travar
+type ExecutionPolicy struct {
+	Timeout   time.Duration
+	Retry     entities.RetryPolicy
+	Resources []string
+	// CancelGrace é quanto o worker espera o executor parar no próximo checkpoint
+	// (e desfazer alterações parciais) depois de um cancelamento ou timeout
+	CancelGrace time.Duration
+}
+
+// SetDefaultPolicy define a política usada quando o booster não declara timeout ou retry
+func (p *Pool) SetDefaultPolicy(policy ExecutionPolicy) {
+	p.defaultPolicy = policy
+}
+
+// policyFor combina a política do booster com a padrão do pool
+func (p *Pool) policyFor(boosterID string) ExecutionPolicy {
+	policy := p.processor.ExecutionPolicy(boosterID)
+	if policy.Timeout <= 0 {
+		policy.Timeout = p.defaultPolicy.Timeout
+	}
+	if policy.Retry.MaxAttempts <= 0 {
+		policy.Retry = p.defaultPolicy.Retry
+	}
+	if policy.Retry.MaxAttempts <= 0 {
+		policy.Retry.MaxAttempts = 1
+	}
+	if policy.CancelGrace <= 0 {
+		policy.CancelGrace = p.defaultPolicy.CancelGrace
+	}
+	return policy
+}
+
+// executeWithRetry executa a operação respeitando o timeout de cada tentativa e
+// repetindo as falhas que a política permite, com backoff exponencial
+func (p *Pool) executeWithRetry(w *workerState, item entities.QueueItem) (*entities.BoostOperation, error) {
+	policy := p.policyFor(item.BoosterID)
+
+	for attempt := 1; ; attempt++ {
+		p.queueManager.UpdateJournal(item.OperationID, entities.JournalStarted, nil)
+		op, abandoned, err := p.runAttempt(w, item, policy)
+
+		result := entities.OperationAttempt{Attempt: attempt, MaxAttempts: policy.Retry.MaxAttempts}
+		if opErr := operationError(op, err); opErr != nil {
+			result.Error = opErr.Error()
+			result.ErrorClass = classifyAttemptError(err)
+			// Uma tentativa abandonada ainda pode estar alterando o sistema; repetir
+			// em paralelo a ela não é seguro
+			result.WillRetry = !abandoned &&
+				attempt < policy.Retry.MaxAttempts &&
+				policy.Retry.Retries(result.ErrorClass) &&
+				item.Context.Err() == nil
+			if result.WillRetry {
+				result.Backoff = policy.Retry.Backoff(attempt)
+			}
+		}
+		p.eventEmitter.EmitAttempt(item.BoosterID, item.OperationID, item.Operation, result)
+
+		if !result.WillRetry || !p.waitBackoff(item.Context, result.Backoff) {
+			return withAttempts(item, op, attempt), cancellationError(item, err)
+		}
+	}
+}
+
+// runAttempt executa uma tentativa com timeout. Se o executor não respeitar o
+// cancelamento do contexto, a tentativa é abandonada para liberar o worker; os
+// recursos só são liberados quando ela de fato termina.
+func (p *Pool) runAttempt(w *workerState, item entities.QueueItem, policy ExecutionPolicy) (*entities.BoostOperation, bool, error) {
+	// A espera pelos recursos não conta para o timeout
+	w.wait()
+	release, err := p.locks.acquire(item.Context, p.queueManager.GetStopChannel(),
+		lockResources(item.BoosterID, policy.Resources))
+	w.run()
+	if err != nil {
+		return nil, false, err
+	}
+
+	timeout := policy.Timeout
+	ctx, cancel := item.Context, context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(item.Context, timeout)
+	}
+	defer cancel()
+
+	type outcome struct {
+		op  *entities.BoostOperation
+		err error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		defer release()
+		// Um panic no executor vira falha da operação em vez de derrubar o app
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				p.logger.Errorf("operation %s of %s panicked: %v\n%s", item.OperationID, item.BoosterID, recovered, debug.Stack())
+				done <- outcome{nil, fmt.Errorf("%w: %v", ErrOperationPanicked, recovered)}
+			}
+		}()
+		op, err := p.executeOperation(ctx, item)
+		done <- outcome{op, err}
+	}()
+
+	heartbeat := time.NewTicker(p.heartbeatInterval())
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case out := <-done:
+			return out.op, false, out.err
+		case <-heartbeat.C:
+			w.beat()
+		case <-ctx.Done():
+			// Dá ao executor a chance de parar no próximo checkpoint e desfazer o que alterou
+			grace := time.NewTimer(policy.CancelGrace)
+			defer grace.Stop()
+			select {
+			case out := <-done:
+				return out.op, false, out.err
+			case <-grace.C:
+			}
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, true, fmt.Errorf("%w after %s", ErrOperationTimeout, timeout)
+			}
+			return nil, true, ctx.Err()
+		}
+	}
+}
+
+func (p *Pool) heartbeatInterval() time.Duration {
+	if p.healthPolicy.HeartbeatInterval > 0 {
+		return p.healthPolicy.HeartbeatInterval
+	}
+	return DefaultHealthPolicy().HeartbeatInterval
+}
+
+// cancellationError garante que a falha de uma operação cancelada pelo usuário
+// seja reconhecida como cancelamento, mesmo quando o executor retornou outro erro
+func cancellationError(item entities.QueueItem, err error) error {
+	if err == nil || !errors.Is(item.Context.Err(), context.Canceled) {
+		return err
+	}
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", entities.ErrOperationCancelled, err)
+}
+
+// waitBackoff aguarda antes da próxima tentativa; retorna false se a operação
+// foi cancelada ou o pool parou
+func (p *Pool) waitBackoff(ctx context.Context, delay time.Duration) bool {
+	if delay <= 0 {
+		return ctx.Err() == nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	case <-p.queueManager.GetStopChannel():
+		return false
+	}
+}
+
+// classifyAttemptError define a classe da falha; err nil indica resultado sem sucesso
+func classifyAttemptError(err error) entities.ErrorClass {
+	switch {
+	case err == nil:
+		return entities.ErrorClassFailedResult
+	case errors.Is(err, ErrOperationTimeout), errors.Is(err, context.DeadlineExceeded):
+		return entities.ErrorClassTimeout
+	case entities.IsTransient(err):
+		return entities.ErrorClassTransient
+	default:
+		return entities.ErrorClassPermanent
+	}
+}
+
+// withAttempts garante uma operação para o histórico com o número de tentativas
+func withAttempts(item entities.QueueItem, op *entities.BoostOperation, attempts int) *entities.BoostOperation {
+	if op == nil {
+		op = &entities.BoostOperation{
+			ID:        item.ID,
+			BoosterID: item.BoosterID,
+			Type:      item.Operation,
+		}
+	}
+	op.Attempts = attempts
+	return op
+}
+
+var ErrOperationTimeout = fmt.Errorf("operation timed out")
@@ -0,0 +1,138 @@
+package booster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// captureRecorder repassa as operações gravadas no histórico para o teste
+type captureRecorder chan *entities.BoostOperation
+
+func (r captureRecorder) RecordQueued(item entities.QueueItem) error    { return nil }
+func (r captureRecorder) RecordStarted(item entities.QueueItem) error   { return nil }
+func (r captureRecorder) RecordCancelled(item entities.QueueItem) error { return nil }
+
+func (r captureRecorder) RecordOperation(item entities.QueueItem, result *entities.BoostOperation, err error) error {
+	r <- result
+	return nil
+}
+
+// helper para montar o pool com um único worker e gravação do histórico
+func newRetryFixture(t *testing.T, b *testBooster) (*Manager, *recordingEmitter, captureRecorder) {
+	proc := newResolverProcessor(t, b)
+	queueManager := NewManager(10)
+	emitter := newRecordingEmitter()
+	recorder := make(captureRecorder, 10)
+
+	pool := NewPool(1, proc, emitter, recorder, queueManager)
+	pool.SetDefaultPolicy(ExecutionPolicy{Timeout: time.Second, Retry: entities.RetryPolicy{MaxAttempts: 1}})
+	pool.Start()
+	t.Cleanup(pool.Stop)
+	return queueManager, emitter, recorder
+}
+
+func fastRetry(maxAttempts int) entities.RetryPolicy {
+	return entities.RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		RetryOn:        []entities.ErrorClass{entities.ErrorClassTimeout, entities.ErrorClassTransient},
+	}
+}
+
+func drainAttempts(emitter *recordingEmitter) []entities.OperationAttempt {
+	var attempts []entities.OperationAttempt
+	for {
+		select {
+		case attempt := <-emitter.attempts:
+			attempts = append(attempts, attempt)
+		default:
+			return attempts
+		}
+	}
+}
+
+func TestRetry_TransientErrorIsRetried(t *testing.T) {
+	b := &testBooster{
+		id:           "a",
+		execResult:   okResult(),
+		execErr:      entities.NewTransientError(errors.New("device busy")),
+		execFailures: 2,
+		retry:        fastRetry(3),
+	}
+	queueManager, emitter, recorder := newRetryFixture(t, b)
+	ctx := context.Background()
+
+	opID, err := queueManager.Add(b.id, entities.ApplyOperationType)
+	require.NoError(t, err)
+	require.NoError(t, queueManager.AwaitOperation(ctx, opID))
+
+	op := <-recorder
+	assert.Equal(t, 3, op.Attempts)
+	assert.Empty(t, op.ErrorMsg)
+
+	attempts := drainAttempts(emitter)
+	require.Len(t, attempts, 3)
+	assert.True(t, attempts[0].WillRetry)
+	assert.Equal(t, entities.ErrorClassTransient, attempts[0].ErrorClass)
+	assert.Equal(t, time.Millisecond, attempts[0].Backoff)
+	assert.Equal(t, 2*time.Millisecond, attempts[1].Backoff)
+	assert.Empty(t, attempts[2].Error)
+	assert.False(t, attempts[2].WillRetry)
+}
+
+func TestRetry_PermanentErrorIsNotRetried(t *testing.T) {
+	b := &testBooster{id: "a", execErr: errors.New("access denied"), retry: fastRetry(3)}
+	queueManager, emitter, recorder := newRetryFixture(t, b)
+
+	opID, err := queueManager.Add(b.id, entities.ApplyOperationType)
+	require.NoError(t, err)
+	require.Error(t, queueManager.AwaitOperation(context.Background(), opID))
+
+	op := <-recorder
+	assert.Equal(t, 1, op.Attempts)
+	assert.Equal(t, 1, b.execCalls)
+
+	attempts := drainAttempts(emitter)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, entities.ErrorClassPermanent, attempts[0].ErrorClass)
+	assert.False(t, attempts[0].WillRetry)
+}
+
+func TestRetry_HungExecutorIsAbandonedOnTimeout(t *testing.T) {
+	block := make(chan struct{})
+	t.Cleanup(func() { close(block) })
+
+	b := &testBooster{id: "a", execResult: okResult(), execBlock: block, timeout: 20 * time.Millisecond, retry: fastRetry(3)}
+	queueManager, emitter, recorder := newRetryFixture(t, b)
+
+	opID, err := queueManager.Add(b.id, entities.ApplyOperationType)
+	require.NoError(t, err)
+	err = queueManager.AwaitOperation(context.Background(), opID)
+	require.ErrorIs(t, err, ErrOperationTimeout)
+
+	op := <-recorder
+	assert.Equal(t, 1, op.Attempts)
+
+	// a tentativa abandonada pode continuar rodando; não é repetida em paralelo
+	attempts := drainAttempts(emitter)
+	require.Len(t, attempts, 1)
+	assert.Equal(t, entities.ErrorClassTimeout, attempts[0].ErrorClass)
+	assert.False(t, attempts[0].WillRetry)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := entities.RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 3}
+
+	assert.Equal(t, 100*time.Millisecond, policy.Backoff(1))
+	assert.Equal(t, 300*time.Millisecond, policy.Backoff(2))
+	assert.Equal(t, 900*time.Millisecond, policy.Backoff(3))
+	assert.Equal(t, time.Second, policy.Backoff(4))
+}
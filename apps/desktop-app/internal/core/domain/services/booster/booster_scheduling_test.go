@@ -0,0 +1,186 @@
+package booster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// helper para montar um pool com workers reais e boosters que avisam quando começam
+func newSchedulingFixture(t *testing.T, workers int, boosters ...*testBooster) (*Manager, chan string) {
+	started := make(chan string, 10)
+	for _, b := range boosters {
+		b.started = started
+		if b.execResult == nil {
+			b.execResult = okResult()
+		}
+	}
+
+	proc := newResolverProcessor(t, boosters...)
+	queueManager := NewManager(10)
+	pool := NewPool(workers, proc, newRecordingEmitter(), noopRecorder{}, queueManager)
+	pool.SetDefaultPolicy(ExecutionPolicy{Timeout: time.Second, Retry: entities.RetryPolicy{MaxAttempts: 1}})
+	pool.Start()
+	t.Cleanup(pool.Stop)
+	return queueManager, started
+}
+
+func expectStarted(t *testing.T, started <-chan string, boosterID string) {
+	t.Helper()
+	select {
+	case id := <-started:
+		require.Equal(t, boosterID, id)
+	case <-time.After(time.Second):
+		t.Fatalf("%s did not start", boosterID)
+	}
+}
+
+func expectIdle(t *testing.T, started <-chan string) {
+	t.Helper()
+	select {
+	case id := <-started:
+		t.Fatalf("%s started while its resource was locked", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestManager_NextPrefersHigherPriorityLanes(t *testing.T) {
+	queueManager := NewManager(10)
+
+	_, err := queueManager.AddWithOptions("scheduled", entities.ApplyOperationType, QueueItemOptions{Priority: entities.PriorityScheduled})
+	require.NoError(t, err)
+	_, err = queueManager.AddWithOptions("batch", entities.ApplyOperationType, QueueItemOptions{Priority: entities.PriorityBatch})
+	require.NoError(t, err)
+	_, err = queueManager.Add("interactive", entities.ApplyOperationType)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, queueManager.GetPosition("interactive"))
+	assert.Equal(t, 2, queueManager.GetPosition("batch"))
+	assert.Equal(t, 3, queueManager.GetPosition("scheduled"))
+
+	for _, expected := range []string{"interactive", "batch", "scheduled"} {
+		item, ok := queueManager.Next()
+		require.True(t, ok)
+		assert.Equal(t, expected, item.BoosterID)
+	}
+}
+
+func TestPool_InteractiveOperationJumpsAheadOfBatch(t *testing.T) {
+	block := make(chan struct{})
+	queueManager, started := newSchedulingFixture(t, 1,
+		&testBooster{id: "running", execBlock: block},
+		&testBooster{id: "batch"},
+		&testBooster{id: "interactive"},
+	)
+	ctx := context.Background()
+
+	_, err := queueManager.Add("running", entities.ApplyOperationType)
+	require.NoError(t, err)
+	expectStarted(t, started, "running")
+
+	batchID, err := queueManager.AddWithOptions("batch", entities.ApplyOperationType, QueueItemOptions{Priority: entities.PriorityBatch})
+	require.NoError(t, err)
+	_, err = queueManager.Add("interactive", entities.ApplyOperationType)
+	require.NoError(t, err)
+
+	close(block)
+	expectStarted(t, started, "interactive")
+	expectStarted(t, started, "batch")
+	require.NoError(t, queueManager.AwaitOperation(ctx, batchID))
+}
+
+func TestPool_SerializesOperationsSharingAResource(t *testing.T) {
+	resolvConf := []string{entities.FileResource("/etc/resolv.conf")}
+	block := make(chan struct{})
+	queueManager, started := newSchedulingFixture(t, 3,
+		&testBooster{id: "first", execBlock: block, resources: resolvConf},
+		&testBooster{id: "second", resources: resolvConf},
+		&testBooster{id: "unrelated", resources: []string{entities.RegistryResource(`HKLM\Tcpip`)}},
+	)
+	ctx := context.Background()
+
+	_, err := queueManager.Add("first", entities.ApplyOperationType)
+	require.NoError(t, err)
+	expectStarted(t, started, "first")
+
+	secondID, err := queueManager.Add("second", entities.ApplyOperationType)
+	require.NoError(t, err)
+	unrelatedID, err := queueManager.Add("unrelated", entities.ApplyOperationType)
+	require.NoError(t, err)
+
+	// o booster sem recurso em comum roda em paralelo
+	expectStarted(t, started, "unrelated")
+	require.NoError(t, queueManager.AwaitOperation(ctx, unrelatedID))
+	expectIdle(t, started)
+
+	close(block)
+	expectStarted(t, started, "second")
+	require.NoError(t, queueManager.AwaitOperation(ctx, secondID))
+}
+
+func TestResourceLocks_CancelledWaitReturnsContextError(t *testing.T) {
+	locks := newResourceLocks()
+	release, err := locks.acquire(context.Background(), nil, []string{"r"})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = locks.acquire(ctx, nil, []string{"other", "r"})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// nenhum recurso fica preso por uma aquisição que falhou
+	release()
+	releaseOther, err := locks.acquire(context.Background(), nil, []string{"other", "r"})
+	require.NoError(t, err)
+	releaseOther()
+}
+
+func TestService_SingleApplyOvertakesQueuedBatch(t *testing.T) {
+	block := make(chan struct{})
+	started := make(chan string, 10)
+	boosters := []*testBooster{
+		{id: "running", execBlock: block},
+		{id: "batch-a"},
+		{id: "batch-b"},
+		{id: "single"},
+	}
+	for _, b := range boosters {
+		b.started = started
+		b.execResult = okResult()
+	}
+
+	proc := newResolverProcessor(t, boosters...)
+	queueManager := NewManager(10)
+	emitter := newRecordingEmitter()
+	service := &Service{
+		processor:          proc,
+		queueManager:       queueManager,
+		workerPool:         NewPool(1, proc, emitter, noopRecorder{}, queueManager),
+		eventEmitter:       emitter,
+		dependencyResolver: NewDependencyResolver(proc, DependencyPolicy{}),
+	}
+	service.StartWorkers()
+	t.Cleanup(service.workerPool.Stop)
+	ctx := context.Background()
+
+	_, err := queueManager.Add("running", entities.ApplyOperationType)
+	require.NoError(t, err)
+	expectStarted(t, started, "running")
+
+	_, err = service.InitBoosterApplyBatch(ctx, []string{"batch-a", "batch-b"})
+	require.NoError(t, err)
+	res, err := service.InitBoosterApply(ctx, "single")
+	require.NoError(t, err)
+	assert.Equal(t, 1, queueManager.GetPosition("single"))
+
+	close(block)
+	expectStarted(t, started, "single")
+	expectStarted(t, started, "batch-a")
+	expectStarted(t, started, "batch-b")
+	require.NoError(t, queueManager.AwaitOperation(ctx, res.OperationID))
+}
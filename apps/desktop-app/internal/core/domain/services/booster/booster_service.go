@@ -3,16 +3,24 @@ package booster
 import (
 	"context"
 	"fmt"
+	"runtime"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+	"github.com/oLenador/mulltbost/internal/core/application/ports/outbound"
 	"github.com/oLenador/mulltbost/internal/core/domain/dto"
 	"github.com/oLenador/mulltbost/internal/core/domain/entities"
 	"github.com/oLenador/mulltbost/internal/core/domain/services/i18n"
 	boosterBase "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/base"
 	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/connection"
-	repos "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/repositories"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/flusher"
+	performance "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/fpsboost"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/games"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/manifest"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/plugin"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/precision"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/capabilities"
 	"github.com/wailsapp/wails/lib/logger"
 	"github.com/wailsapp/wails/v3/pkg/application"
 )
@@ -22,24 +30,77 @@ type Service struct {
 	queueManager        *Manager
 	workerPool          *Pool
 	historyRecorder     *Recorder
-	eventEmitter        *BoosterEventEmitter
-	boostActivationRepo *repos.BoostConfigRepository
+	eventEmitter        EventEmitter
+	boostActivationRepo outbound.BoosterActivationRepository
+	dependencyResolver  *DependencyResolver
+	driftChecker        *DriftChecker
+	snapshotter         *Snapshotter
+	expiryScheduler     *ExpiryScheduler
+	catalog             *Catalog
+	capabilities        *CapabilityProber
+	pluginHost          *plugin.Host
+	healthMonitor       *HealthMonitor
+	reconciliation      *entities.ReconciliationReport
+	databaseRecovery    *entities.DatabaseRecovery
 }
 
 type Config struct {
-	WorkerCount     int
-	QueueBufferSize int
+	WorkerCount      int
+	QueueBufferSize  int
+	DependencyPolicy DependencyPolicy
+	// DriftCheckInterval define a frequência da verificação de drift; <= 0 desativa
+	DriftCheckInterval time.Duration
+	// DefaultDriftPolicy vale para boosters que não declaram política própria
+	DefaultDriftPolicy entities.DriftPolicy
+	// DefaultExecutionPolicy vale para boosters sem timeout ou retry próprios
+	DefaultExecutionPolicy ExecutionPolicy
+	// AutoSnapshotRetention limita quantos snapshots automáticos (antes de lotes) são mantidos
+	AutoSnapshotRetention int
+	// ExpiryCheckInterval define a frequência da verificação dos boosters por tempo limitado
+	ExpiryCheckInterval time.Duration
+	// ExpiryWarning define a antecedência do aviso de que um booster vai expirar
+	ExpiryWarning time.Duration
+	// Health define quando uma operação está travada e quando um worker não responde
+	Health HealthPolicy
+	// HealthCheckInterval define a frequência da verificação de saúde; <= 0 desativa
+	HealthCheckInterval time.Duration
 }
 
 func NewService(
-	rollbackRepo *repos.RollbackRepo,
-	operationsRepo *repos.BoostOperationsRepo,
+	rollbackRepo outbound.BoosterStateRepository,
+	operationsRepo outbound.OperationHistoryRepository,
 	eventManager *application.EventManager,
-	boostActivationRepo *repos.BoostConfigRepository,
+	boostActivationRepo outbound.BoosterActivationRepository,
+	journalRepo OperationJournal,
+	snapshotRepo SnapshotStore,
+	expiryRepo ExpiryStore,
+	settingsRepo ParamStore,
 ) (*Service, error) {
 	config := Config{
 		WorkerCount:     3,
 		QueueBufferSize: 100,
+		DependencyPolicy: DependencyPolicy{
+			IncludeMissingDependencies: true,
+			RevertConflicting:          false,
+		},
+		DriftCheckInterval: 10 * time.Minute,
+		DefaultDriftPolicy: entities.DriftPolicyNotify,
+		DefaultExecutionPolicy: ExecutionPolicy{
+			Timeout: 2 * time.Minute,
+			Retry: entities.RetryPolicy{
+				MaxAttempts:    1,
+				InitialBackoff: time.Second,
+				MaxBackoff:     30 * time.Second,
+				Multiplier:     2,
+				RetryOn:        []entities.ErrorClass{entities.ErrorClassTimeout, entities.ErrorClassTransient},
+			},
+			CancelGrace: 5 * time.Second,
+		},
+		AutoSnapshotRetention: 20,
+		ExpiryCheckInterval:   30 * time.Second,
+		ExpiryWarning:         5 * time.Minute,
+		Health:                DefaultHealthPolicy(),
+		HealthCheckInterval:   15 * time.Second,
 	}
 
 	boosterProcessor := NewBoosterProcessor(rollbackRepo)
@@ -47,6 +108,15 @@ func NewService(
 	historyRecorder := NewRecorder(operationsRepo)
 	eventEmitter := NewBoosterEventEmitter(eventManager)
 
+	boosterProcessor.SetJournal(journalRepo)
+	boosterProcessor.SetParamStore(settingsRepo)
+	boosterProcessor.SetActivationRepo(boostActivationRepo)
+	capabilityProber := NewCapabilityProber(capabilities.NewProbe(), capabilities.NewFileCache(capabilities.CachePath()))
+	boosterProcessor.SetCapabilityProber(capabilityProber)
+	queueManager.SetJournal(journalRepo)
+	queueManager.SetRecorder(historyRecorder)
+	historyRecorder.SetVersionLookup(boosterProcessor.BoosterVersion)
+
 	workerPool := NewPool(
 		config.WorkerCount,
 		boosterProcessor,
@@ -54,16 +124,41 @@ func NewService(
 		historyRecorder,
 		queueManager,
 	)
+	workerPool.SetDefaultPolicy(config.DefaultExecutionPolicy)
+	workerPool.SetHealthPolicy(config.Health)
+
+	catalog := NewCatalog(entities.Platform(runtime.GOOS))
+	pluginHost := plugin.NewHost(plugin.Dir(), plugin.DefaultOptions())
+	err := initAllBoosts(boosterProcessor, catalog, pluginHost)
+	if err != nil {
+		pluginHost.Close()
+		return nil, fmt.Errorf("Erro on register the boosters: %w", err)
+	}
+
+	err = boostActivationRepo.SyncWithAvailableBoosts(context.Background(), boosterProcessor.GetAllBoostersEntities())
+	if err != nil {
+		return nil, fmt.Errorf("Erro on sync the boosters: %w", err)
+	}
 
-	err := initAllBoosts(boosterProcessor)
+	// Registros que ficaram abertos pertencem à execução anterior; o journal decide o destino delas
+	if _, err := historyRecorder.CloseInterrupted(context.Background()); err != nil {
+		return nil, fmt.Errorf("Erro on close interrupted operations: %w", err)
+	}
+
+	// Conclui, desfaz ou reenfileira operações interrompidas na última execução
+	recoveries, err := NewJournalRecoverer(journalRepo, boosterProcessor, queueManager, eventEmitter).Recover(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("Erro on register the boosters", err)
+		return nil, fmt.Errorf("Erro on recover interrupted operations: %w", err)
 	}
 
-	err = boostActivationRepo.SyncWithAvailableBoosts(boosterProcessor.GetAllBoostersEntities())
+	// Repara divergências entre ativação, rollback e sistema antes de aceitar operações
+	reconciler := NewReconciler(boosterProcessor, boostActivationRepo)
+	reconciler.ReviewInterrupted(recoveries)
+	reconciliation, err := reconciler.Reconcile(context.Background())
 	if err != nil {
-		return nil, fmt.Errorf("Erro on sync the boosters", err)
+		return nil, fmt.Errorf("Erro on reconcile the boosters: %w", err)
 	}
+	reconciliation.InterruptedOperations = recoveries
 
 	service := &Service{
 		processor:           boosterProcessor,
@@ -72,30 +167,49 @@ func NewService(
 		historyRecorder:     historyRecorder,
 		eventEmitter:        eventEmitter,
 		boostActivationRepo: boostActivationRepo,
+		dependencyResolver:  NewDependencyResolver(boosterProcessor, config.DependencyPolicy),
+		reconciliation:      reconciliation,
+		driftChecker:        NewDriftChecker(boosterProcessor, queueManager, eventEmitter, config.DriftCheckInterval, config.DefaultDriftPolicy),
+		snapshotter:         NewSnapshotter(boosterProcessor, snapshotRepo, config.AutoSnapshotRetention),
+		expiryScheduler:     NewExpiryScheduler(boosterProcessor, queueManager, eventEmitter, expiryRepo, config.ExpiryCheckInterval, config.ExpiryWarning),
+		catalog:             catalog,
+		capabilities:        capabilityProber,
+		pluginHost:          pluginHost,
 	}
+	service.healthMonitor = NewHealthMonitor(service.computeHealth, eventEmitter, config.HealthCheckInterval)
 
 	service.StartWorkers()
+	service.driftChecker.Start()
+	service.expiryScheduler.Start()
+	service.healthMonitor.Start()
 
 	return service, nil
 }
 
-func initAllBoosts(processor *BoosterProcessor) error {
-
+// initAllBoosts registra no catálogo os boosters de todas as categorias, depois
+// os dos manifestos e por último os dos plugins externos
+func initAllBoosts(processor *BoosterProcessor, catalog *Catalog, pluginHost *plugin.Host) error {
 	ps := boosterBase.GetPlatformServices()
 	deps := inbound.NewExecutorDepServices(ps)
 
-	loaders := map[string][]inbound.BoosterUseCase{
-		"connection": connection.GetAllPlugins(deps),
+	builtin := func(load func() []inbound.BoosterUseCase) func() ([]inbound.BoosterUseCase, error) {
+		return func() ([]inbound.BoosterUseCase, error) { return load(), nil }
 	}
 
-	for _, boostArray := range loaders {
-		for _, booster := range boostArray {
-			if err := processor.RegisterBooster(booster); err != nil {
-				return err
-			}
-		}
-	}
-	return nil
+	return catalog.Register(processor,
+		CatalogSource{Name: "connection", Load: builtin(func() []inbound.BoosterUseCase { return connection.GetAllPlugins(deps) })},
+		CatalogSource{Name: "performance", Load: builtin(performance.GetAllPlugins)},
+		CatalogSource{Name: "precision", Load: builtin(precision.GetAllPlugins)},
+		CatalogSource{Name: "flusher", Load: builtin(flusher.GetAllPlugins)},
+		CatalogSource{Name: "games", Load: builtin(games.GetAllPlugins)},
+		// Manifestos e plugins não substituem os boosters implementados em Go
+		CatalogSource{Name: "manifest", External: true, Load: func() ([]inbound.BoosterUseCase, error) {
+			return manifest.GetAllPlugins(deps)
+		}},
+		CatalogSource{Name: "plugin", External: true, Load: func() ([]inbound.BoosterUseCase, error) {
+			return pluginHost.GetAllPlugins(context.Background()), nil
+		}},
+	)
 }
 
 func (s *Service) StartWorkers() {
@@ -103,7 +217,40 @@ func (s *Service) StartWorkers() {
 }
 
 func (s *Service) StopWorkers() {
+	if s.healthMonitor != nil {
+		s.healthMonitor.Stop()
+	}
+	if s.driftChecker != nil {
+		s.driftChecker.Stop()
+	}
+	if s.expiryScheduler != nil {
+		s.expiryScheduler.Stop()
+	}
 	s.workerPool.Stop()
+	if s.pluginHost != nil {
+		s.pluginHost.Close()
+	}
+}
+
+// CheckBoosterDrift verifica imediatamente se os boosters aplicados continuam em efeito
+func (s *Service) CheckBoosterDrift(ctx context.Context) ([]entities.BoostVerifyResult, error) {
+	return s.driftChecker.CheckAll(ctx)
+}
+
+// GetReconciliationReport retorna o relatório da reconciliação feita na inicialização
+func (s *Service) GetReconciliationReport(ctx context.Context) *entities.ReconciliationReport {
+	return s.reconciliation
+}
+
+// SetDatabaseRecovery registra a restauração do banco feita na inicialização
+func (s *Service) SetDatabaseRecovery(recovery *entities.DatabaseRecovery) {
+	s.databaseRecovery = recovery
+}
+
+// GetDatabaseRecovery retorna a restauração do banco feita na inicialização, ou
+// nil se o banco estava íntegro
+func (s *Service) GetDatabaseRecovery(ctx context.Context) *entities.DatabaseRecovery {
+	return s.databaseRecovery
 }
 
 func (s *Service) RegisterBooster(booster inbound.BoosterUseCase) error {
@@ -132,6 +279,10 @@ func (s *Service) convertBoosterToDto(ctx context.Context, booster inbound.Boost
 	if err != nil {
 		return nil, err
 	}
+	paramValues, err := s.processor.resolveParams(ctx, booster)
+	if err != nil {
+		return nil, err
+	}
 	
 	return &dto.GetBoosterDto{
 		ID:           boosterDto.ID,
@@ -149,9 +300,45 @@ func (s *Service) convertBoosterToDto(ctx context.Context, booster inbound.Boost
 		AppliedAt:    resActivationState.AppliedAt,
 		RevertedAt:   resActivationState.RevertedAt,
 		Tags:         boosterDto.Tags,
+		Params:       boosterDto.Params,
+		ParamValues:  paramValues,
+		Available:    true,
+
+		UnsupportedReasons: s.unsupportedReasons(ctx, booster.GetEntity()),
+		RequiresReboot:     booster.GetEntity().Requirements.RebootRequired,
 	}, nil
 }
 
+// unavailableBoosterDto descreve um booster do catálogo que não foi registrado
+func unavailableBoosterDto(unavailable UnavailableBooster, lang i18n.Language) dto.GetBoosterDto {
+	boosterDto := unavailable.Booster.GetEntityDto(lang)
+	return dto.GetBoosterDto{
+		ID:                boosterDto.ID,
+		Name:              boosterDto.Name,
+		Description:       boosterDto.Description,
+		Category:          boosterDto.Category,
+		Level:             boosterDto.Level,
+		Platform:          boosterDto.Platform,
+		Dependencies:      boosterDto.Dependencies,
+		Conflicts:         boosterDto.Conflicts,
+		Reversible:        boosterDto.Reversible,
+		RiskLevel:         boosterDto.RiskLevel,
+		Version:           boosterDto.Version,
+		Tags:              boosterDto.Tags,
+		Params:            boosterDto.Params,
+		UnavailableReason: unavailable.Reason,
+		RequiresReboot:    unavailable.Booster.GetEntity().Requirements.RebootRequired,
+	}
+}
+
+// unsupportedReasons usa o resultado do probe de capacidades do boot atual
+func (s *Service) unsupportedReasons(ctx context.Context, entity entities.Booster) []entities.UnsupportedReason {
+	if s.capabilities == nil {
+		return nil
+	}
+	return s.capabilities.Unsupported(ctx, entity)
+}
+
 
 func (s *Service) GetAvailableBoosters(ctx context.Context, lang i18n.Language) []dto.GetBoosterDto {
 	boosters := s.processor.GetAllBoosters()
@@ -164,6 +351,9 @@ func (s *Service) GetAvailableBoosters(ctx context.Context, lang i18n.Language)
 		}
 		result = append(result, *boosterDto)
 	}
+	for _, unavailable := range s.catalog.Unavailable() {
+		result = append(result, unavailableBoosterDto(unavailable, lang))
+	}
 	return result
 }
 
@@ -187,6 +377,11 @@ func (s *Service) GetBoostersByCategory(ctx context.Context, category entities.B
 			result = append(result, *fullBoosterDto)
 		}
 	}
+	for _, unavailable := range s.catalog.Unavailable() {
+		if unavailable.Booster.GetEntity().Category == category {
+			result = append(result, unavailableBoosterDto(unavailable, lang))
+		}
+	}
 	return result
 }
 
@@ -203,80 +398,84 @@ func (s *Service) GetExecutionQueueState(ctx context.Context) *entities.QueueSta
 	return queueItems
 }
 
-func (s *Service) InitBoosterApply(ctx context.Context, id string) (entities.InitResult, error) {
-
+func (s *Service) InitBoosterApply(ctx context.Context, id string, opts ...entities.ApplyOptions) (entities.InitResult, error) {
+	plan := s.dependencyResolver.PlanApply(ctx, []string{id})
+	if err := s.scheduleExpiries(ctx, []string{id}, plan, opts); err != nil {
+		return expiryFailedResult(err), err
+	}
+	return s.initSinglePlan(id, plan, "operation queued successfully")
+}
 
-	operationID, err := s.queueManager.Add(id, entities.ApplyOperationType)
-	if err != nil {
-		return entities.InitResult{
-			OperationID: "",
-			SubmittedAt: time.Now(),
-			Success:     false,
-			Status:      entities.OperationFailed,
-			Message:     "failed to enqueue operation",
-			Error:       err,
-		}, err
+func (s *Service) InitBoosterApplyBatch(ctx context.Context, ids []string, opts ...entities.ApplyOptions) (entities.InitResult, error) {
+	plan := s.dependencyResolver.PlanApply(ctx, ids)
+	if err := s.scheduleExpiries(ctx, ids, plan, opts); err != nil {
+		return expiryFailedResult(err), err
 	}
+	return s.initBatchPlan(ctx, entities.ApplyOperationType, plan)
+}
 
-	s.eventEmitter.EmitQueued(id, operationID, entities.ApplyOperationType, s.queueManager.Size())
+func (s *Service) InitRevertBooster(ctx context.Context, id string) (entities.InitResult, error) {
+	plan := s.dependencyResolver.PlanRevert(ctx, []string{id})
+	s.clearExpiries(ctx, []string{id})
+	return s.initSinglePlan(id, plan, "revert operation queued successfully")
+}
 
-	return entities.InitResult{
-		OperationID: operationID,
-		SubmittedAt: time.Now(),
-		Success:     true,
-		Status:      entities.OperationPending,
-		Message:     "operation queued successfully",
-	}, nil
+func (s *Service) InitRevertBoosterBatch(ctx context.Context, ids []string) (entities.InitResult, error) {
+	plan := s.dependencyResolver.PlanRevert(ctx, ids)
+	s.clearExpiries(ctx, ids)
+	return s.initBatchPlan(ctx, entities.RevertOperationType, plan)
 }
 
-func (s *Service) InitBoosterApplyBatch(ctx context.Context, ids []string) (entities.InitResult, error) {
-	batchID := uuid.New().String()
-	successCount := 0
-	validationErrors := make(map[string]error)
+// PlanBooster retorna a prévia das alterações de um booster sem aplicá-lo
+func (s *Service) PlanBooster(ctx context.Context, id string) (*entities.BoostPlan, error) {
+	plan, err := s.processor.ProcessPlan(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	plan.Requested = true
+	return plan, nil
+}
+
+// PlanBoosterBatch retorna a prévia de um lote na ordem em que seria executado,
+// incluindo dependências e reversões de conflitos adicionadas pelo resolver
+func (s *Service) PlanBoosterBatch(ctx context.Context, ids []string) (*entities.BoostBatchPlan, error) {
+	plan := s.dependencyResolver.PlanApply(ctx, ids)
+	result := &entities.BoostBatchPlan{
+		Plans:    make([]entities.BoostPlan, 0, len(plan.Steps)),
+		Rejected: make(map[string]string, len(plan.Errors)),
+	}
+	for id, err := range plan.Errors {
+		result.Rejected[id] = err.Error()
+	}
 
-	for _, id := range ids {
-		if err := s.processor.ValidateBoosterOperation(ctx, id, entities.ApplyOperationType); err != nil {
-			validationErrors[id] = err
+	for _, step := range plan.Steps {
+		// Reversões restauram o backup salvo; não há prévia de valores
+		if step.Operation == entities.RevertOperationType {
+			result.Plans = append(result.Plans, entities.BoostPlan{
+				BoosterID:   step.BoosterID,
+				Operation:   step.Operation,
+				Requested:   step.Requested,
+				GeneratedAt: time.Now(),
+			})
 			continue
 		}
 
-		if _, err := s.queueManager.Add(id, entities.ApplyOperationType); err == nil {
-			successCount++
-		} else {
-			validationErrors[id] = err
+		boostPlan, err := s.processor.ProcessPlan(ctx, step.BoosterID)
+		if err != nil {
+			result.Rejected[step.BoosterID] = err.Error()
+			continue
 		}
+		boostPlan.Requested = step.Requested
+		result.Plans = append(result.Plans, *boostPlan)
 	}
 
-	s.eventEmitter.EmitBatchQueued(
-		batchID,
-		entities.ApplyOperationType,
-		len(ids),
-		successCount,
-		validationErrors,
-		s.queueManager.Size(),
-	)
-
-	status := entities.OperationPending
-	success := true
-	message := fmt.Sprintf("batch queued with %d/%d successes", successCount, len(ids))
-
-	if successCount == 0 {
-		status = entities.OperationFailed
-		success = false
-		message = "all operations failed"
-	}
-
-	return entities.InitResult{
-		OperationID: batchID,
-		SubmittedAt: time.Now(),
-		Success:     success,
-		Status:      status,
-		Message:     message,
-	}, nil
+	return result, nil
 }
 
-func (s *Service) InitRevertBooster(ctx context.Context, id string) (entities.InitResult, error) {
-	if err := s.processor.ValidateBoosterOperation(ctx, id, entities.RevertOperationType); err != nil {
+// initSinglePlan enfileira o plano de um único booster, incluindo as operações
+// de dependências e conflitos que o resolver adicionou
+func (s *Service) initSinglePlan(id string, plan *BatchPlan, message string) (entities.InitResult, error) {
+	if err, rejected := plan.Errors[id]; rejected {
 		return entities.InitResult{
 			SubmittedAt: time.Now(),
 			Success:     false,
@@ -286,8 +485,8 @@ func (s *Service) InitRevertBooster(ctx context.Context, id string) (entities.In
 		}, err
 	}
 
-	operationID, err := s.queueManager.Add(id, entities.RevertOperationType)
-	if err != nil {
+	operationIDs, enqueueErrors := s.enqueuePlan(plan, "", entities.PriorityInteractive)
+	if err, failed := enqueueErrors[id]; failed {
 		return entities.InitResult{
 			SubmittedAt: time.Now(),
 			Success:     false,
@@ -297,39 +496,55 @@ func (s *Service) InitRevertBooster(ctx context.Context, id string) (entities.In
 		}, err
 	}
 
-	s.eventEmitter.EmitQueued(id, operationID, entities.RevertOperationType, s.queueManager.Size())
+	for _, step := range plan.Steps {
+		if operationID, queued := operationIDs[step.BoosterID]; queued {
+			s.eventEmitter.EmitQueued(step.BoosterID, operationID, step.Operation, s.queueManager.Size())
+		}
+	}
 
 	return entities.InitResult{
-		OperationID: operationID,
+		OperationID: operationIDs[id],
 		SubmittedAt: time.Now(),
 		Success:     true,
 		Status:      entities.OperationPending,
-		Message:     "revert operation queued successfully",
+		Message:     message,
 	}, nil
 }
 
-func (s *Service) InitRevertBoosterBatch(ctx context.Context, ids []string) (entities.InitResult, error) {
+// initBatchPlan grava um snapshot do estado atual e enfileira o plano em lote
+func (s *Service) initBatchPlan(ctx context.Context, operation entities.BoosterOperationType, plan *BatchPlan) (entities.InitResult, error) {
 	batchID := uuid.New().String()
-	successCount := 0
-	validationErrors := make(map[string]error)
+	if _, err := s.snapshotBeforeBatch(ctx, batchID, operation, plan); err != nil {
+		return entities.InitResult{
+			OperationID: batchID,
+			SubmittedAt: time.Now(),
+			Success:     false,
+			Status:      entities.OperationFailed,
+			Message:     "failed to create snapshot before batch",
+			Error:       err,
+		}, err
+	}
+	return s.queueBatchPlan(batchID, operation, plan), nil
+}
 
-	for _, id := range ids {
-		if err := s.processor.ValidateBoosterOperation(ctx, id, entities.RevertOperationType); err != nil {
-			validationErrors[id] = err
-			continue
-		}
+// queueBatchPlan enfileira um plano em lote e emite o evento com os erros de validação
+func (s *Service) queueBatchPlan(batchID string, operation entities.BoosterOperationType, plan *BatchPlan) entities.InitResult {
+	operationIDs, enqueueErrors := s.enqueuePlan(plan, batchID, entities.PriorityBatch)
 
-		if _, err := s.queueManager.Add(id, entities.RevertOperationType); err == nil {
-			successCount++
-		} else {
-			validationErrors[id] = err
-		}
+	validationErrors := make(map[string]error, len(plan.Errors)+len(enqueueErrors))
+	for id, err := range plan.Errors {
+		validationErrors[id] = err
+	}
+	for id, err := range enqueueErrors {
+		validationErrors[id] = err
 	}
+	successCount := len(operationIDs)
+	totalCount := successCount + len(validationErrors)
 
 	s.eventEmitter.EmitBatchQueued(
 		batchID,
-		entities.RevertOperationType,
-		len(ids),
+		operation,
+		totalCount,
 		successCount,
 		validationErrors,
 		s.queueManager.Size(),
@@ -337,7 +552,7 @@ func (s *Service) InitRevertBoosterBatch(ctx context.Context, ids []string) (ent
 
 	status := entities.OperationPending
 	success := true
-	message := fmt.Sprintf("batch queued with %d/%d successes", successCount, len(ids))
+	message := fmt.Sprintf("batch queued with %d/%d successes", successCount, totalCount)
 
 	if successCount == 0 {
 		status = entities.OperationFailed
@@ -351,38 +566,89 @@ func (s *Service) InitRevertBoosterBatch(ctx context.Context, ids []string) (ent
 		Success:     success,
 		Status:      status,
 		Message:     message,
-	}, nil
+	}
+}
+
+// enqueuePlan enfileira os passos na ordem do plano, na faixa de priority; cada
+// item aguarda as operações dos boosters dos quais depende antes de executar
+func (s *Service) enqueuePlan(plan *BatchPlan, batchID string, priority entities.OperationPriority) (map[string]string, map[string]error) {
+	operationIDs := make(map[string]string, len(plan.Steps))
+	enqueueErrors := make(map[string]error)
+
+	for _, step := range plan.Steps {
+		dependsOn := make([]string, 0, len(step.DependsOn))
+		var blocked string
+		for _, depID := range step.DependsOn {
+			operationID, queued := operationIDs[depID]
+			if !queued {
+				blocked = depID
+				break
+			}
+			dependsOn = append(dependsOn, operationID)
+		}
+		if blocked != "" {
+			enqueueErrors[step.BoosterID] = entities.NewBoosterValidationError(step.BoosterID,
+				entities.ValidationMissingDependency, []string{blocked}, "operation for %s could not be queued", blocked)
+			continue
+		}
+
+		operationID, err := s.queueManager.AddWithOptions(step.BoosterID, step.Operation, QueueItemOptions{
+			BatchID:   batchID,
+			DependsOn: dependsOn,
+			Priority:  priority,
+		})
+		if err != nil {
+			enqueueErrors[step.BoosterID] = err
+			continue
+		}
+		operationIDs[step.BoosterID] = operationID
+	}
+
+	return operationIDs, enqueueErrors
 }
 
 func (s *Service) GetBoosterRollbackState(id string) (*entities.BoosterRollbackState, error) {
 	return s.processor.GetRollbackState(context.Background(), id)
 }
 
-// CancelOperation cancela uma operação na queue
-func (s *Service) CancelOperation(ctx context.Context, boosterID string) error {
-	if !s.queueManager.IsInQueue(boosterID) {
-		return fmt.Errorf("booster %s is not in queue", boosterID)
+// CancelOperation cancela uma operação pelo ID. Na queue ela é descartada; em
+// execução, o executor para no próximo checkpoint e as alterações parciais são desfeitas
+func (s *Service) CancelOperation(ctx context.Context, operationID string) error {
+	item, err := s.queueManager.CancelOperation(operationID)
+	if err != nil {
+		return fmt.Errorf("operation %s is not queued or running: %w", operationID, err)
 	}
 
-	s.queueManager.Remove(boosterID)
+	s.eventEmitter.EmitCancelled(item.BoosterID, item.OperationID, item.Operation, s.queueManager.Size())
+	return nil
+}
 
-	// Emite evento de cancelamento
-	s.eventEmitter.EmitCancelled(boosterID, s.queueManager.Size())
+// CancelBatch cancela todas as operações pendentes ou em execução de um lote
+func (s *Service) CancelBatch(ctx context.Context, batchID string) error {
+	items, err := s.queueManager.CancelBatch(batchID)
+	if err != nil {
+		return fmt.Errorf("batch %s has no queued or running operations: %w", batchID, err)
+	}
 
+	for _, item := range items {
+		s.eventEmitter.EmitCancelled(item.BoosterID, item.OperationID, item.Operation, s.queueManager.Size())
+	}
 	return nil
 }
 
-// GetQueueStats retorna estatísticas da queue
+// GetQueueStats retorna estatísticas da queue; a saúde é a mesma do HealthCheck
 func (s *Service) GetQueueStats() *QueueStats {
+	health := s.healthMonitor.Check(context.Background())
 	return &QueueStats{
-		Size:          s.queueManager.Size(),
-		ActiveWorkers: s.workerPool.GetActiveWorkerCount(),
-		IsHealthy:     s.queueManager.Size() < 50, // Arbitrário
+		Size:          health.QueueSize,
+		ActiveWorkers: health.ActiveWorkers,
+		IsHealthy:     health.IsHealthy,
 	}
 }
 
-func (s *Service) GetOperationStats(ctx context.Context) (*OperationStats, error) {
-	return s.historyRecorder.GetOperationStats(ctx)
+// GetOperationStats retorna as estatísticas das operações do filtro, no total e por booster
+func (s *Service) GetOperationStats(ctx context.Context, filter entities.OperationStatsFilter) (*entities.OperationStats, error) {
+	return s.historyRecorder.GetOperationStats(ctx, filter)
 }
 
 type QueueStats struct {
@@ -391,31 +657,11 @@ type QueueStats struct {
 	IsHealthy     bool `json:"isHealthy"`
 }
 
-// HealthCheck verifica a saúde do serviço
-func (s *Service) HealthCheck() *HealthStatus {
-	queueSize := s.queueManager.Size()
-
-	status := &HealthStatus{
-		IsHealthy:          true,
-		QueueSize:          queueSize,
-		ActiveWorkers:      s.workerPool.GetActiveWorkerCount(),
-		RegisteredBoosters: s.processor.GetBoosterCount(),
-	}
-
-	// Verifica se a queue não está muito cheia
-	if queueSize > 80 {
-		status.IsHealthy = false
-		status.Issues = append(status.Issues, "Queue is nearly full")
-	}
-
-	return status
+// HealthCheck verifica a saúde dos workers, da fila e das operações em execução
+func (s *Service) HealthCheck(ctx context.Context) *entities.HealthStatus {
+	return s.healthMonitor.Check(ctx)
 }
 
-// HealthStatus contém informações sobre a saúde do serviço
-type HealthStatus struct {
-	IsHealthy          bool     `json:"isHealthy"`
-	QueueSize          int      `json:"queueSize"`
-	ActiveWorkers      int      `json:"activeWorkers"`
-	RegisteredBoosters int      `json:"registeredBoosters"`
-	Issues             []string `json:"issues,omitempty"`
+func (s *Service) computeHealth(now time.Time) *entities.HealthStatus {
+	return buildHealthStatus(now, s.workerPool.WorkerHealth(now), s.queueManager.Size(), s.processor.GetBoosterCount())
 }
@@ -0,0 +1,48 @@
+package booster
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+func TestPlanBoosterBatch_FollowsExecutionOrder(t *testing.T) {
+	change := entities.NewPlannedChange("registry value", 0, 1, true)
+	service, _ := newTestService(t,
+		&testBooster{id: "a", planChanges: []entities.PlannedChange{change}},
+		&testBooster{id: "b", deps: []string{"a"}, planChanges: []entities.PlannedChange{change}},
+	)
+
+	plan, err := service.PlanBoosterBatch(context.Background(), []string{"b", "ghost"})
+	require.NoError(t, err)
+
+	require.Len(t, plan.Plans, 2)
+	assert.Equal(t, "a", plan.Plans[0].BoosterID)
+	assert.False(t, plan.Plans[0].Requested)
+	assert.Equal(t, "b", plan.Plans[1].BoosterID)
+	assert.True(t, plan.Plans[1].Requested)
+	assert.Equal(t, "0", plan.Plans[1].Changes[0].CurrentValue)
+	assert.Contains(t, plan.Rejected, "ghost")
+
+	// o frontend recebe a prévia em JSON, com a mensagem de cada rejeição
+	data, err := json.Marshal(plan)
+	require.NoError(t, err)
+	var decoded struct {
+		Plans []struct {
+			BoosterID string `json:"boosterId"`
+		} `json:"plans"`
+		Rejected map[string]string `json:"rejected"`
+	}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "b", decoded.Plans[1].BoosterID)
+	assert.Equal(t, plan.Rejected["ghost"], decoded.Rejected["ghost"])
+	assert.NotEmpty(t, decoded.Rejected["ghost"])
+
+	// nada foi enfileirado
+	assert.Equal(t, 0, service.queueManager.Size())
+}
@@ -0,0 +1,190 @@
+package booster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/wailsapp/wails/lib/logger"
+)
+
+// SnapshotStore persiste os snapshots do estado dos boosters
+type SnapshotStore interface {
+	Save(ctx context.Context, snapshot *entities.Snapshot) error
+	GetByID(ctx context.Context, id string) (*entities.Snapshot, error)
+	List(ctx context.Context) ([]entities.Snapshot, error)
+	Delete(ctx context.Context, id string) error
+	PruneOrigin(ctx context.Context, origin entities.SnapshotOrigin, keep int) error
+}
+
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// Snapshotter captura os boosters aplicados, o backup de cada um e os valores
+// que os executores reportam no sistema em execução
+type Snapshotter struct {
+	processor     *BoosterProcessor
+	store         SnapshotStore
+	autoRetention int
+	logger        *logger.CustomLogger
+}
+
+func NewSnapshotter(processor *BoosterProcessor, store SnapshotStore, autoRetention int) *Snapshotter {
+	return &Snapshotter{
+		processor:     processor,
+		store:         store,
+		autoRetention: autoRetention,
+		logger:        logger.NewCustomLogger("[Snapshotter]"),
+	}
+}
+
+// Capture grava um snapshot do estado atual; snapshots automáticos antigos são descartados
+func (s *Snapshotter) Capture(ctx context.Context, name string, origin entities.SnapshotOrigin, batchID string) (*entities.Snapshot, error) {
+	states, err := s.processor.GetAppliedStates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load applied boosters: %w", err)
+	}
+
+	snapshot := &entities.Snapshot{
+		ID:        uuid.New().String(),
+		Name:      name,
+		Origin:    origin,
+		BatchID:   batchID,
+		CreatedAt: time.Now(),
+		Boosters:  make([]entities.SnapshotBooster, 0, len(states)),
+	}
+	for _, state := range states {
+		booster := entities.SnapshotBooster{
+			BoosterID:  state.ID,
+			Version:    state.Version,
+			AppliedAt:  state.AppliedAt,
+			BackupData: state.BackupData,
+		}
+		booster.LiveValues, booster.LiveError = s.liveValues(ctx, state.ID)
+		snapshot.Boosters = append(snapshot.Boosters, booster)
+	}
+	sort.Slice(snapshot.Boosters, func(i, j int) bool {
+		return snapshot.Boosters[i].BoosterID < snapshot.Boosters[j].BoosterID
+	})
+
+	if err := s.store.Save(ctx, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	if origin != entities.SnapshotManual && s.autoRetention > 0 {
+		if err := s.store.PruneOrigin(ctx, origin, s.autoRetention); err != nil {
+			s.logger.Warnf("failed to prune %s snapshots: %v", origin, err)
+		}
+	}
+	return snapshot, nil
+}
+
+// liveValues lê os valores atuais pela prévia do executor, que reporta o valor
+// corrente de cada recurso que o booster altera
+func (s *Snapshotter) liveValues(ctx context.Context, boosterID string) (map[string]string, string) {
+	plan, err := s.processor.ProcessPlan(ctx, boosterID)
+	if err != nil {
+		return nil, err.Error()
+	}
+
+	values := make(map[string]string, len(plan.Changes))
+	for _, change := range plan.Changes {
+		values[change.Resource] = change.CurrentValue
+	}
+	return values, ""
+}
+
+// snapshotBeforeBatch cria o ponto de restauração automático de um lote que vai
+// alterar o sistema. Retorna o ID vazio se não há snapshots configurados ou nada a executar.
+func (s *Service) snapshotBeforeBatch(ctx context.Context, batchID string, operation entities.BoosterOperationType, plan *BatchPlan) (string, error) {
+	if s.snapshotter == nil || len(plan.Steps) == 0 {
+		return "", nil
+	}
+
+	name := fmt.Sprintf("Before %s batch", operation)
+	snapshot, err := s.snapshotter.Capture(ctx, name, entities.SnapshotBeforeBatch, batchID)
+	if err != nil {
+		return "", err
+	}
+	return snapshot.ID, nil
+}
+
+// CreateSnapshot grava um snapshot nomeado com todos os boosters aplicados
+func (s *Service) CreateSnapshot(ctx context.Context, name string) (*entities.Snapshot, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("snapshot name is required")
+	}
+	return s.snapshotter.Capture(ctx, name, entities.SnapshotManual, "")
+}
+
+// ListSnapshots retorna os snapshots do mais recente para o mais antigo
+func (s *Service) ListSnapshots(ctx context.Context) ([]entities.Snapshot, error) {
+	return s.snapshotter.store.List(ctx)
+}
+
+func (s *Service) GetSnapshot(ctx context.Context, snapshotID string) (*entities.Snapshot, error) {
+	snapshot, err := s.snapshotter.store.GetByID(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSnapshotNotFound, snapshotID)
+	}
+	return snapshot, nil
+}
+
+func (s *Service) DeleteSnapshot(ctx context.Context, snapshotID string) error {
+	if _, err := s.GetSnapshot(ctx, snapshotID); err != nil {
+		return err
+	}
+	return s.snapshotter.store.Delete(ctx, snapshotID)
+}
+
+// RestoreSnapshot enfileira as reversões e aplicações necessárias para que
+// fiquem aplicados exatamente os boosters do snapshot. Boosters aplicados nos
+// dois estados não são tocados.
+func (s *Service) RestoreSnapshot(ctx context.Context, snapshotID string) (*entities.SnapshotRestore, error) {
+	snapshot, err := s.GetSnapshot(ctx, snapshotID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := s.dependencyResolver.PlanRestore(ctx, snapshot.BoosterIDs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to plan snapshot restore: %w", err)
+	}
+
+	restore := &entities.SnapshotRestore{
+		SnapshotID: snapshot.ID,
+		Revert:     make([]string, 0),
+		Apply:      make([]string, 0),
+		Rejected:   make(map[string]string, len(plan.Errors)),
+	}
+	for _, step := range plan.Steps {
+		if step.Operation == entities.RevertOperationType {
+			restore.Revert = append(restore.Revert, step.BoosterID)
+		} else {
+			restore.Apply = append(restore.Apply, step.BoosterID)
+		}
+	}
+	for id, err := range plan.Errors {
+		restore.Rejected[id] = err.Error()
+	}
+	if len(plan.Steps) == 0 {
+		return restore, nil
+	}
+
+	batchID := uuid.New().String()
+	restore.SafetySnapshotID, err = s.snapshotBeforeBatch(ctx, batchID, entities.ApplyOperationType, plan)
+	if err != nil {
+		return nil, err
+	}
+	result := s.queueBatchPlan(batchID, entities.ApplyOperationType, plan)
+	restore.BatchID = result.OperationID
+	return restore, nil
+}
@@ -0,0 +1,150 @@
+package booster
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	storagemodels "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+	repos "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/repositories"
+)
+
+// helper para criar o repositório de snapshots num banco em memória compartilhado
+func setupSnapshotRepoForTest(t *testing.T) *repos.SnapshotRepo {
+	dsn := fmt.Sprintf("file:%s-%d?mode=memory&cache=shared", t.Name(), time.Now().UnixNano())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&storagemodels.SystemSnapshot{}))
+	return repos.NewSnapshotRepo(db)
+}
+
+func newSnapshotService(t *testing.T, autoRetention int, boosters ...*testBooster) *Service {
+	for _, b := range boosters {
+		if b.execResult == nil {
+			b.execResult = okResult()
+		}
+		if b.revertRes == nil {
+			b.revertRes = &entities.BoostRevertResult{Success: true}
+		}
+	}
+	service, _ := newRecordedService(t, boosters...)
+	service.snapshotter = NewSnapshotter(service.processor, setupSnapshotRepoForTest(t), autoRetention)
+	return service
+}
+
+func awaitBatch(t *testing.T, service *Service, batchID string) {
+	t.Helper()
+	ctx := context.Background()
+	batch, err := service.GetBatch(ctx, batchID)
+	require.NoError(t, err)
+	for _, op := range batch.Operations {
+		require.NoError(t, service.queueManager.AwaitOperation(ctx, op.OperationID), op.BoosterID)
+	}
+}
+
+func appliedIDs(t *testing.T, service *Service) []string {
+	t.Helper()
+	states, err := service.processor.GetAppliedStates(context.Background())
+	require.NoError(t, err)
+	ids := make([]string, 0, len(states))
+	for _, state := range states {
+		ids = append(ids, state.ID)
+	}
+	return ids
+}
+
+func TestRestoreSnapshot_ReturnsToCapturedState(t *testing.T) {
+	service := newSnapshotService(t, 10,
+		&testBooster{id: "a", conflicts: []string{"c"},
+			planChanges: []entities.PlannedChange{entities.NewPlannedChange("sysctl a", "1", "1", true)}},
+		&testBooster{id: "b"},
+		&testBooster{id: "c", conflicts: []string{"a"}},
+	)
+	ctx := context.Background()
+
+	res, err := service.InitBoosterApplyBatch(ctx, []string{"a", "b"})
+	require.NoError(t, err)
+	awaitBatch(t, service, res.OperationID)
+
+	snapshot, err := service.CreateSnapshot(ctx, "before tournament")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, snapshot.BoosterIDs())
+	assert.Equal(t, entities.SnapshotManual, snapshot.Origin)
+	assert.Equal(t, "v", snapshot.Boosters[0].BackupData["k"])
+	assert.Equal(t, map[string]string{"sysctl a": "1"}, snapshot.Boosters[0].LiveValues)
+
+	// c conflita com a: precisa ser revertido antes de a voltar
+	res, err = service.InitRevertBooster(ctx, "a")
+	require.NoError(t, err)
+	require.NoError(t, service.queueManager.AwaitOperation(ctx, res.OperationID))
+	res, err = service.InitBoosterApply(ctx, "c")
+	require.NoError(t, err)
+	require.NoError(t, service.queueManager.AwaitOperation(ctx, res.OperationID))
+	assert.ElementsMatch(t, []string{"b", "c"}, appliedIDs(t, service))
+
+	restore, err := service.RestoreSnapshot(ctx, snapshot.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, restore.Revert)
+	assert.Equal(t, []string{"a"}, restore.Apply)
+	assert.Empty(t, restore.Rejected)
+	awaitBatch(t, service, restore.BatchID)
+	assert.ElementsMatch(t, []string{"a", "b"}, appliedIDs(t, service))
+
+	// a restauração também deixa um ponto de volta para o estado anterior
+	safety, err := service.GetSnapshot(ctx, restore.SafetySnapshotID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "c"}, safety.BoosterIDs())
+	assert.Equal(t, restore.BatchID, safety.BatchID)
+
+	// restaurar de novo não tem nada a fazer
+	again, err := service.RestoreSnapshot(ctx, snapshot.ID)
+	require.NoError(t, err)
+	assert.Empty(t, again.BatchID)
+	assert.Empty(t, again.Revert)
+	assert.Empty(t, again.Apply)
+}
+
+func TestBatchOperations_CreateAutomaticSnapshots(t *testing.T) {
+	service := newSnapshotService(t, 2, &testBooster{id: "a"}, &testBooster{id: "b"})
+	ctx := context.Background()
+
+	_, err := service.CreateSnapshot(ctx, "manual")
+	require.NoError(t, err)
+
+	apply := func(ctx context.Context, ids []string) (entities.InitResult, error) {
+		return service.InitBoosterApplyBatch(ctx, ids)
+	}
+	batchIDs := make([]string, 0, 3)
+	for _, run := range []func(context.Context, []string) (entities.InitResult, error){
+		apply,
+		service.InitRevertBoosterBatch,
+		apply,
+	} {
+		res, err := run(ctx, []string{"a", "b"})
+		require.NoError(t, err)
+		awaitBatch(t, service, res.OperationID)
+		batchIDs = append(batchIDs, res.OperationID)
+	}
+
+	snapshots, err := service.ListSnapshots(ctx)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 3)
+
+	// só os dois automáticos mais recentes são mantidos; o manual não expira
+	assert.Equal(t, entities.SnapshotBeforeBatch, snapshots[0].Origin)
+	assert.Equal(t, batchIDs[2], snapshots[0].BatchID)
+	assert.Empty(t, snapshots[0].Boosters)
+	assert.Equal(t, batchIDs[1], snapshots[1].BatchID)
+	assert.Equal(t, []string{"a", "b"}, snapshots[1].BoosterIDs())
+	assert.Equal(t, entities.SnapshotManual, snapshots[2].Origin)
+
+	_, err = service.GetSnapshot(ctx, "missing")
+	assert.ErrorIs(t, err, ErrSnapshotNotFound)
+}
@@ -0,0 +1,257 @@
+package booster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// batchTransaction acompanha um lote transacional: os passos são executados em
+// série e, se algum falhar, os já concluídos são desfeitos em ordem inversa
+type batchTransaction struct {
+	batchID      string
+	operation    entities.BoosterOperationType
+	steps        []PlannedStep
+	operationIDs []string
+	enqueueErr   error
+	// preApplied são os boosters pedidos que já estavam aplicados; ficam fora
+	// dos passos e o rollback nunca os reverte
+	preApplied []string
+}
+
+// InitBoosterApplyTransaction aplica os boosters como uma unidade (tudo ou nada).
+// Os que já estavam aplicados ficam fora do lote, para que o rollback volte
+// exatamente ao estado anterior à transação.
+func (s *Service) InitBoosterApplyTransaction(ctx context.Context, ids []string) (entities.InitResult, error) {
+	pending, preApplied := s.splitPreApplied(ctx, ids)
+	plan := s.dependencyResolver.PlanApply(ctx, pending)
+	batchID := uuid.New().String()
+
+	// Um lote transacional nunca é aplicado parcialmente: qualquer recusa cancela tudo
+	if len(plan.Errors) > 0 {
+		s.eventEmitter.EmitBatchQueued(batchID, entities.ApplyOperationType, len(plan.Steps)+len(plan.Errors), 0, plan.Errors, s.queueManager.Size())
+		s.eventEmitter.EmitBatchCompleted(batchID, entities.ApplyOperationType, true, false, rejectedOutcomes(plan), nil)
+
+		err := fmt.Errorf("%w: %d booster(s) rejected", ErrTransactionRejected, len(plan.Errors))
+		return entities.InitResult{
+			OperationID: batchID,
+			SubmittedAt: time.Now(),
+			Success:     false,
+			Status:      entities.OperationFailed,
+			Message:     "transaction rejected by validation",
+			Error:       err,
+		}, err
+	}
+
+	if _, err := s.snapshotBeforeBatch(ctx, batchID, entities.ApplyOperationType, plan); err != nil {
+		return entities.InitResult{
+			OperationID: batchID,
+			SubmittedAt: time.Now(),
+			Success:     false,
+			Status:      entities.OperationFailed,
+			Message:     "failed to create snapshot before transaction",
+			Error:       err,
+		}, err
+	}
+
+	tx := &batchTransaction{
+		batchID:      batchID,
+		operation:    entities.ApplyOperationType,
+		steps:        plan.Steps,
+		operationIDs: make([]string, len(plan.Steps)),
+		preApplied:   preApplied,
+	}
+
+	// Cada passo depende do anterior, então o lote roda em série na ordem do plano
+	queuedCount := 0
+	enqueueErrors := make(map[string]error)
+	previous := ""
+	for i, step := range plan.Steps {
+		opts := QueueItemOptions{BatchID: batchID, Priority: entities.PriorityBatch}
+		if previous != "" {
+			opts.DependsOn = []string{previous}
+		}
+
+		operationID, err := s.queueManager.AddWithOptions(step.BoosterID, step.Operation, opts)
+		if err != nil {
+			tx.enqueueErr = err
+			enqueueErrors[step.BoosterID] = err
+			break
+		}
+
+		tx.operationIDs[i] = operationID
+		previous = operationID
+		queuedCount++
+		s.eventEmitter.EmitQueued(step.BoosterID, operationID, step.Operation, s.queueManager.Size())
+	}
+
+	s.eventEmitter.EmitBatchQueued(batchID, entities.ApplyOperationType, len(plan.Steps), queuedCount, enqueueErrors, s.queueManager.Size())
+
+	go s.runTransaction(tx)
+
+	return entities.InitResult{
+		OperationID: batchID,
+		SubmittedAt: time.Now(),
+		Success:     true,
+		Status:      entities.OperationPending,
+		Message:     fmt.Sprintf("transaction queued with %d operations", len(plan.Steps)),
+	}, nil
+}
+
+// splitPreApplied separa os boosters pedidos que já estavam aplicados antes da
+// transação, segundo o estado de ativação ou o estado de rollback
+func (s *Service) splitPreApplied(ctx context.Context, ids []string) ([]string, []string) {
+	pending := make([]string, 0, len(ids))
+	var preApplied []string
+	for _, id := range ids {
+		if s.isPreApplied(ctx, id) {
+			preApplied = append(preApplied, id)
+			continue
+		}
+		pending = append(pending, id)
+	}
+	return pending, preApplied
+}
+
+func (s *Service) isPreApplied(ctx context.Context, boosterID string) bool {
+	if s.boostActivationRepo != nil {
+		state, err := s.boostActivationRepo.GetBoostState(ctx, boosterID)
+		if err == nil && state != nil && state.IsApplied {
+			return true
+		}
+	}
+	applied, err := s.processor.IsApplied(ctx, boosterID)
+	return err == nil && applied
+}
+
+// runTransaction aguarda os passos do lote e desfaz os concluídos se algum falhar
+func (s *Service) runTransaction(tx *batchTransaction) {
+	ctx := context.Background()
+	outcomes := make([]entities.BatchBoosterOutcome, 0, len(tx.preApplied)+len(tx.steps))
+	completed := make([]int, 0, len(tx.steps))
+	failed := false
+
+	for _, boosterID := range tx.preApplied {
+		outcomes = append(outcomes, entities.BatchBoosterOutcome{
+			BoosterID: boosterID,
+			Operation: tx.operation,
+			Status:    entities.BatchOutcomeSkipped,
+		})
+	}
+
+	for i, step := range tx.steps {
+		outcome := entities.BatchBoosterOutcome{
+			BoosterID:   step.BoosterID,
+			OperationID: tx.operationIDs[i],
+			Operation:   step.Operation,
+		}
+
+		var err error
+		if tx.operationIDs[i] == "" {
+			err = tx.enqueueErr
+			if failed {
+				err = ErrDependencyFailed
+			}
+		} else {
+			err = s.queueManager.AwaitOperation(ctx, tx.operationIDs[i])
+		}
+
+		switch {
+		case err == nil:
+			outcome.Status = entities.BatchOutcomeSucceeded
+			completed = append(completed, i)
+		case errors.Is(err, ErrDependencyFailed):
+			outcome.Status = entities.BatchOutcomeSkipped
+			outcome.Error = err.Error()
+		default:
+			outcome.Status = entities.BatchOutcomeFailed
+			outcome.Error = err.Error()
+		}
+		if outcome.Status != entities.BatchOutcomeSucceeded {
+			failed = true
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	var rollbacks []entities.BatchBoosterOutcome
+	if failed {
+		rollbacks = s.rollbackTransaction(ctx, tx, completed)
+	}
+
+	s.eventEmitter.EmitBatchCompleted(tx.batchID, tx.operation, true, !failed, outcomes, rollbacks)
+}
+
+// rollbackTransaction desfaz os passos concluídos em ordem inversa; a reversão
+// usa o estado de rollback persistido de cada booster
+func (s *Service) rollbackTransaction(ctx context.Context, tx *batchTransaction, completed []int) []entities.BatchBoosterOutcome {
+	rollbacks := make([]entities.BatchBoosterOutcome, 0, len(completed))
+
+	for j := len(completed) - 1; j >= 0; j-- {
+		step := tx.steps[completed[j]]
+		inverse := inverseOperation(step.Operation)
+		rollback := entities.BatchBoosterOutcome{
+			BoosterID: step.BoosterID,
+			Operation: inverse,
+		}
+
+		operationID, err := s.queueManager.AddWithOptions(step.BoosterID, inverse, QueueItemOptions{
+			BatchID:  tx.batchID,
+			Priority: entities.PriorityBatch,
+		})
+		if err == nil {
+			rollback.OperationID = operationID
+			s.eventEmitter.EmitQueued(step.BoosterID, operationID, inverse, s.queueManager.Size())
+			err = s.queueManager.AwaitOperation(ctx, operationID)
+		}
+
+		if err != nil {
+			rollback.Status = entities.BatchOutcomeFailed
+			rollback.Error = err.Error()
+		} else {
+			rollback.Status = entities.BatchOutcomeRolledBack
+		}
+		rollbacks = append(rollbacks, rollback)
+	}
+
+	return rollbacks
+}
+
+func rejectedOutcomes(plan *BatchPlan) []entities.BatchBoosterOutcome {
+	outcomes := make([]entities.BatchBoosterOutcome, 0, len(plan.Steps)+len(plan.Errors))
+	for _, step := range plan.Steps {
+		outcomes = append(outcomes, entities.BatchBoosterOutcome{
+			BoosterID: step.BoosterID,
+			Operation: step.Operation,
+			Status:    entities.BatchOutcomeSkipped,
+		})
+	}
+	rejectedIDs := make([]string, 0, len(plan.Errors))
+	for boosterID := range plan.Errors {
+		rejectedIDs = append(rejectedIDs, boosterID)
+	}
+	sort.Strings(rejectedIDs)
+	for _, boosterID := range rejectedIDs {
+		err := plan.Errors[boosterID]
+		outcomes = append(outcomes, entities.BatchBoosterOutcome{
+			BoosterID: boosterID,
+			Operation: entities.ApplyOperationType,
+			Status:    entities.BatchOutcomeRejected,
+			Error:     err.Error(),
+		})
+	}
+	return outcomes
+}
+
+func inverseOperation(operation entities.BoosterOperationType) entities.BoosterOperationType {
+	if operation == entities.ApplyOperationType {
+		return entities.RevertOperationType
+	}
+	return entities.ApplyOperationType
+}
+
+var ErrTransactionRejected = fmt.Errorf("transaction rejected")
@@ -0,0 +1,228 @@
+package booster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+type batchResult struct {
+	committed bool
+	outcomes  []entities.BatchBoosterOutcome
+	rollbacks []entities.BatchBoosterOutcome
+}
+
+// recordingEmitter ignora os eventos por booster e captura o resultado final dos lotes
+type recordingEmitter struct {
+	results  chan batchResult
+	drifted  chan string
+	attempts chan entities.OperationAttempt
+	expiring chan string
+	expired  chan string
+	health   chan entities.HealthStatus
+}
+
+func newRecordingEmitter() *recordingEmitter {
+	return &recordingEmitter{
+		results:  make(chan batchResult, 10),
+		drifted:  make(chan string, 10),
+		attempts: make(chan entities.OperationAttempt, 10),
+		expiring: make(chan string, 10),
+		expired:  make(chan string, 10),
+		health:   make(chan entities.HealthStatus, 10),
+	}
+}
+
+func (e *recordingEmitter) EmitProcessing(boosterID, operationID string, operation entities.BoosterOperationType) {
+}
+func (e *recordingEmitter) EmitSuccess(boosterID, operationID string, operation entities.BoosterOperationType, message string) {
+}
+func (e *recordingEmitter) EmitError(boosterID, operationID string, operation entities.BoosterOperationType, err error) {
+}
+func (e *recordingEmitter) EmitFailed(boosterID, operationID string, operation entities.BoosterOperationType, message string) {
+}
+func (e *recordingEmitter) EmitQueued(boosterID, operationID string, operation entities.BoosterOperationType, queueSize int) {
+}
+func (e *recordingEmitter) EmitBatchQueued(batchID string, operation entities.BoosterOperationType, totalCount, queuedCount int, validationErrors map[string]error, queueSize int) {
+}
+func (e *recordingEmitter) EmitCancelled(boosterID, operationID string, operation entities.BoosterOperationType, queueSize int) {
+}
+
+func (e *recordingEmitter) EmitBatchCompleted(batchID string, operation entities.BoosterOperationType, transactional, committed bool, outcomes, rollbacks []entities.BatchBoosterOutcome) {
+	e.results <- batchResult{committed: committed, outcomes: outcomes, rollbacks: rollbacks}
+}
+
+func (e *recordingEmitter) EmitDrifted(boosterID string, policy entities.DriftPolicy, drifted []entities.PlannedChange, reapplyOperationID string) {
+	e.drifted <- boosterID
+}
+
+func (e *recordingEmitter) EmitAttempt(boosterID, operationID string, operation entities.BoosterOperationType, attempt entities.OperationAttempt) {
+	e.attempts <- attempt
+}
+
+func (e *recordingEmitter) EmitExpiring(boosterID string, expiresAt time.Time) {
+	e.expiring <- boosterID
+}
+
+func (e *recordingEmitter) EmitExpired(boosterID string, expiresAt time.Time, revertOperationID string) {
+	e.expired <- boosterID
+}
+
+func (e *recordingEmitter) EmitHealthChanged(status entities.HealthStatus) {
+	e.health <- status
+}
+
+func (e *recordingEmitter) wait(t *testing.T) batchResult {
+	select {
+	case res := <-e.results:
+		return res
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout aguardando o fim do lote")
+		return batchResult{}
+	}
+}
+
+type noopRecorder struct{}
+
+func (noopRecorder) RecordQueued(item entities.QueueItem) error    { return nil }
+func (noopRecorder) RecordStarted(item entities.QueueItem) error   { return nil }
+func (noopRecorder) RecordCancelled(item entities.QueueItem) error { return nil }
+
+func (noopRecorder) RecordOperation(item entities.QueueItem, result *entities.BoostOperation, err error) error {
+	return nil
+}
+
+// helper para montar um Service com workers reais sobre boosters de teste
+func newTestService(t *testing.T, boosters ...*testBooster) (*Service, *recordingEmitter) {
+	proc := newResolverProcessor(t, boosters...)
+	queueManager := NewManager(20)
+	emitter := newRecordingEmitter()
+
+	service := &Service{
+		processor:          proc,
+		queueManager:       queueManager,
+		workerPool:         NewPool(2, proc, emitter, noopRecorder{}, queueManager),
+		eventEmitter:       emitter,
+		dependencyResolver: NewDependencyResolver(proc, DependencyPolicy{IncludeMissingDependencies: true}),
+	}
+	service.StartWorkers()
+	t.Cleanup(service.workerPool.Stop)
+	return service, emitter
+}
+
+func okResult() *entities.BoostApplyResult {
+	return &entities.BoostApplyResult{
+		Success:    true,
+		BackupData: map[string]interface{}{"k": "v"},
+	}
+}
+
+func TestApplyTransaction_Commits(t *testing.T) {
+	service, emitter := newTestService(t,
+		&testBooster{id: "a", execResult: okResult()},
+		&testBooster{id: "b", execResult: okResult(), deps: []string{"a"}},
+	)
+
+	res, err := service.InitBoosterApplyTransaction(context.Background(), []string{"b", "a"})
+	require.NoError(t, err)
+	assert.True(t, res.Success)
+
+	result := emitter.wait(t)
+	assert.True(t, result.committed)
+	assert.Empty(t, result.rollbacks)
+	require.Len(t, result.outcomes, 2)
+	assert.Equal(t, "a", result.outcomes[0].BoosterID)
+	assert.Equal(t, entities.BatchOutcomeSucceeded, result.outcomes[1].Status)
+}
+
+func TestApplyTransaction_RollsBackInReverseOrder(t *testing.T) {
+	a := &testBooster{id: "a", execResult: okResult(), revertRes: &entities.BoostRevertResult{Success: true}}
+	b := &testBooster{id: "b", execResult: okResult(), revertRes: &entities.BoostRevertResult{Success: true}}
+	c := &testBooster{id: "c", execErr: errors.New("exec failed")}
+	d := &testBooster{id: "d", execResult: okResult()}
+	service, emitter := newTestService(t, a, b, c, d)
+
+	_, err := service.InitBoosterApplyTransaction(context.Background(), []string{"a", "b", "c", "d"})
+	require.NoError(t, err)
+
+	result := emitter.wait(t)
+	assert.False(t, result.committed)
+
+	statuses := make(map[string]entities.BatchOutcomeStatus)
+	for _, outcome := range result.outcomes {
+		statuses[outcome.BoosterID] = outcome.Status
+	}
+	assert.Equal(t, entities.BatchOutcomeSucceeded, statuses["a"])
+	assert.Equal(t, entities.BatchOutcomeSucceeded, statuses["b"])
+	assert.Equal(t, entities.BatchOutcomeFailed, statuses["c"])
+	assert.Equal(t, entities.BatchOutcomeSkipped, statuses["d"])
+
+	// b foi aplicado depois de a, então é revertido primeiro
+	require.Len(t, result.rollbacks, 2)
+	assert.Equal(t, "b", result.rollbacks[0].BoosterID)
+	assert.Equal(t, "a", result.rollbacks[1].BoosterID)
+	for _, rollback := range result.rollbacks {
+		assert.Equal(t, entities.RevertOperationType, rollback.Operation)
+		assert.Equal(t, entities.BatchOutcomeRolledBack, rollback.Status)
+	}
+
+	applied, err := service.processor.IsApplied(context.Background(), "a")
+	require.NoError(t, err)
+	assert.False(t, applied)
+	assert.Equal(t, "v", a.revertBackup["k"])
+}
+
+func TestApplyTransaction_RollbackKeepsPreAppliedBoosters(t *testing.T) {
+	a := &testBooster{id: "a", execResult: okResult(), revertRes: &entities.BoostRevertResult{Success: true}}
+	b := &testBooster{id: "b", execResult: okResult(), revertRes: &entities.BoostRevertResult{Success: true}}
+	c := &testBooster{id: "c", execErr: errors.New("exec failed")}
+	service, emitter := newTestService(t, a, b, c)
+	markApplied(t, service.processor, "a")
+
+	_, err := service.InitBoosterApplyTransaction(context.Background(), []string{"a", "b", "c"})
+	require.NoError(t, err)
+
+	result := emitter.wait(t)
+	assert.False(t, result.committed)
+
+	statuses := make(map[string]entities.BatchOutcomeStatus)
+	for _, outcome := range result.outcomes {
+		statuses[outcome.BoosterID] = outcome.Status
+	}
+	assert.Equal(t, entities.BatchOutcomeSkipped, statuses["a"])
+	assert.Equal(t, entities.BatchOutcomeSucceeded, statuses["b"])
+	assert.Equal(t, entities.BatchOutcomeFailed, statuses["c"])
+
+	// só o que a transação aplicou é desfeito
+	require.Len(t, result.rollbacks, 1)
+	assert.Equal(t, "b", result.rollbacks[0].BoosterID)
+
+	applied, err := service.processor.IsApplied(context.Background(), "a")
+	require.NoError(t, err)
+	assert.True(t, applied)
+	assert.Nil(t, a.revertBackup)
+}
+
+func TestApplyTransaction_RejectsWholeBatch(t *testing.T) {
+	service, emitter := newTestService(t,
+		&testBooster{id: "a", execResult: okResult()},
+	)
+
+	res, err := service.InitBoosterApplyTransaction(context.Background(), []string{"a", "ghost"})
+	require.ErrorIs(t, err, ErrTransactionRejected)
+	assert.False(t, res.Success)
+
+	result := emitter.wait(t)
+	assert.False(t, result.committed)
+	assert.Equal(t, 0, service.queueManager.Size())
+
+	applied, err := service.processor.IsApplied(context.Background(), "a")
+	require.NoError(t, err)
+	assert.False(t, applied)
+}
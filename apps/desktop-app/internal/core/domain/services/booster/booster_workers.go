@@ -2,7 +2,9 @@ package booster
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"runtime/debug"
 	"sync"
 	"time"
 
@@ -14,6 +16,7 @@ type Processor interface {
 	ProcessApply(ctx context.Context, boosterID string) (*entities.BoostApplyResult, error)
 	ProcessRevert(ctx context.Context, boosterID string) (*entities.BoostRevertResult, error)
 	ValidateBoosterOperation(ctx context.Context, boosterID string, operation entities.BoosterOperationType) error
+	ExecutionPolicy(boosterID string) ExecutionPolicy
 }
 
 type EventEmitter interface {
@@ -23,10 +26,20 @@ type EventEmitter interface {
 	EmitFailed(boosterID, operationID string, operation entities.BoosterOperationType, message string) 
 	EmitQueued(boosterID, operationID string, operation entities.BoosterOperationType, queueSize int) 
 	EmitBatchQueued(batchID string, operation entities.BoosterOperationType, totalCount, queuedCount int, validationErrors map[string]error, queueSize int) 
-	EmitCancelled(boosterID string, queueSize int) 
+	EmitBatchCompleted(batchID string, operation entities.BoosterOperationType, transactional, committed bool, outcomes, rollbacks []entities.BatchBoosterOutcome)
+	EmitDrifted(boosterID string, policy entities.DriftPolicy, drifted []entities.PlannedChange, reapplyOperationID string)
+	EmitAttempt(boosterID, operationID string, operation entities.BoosterOperationType, attempt entities.OperationAttempt)
+	EmitCancelled(boosterID, operationID string, operation entities.BoosterOperationType, queueSize int)
+	EmitExpiring(boosterID string, expiresAt time.Time)
+	EmitExpired(boosterID string, expiresAt time.Time, revertOperationID string)
+	EmitHealthChanged(status entities.HealthStatus)
 }
 
+// HistoryRecorder persiste o ciclo de vida das operações: queued → processing → completed/failed/cancelled
 type HistoryRecorder interface {
+	RecordQueued(item entities.QueueItem) error
+	RecordStarted(item entities.QueueItem) error
+	RecordCancelled(item entities.QueueItem) error
 	RecordOperation(item entities.QueueItem, result *entities.BoostOperation, err error) error
 }
 
@@ -37,8 +50,13 @@ type Pool struct {
 	historyRecorder HistoryRecorder
 	queueManager    *Manager
 	workerCount     int
+	defaultPolicy   ExecutionPolicy
+	locks           *resourceLocks
+	healthPolicy    HealthPolicy
+	workers         []*workerState
 	wg              sync.WaitGroup
 	stopOnce        sync.Once
+	logger          *logger.CustomLogger
 }
 
 // NewPool cria um novo pool de workers
@@ -55,43 +73,109 @@ func NewPool(
 		historyRecorder: historyRecorder,
 		queueManager:    queueManager,
 		workerCount:     workerCount,
+		locks:           newResourceLocks(),
+		healthPolicy:    DefaultHealthPolicy(),
+		logger:          logger.NewCustomLogger("[WorkerPool]"),
 	}
 }
 
+// SetHealthPolicy define os limites de operação travada e de heartbeat; deve ser
+// chamado antes de Start
+func (p *Pool) SetHealthPolicy(policy HealthPolicy) {
+	p.healthPolicy = policy
+}
+
 // Start inicia todos os workers
 func (p *Pool) Start() {
+	p.workers = make([]*workerState, p.workerCount)
 	for i := 0; i < p.workerCount; i++ {
+		p.workers[i] = newWorkerState(i)
 		p.wg.Add(1)
-		go p.worker(i)
+		go p.worker(p.workers[i])
 	}
 }
 
 // worker processa itens da queue
-func (p *Pool) worker(id int) {
+func (p *Pool) worker(w *workerState) {
 	defer p.wg.Done()
 
-	workCh := p.queueManager.GetWorkChannel()
-	stopCh := p.queueManager.GetStopChannel()
-
 	for {
-		select {
-		case <-stopCh:
+		w.idle()
+		item, ok := p.queueManager.Next()
+		if !ok {
+			w.stop()
+			return
+		}
+		if !p.runItem(w, item) {
 			return
-		case item := <-workCh:
-			p.processItem(item)
 		}
 	}
 }
 
-func (p *Pool) processItem(item entities.QueueItem) {
+// runItem processa o item recuperando um panic do worker: a operação falha e um
+// novo worker assume o lugar deste. Retorna false quando o worker foi substituído.
+func (p *Pool) runItem(w *workerState, item entities.QueueItem) (ok bool) {
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+		p.logger.Errorf("worker %d panicked processing %s of %s: %v\n%s",
+			w.id, item.OperationID, item.BoosterID, recovered, debug.Stack())
+		p.failItem(item, fmt.Errorf("%w: %v", ErrWorkerPanic, recovered))
+		w.panicked(recovered)
+
+		p.wg.Add(1)
+		go p.worker(w)
+		ok = false
+	}()
+
+	w.pick(item)
+	p.processItem(w, item)
+	return true
+}
+
+// failItem encerra com erro um item cujo processamento foi interrompido
+func (p *Pool) failItem(item entities.QueueItem, err error) {
+	p.queueManager.Dequeue(item)
+	p.queueManager.UpdateJournal(item.OperationID, entities.JournalFailed, err)
+	p.queueManager.MarkCompleted(item.OperationID, err)
+	p.eventEmitter.EmitError(item.BoosterID, item.OperationID, item.Operation, err)
+	p.historyRecorder.RecordOperation(item, nil, err)
+}
+
+func (p *Pool) processItem(w *workerState, item entities.QueueItem) {
 	logger.NewCustomLogger("ProcessItem").DebugFields(
 		"Listando Listando i",
 		logger.Fields{
 			"boosters": item,
 		},
 	)
-	p.queueManager.Remove(item.BoosterID)
+	// Itens cancelados ainda na queue já foram encerrados pelo Manager
+	if item.Context.Err() != nil {
+		return
+	}
+
+	// Aguarda as operações das quais este item depende (ordem topológica do lote)
+	if err := p.queueManager.WaitForDependencies(item.Context, item.DependsOn); err != nil {
+		if item.Context.Err() != nil {
+			return
+		}
+		p.queueManager.Dequeue(item)
+		p.queueManager.UpdateJournal(item.OperationID, entities.JournalFailed, err)
+		p.queueManager.MarkCompleted(item.OperationID, err)
+		p.eventEmitter.EmitError(item.BoosterID, item.OperationID, item.Operation, err)
+		p.historyRecorder.RecordOperation(item, nil, err)
+		return
+	}
+
+	// Um cancelamento entre a checagem acima e a saída da queue já encerrou o item
+	if !p.queueManager.Dequeue(item) && item.Context.Err() != nil {
+		return
+	}
+	w.run()
 	p.eventEmitter.EmitProcessing(item.BoosterID, item.OperationID, item.Operation)
+	p.historyRecorder.RecordStarted(item)
 
 	// Validar operação
 	if err := p.validateOperation(item); err != nil {
@@ -101,12 +185,15 @@ func (p *Pool) processItem(item entities.QueueItem) {
 				"boosters": err,
 			},
 		)
+		p.queueManager.UpdateJournal(item.OperationID, entities.JournalFailed, err)
+		p.queueManager.MarkCompleted(item.OperationID, err)
 		p.eventEmitter.EmitError(item.BoosterID, item.OperationID, item.Operation, err)
+		p.historyRecorder.RecordOperation(item, nil, err)
 		return
 	}
 
-	// Processar operação
-	op, err := p.executeOperation(item)
+	// Processar operação com timeout e retry
+	op, err := p.executeWithRetry(w, item)
 	logger.NewCustomLogger("ProcessItem").ErrorFields(
 		"Listando Erro",
 		logger.Fields{
@@ -116,6 +203,13 @@ func (p *Pool) processItem(item entities.QueueItem) {
 
 	// Emitir eventos e registrar histórico
 	p.handleResult(item, op, err)
+
+	// Se o estado foi salvo a fase já é terminal e esta atualização é ignorada
+	opErr := operationError(op, err)
+	if opErr != nil {
+		p.queueManager.UpdateJournal(item.OperationID, entities.JournalFailed, opErr)
+	}
+	p.queueManager.MarkCompleted(item.OperationID, opErr)
 }
 
 func (p *Pool) handleResult(item entities.QueueItem, op *entities.BoostOperation, err error) {
@@ -134,11 +228,22 @@ func (p *Pool) handleError(item entities.QueueItem, op *entities.BoostOperation,
 
 
 func (p *Pool) handleSuccess(item entities.QueueItem, op *entities.BoostOperation) {
-	errorMsg := ""
-	if op != nil {
-		errorMsg = op.ErrorMsg
+	if op != nil && op.ErrorMsg != "" {
+		p.eventEmitter.EmitFailed(item.BoosterID, item.OperationID, item.Operation, op.ErrorMsg)
+		return
 	}
-	p.eventEmitter.EmitSuccess(item.BoosterID, item.OperationID, item.Operation, errorMsg)
+	p.eventEmitter.EmitSuccess(item.BoosterID, item.OperationID, item.Operation, "")
+}
+
+// operationError resume o resultado da operação em um erro (nil indica sucesso)
+func operationError(op *entities.BoostOperation, err error) error {
+	if err != nil {
+		return err
+	}
+	if op != nil && op.ErrorMsg != "" {
+		return errors.New(op.ErrorMsg)
+	}
+	return nil
 }
 
 // validateOperation valida se a operação pode ser executada
@@ -155,20 +260,20 @@ func (p *Pool) validateOperation(item entities.QueueItem) error {
 }
 
 // executeOperation executa a operação apropriada
-func (p *Pool) executeOperation(item entities.QueueItem) (*entities.BoostOperation, error) {
+func (p *Pool) executeOperation(ctx context.Context, item entities.QueueItem) (*entities.BoostOperation, error) {
 	switch entities.BoosterOperationType(item.Operation) {
 	case entities.BoosterOperationType(entities.ApplyOperationType):
-		return p.processApplyOperation(item)
+		return p.processApplyOperation(ctx, item)
 	case entities.BoosterOperationType(entities.RevertOperationType):
-		return p.processRevertOperation(item)
+		return p.processRevertOperation(ctx, item)
 	default:
 		return nil, fmt.Errorf("unsupported operation type: %v", item.Operation)
 	}
 }
 
 // processApplyOperation processa operação de aplicação
-func (p *Pool) processApplyOperation(item entities.QueueItem) (*entities.BoostOperation, error) {
-	res, err := p.processor.ProcessApply(item.Context, item.BoosterID)
+func (p *Pool) processApplyOperation(ctx context.Context, item entities.QueueItem) (*entities.BoostOperation, error) {
+	res, err := p.processor.ProcessApply(ctx, item.BoosterID)
 	if err != nil {
 		return nil, err
 	}
@@ -182,20 +287,28 @@ func (p *Pool) processApplyOperation(item entities.QueueItem) (*entities.BoostOp
 		BoosterID: item.BoosterID,
 		Type:      item.Operation,
 		AppliedAt: time.Now(),
-		ErrorMsg:  p.getErrorMessage(res.Error),
+		ErrorMsg:  p.getErrorMessage(res.Success, res.Message, res.Error),
+		Message:   res.Message,
 	}, nil
 }
 
-func (p *Pool) getErrorMessage(err error) string {
-	if err == nil {
+// getErrorMessage considera falha também um resultado sem sucesso e sem erro
+func (p *Pool) getErrorMessage(success bool, message string, err error) string {
+	if err != nil {
+		return err.Error()
+	}
+	if success {
 		return ""
 	}
-	return err.Error()
+	if message == "" {
+		return "operation reported failure"
+	}
+	return message
 }
 
 // processRevertOperation processa operação de reversão
-func (p *Pool) processRevertOperation(item entities.QueueItem) (*entities.BoostOperation, error) {
-	res, err := p.processor.ProcessRevert(item.Context, item.BoosterID)
+func (p *Pool) processRevertOperation(ctx context.Context, item entities.QueueItem) (*entities.BoostOperation, error) {
+	res, err := p.processor.ProcessRevert(ctx, item.BoosterID)
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +322,8 @@ func (p *Pool) processRevertOperation(item entities.QueueItem) (*entities.BoostO
 		BoosterID:  item.BoosterID,
 		Type:       item.Operation,
 		RevertedAt: time.Now(),
-		ErrorMsg:   p.getErrorMessage(res.Error),
+		ErrorMsg:   p.getErrorMessage(res.Success, res.Message, res.Error),
+		Message:    res.Message,
 	}, nil
 }
 
@@ -221,6 +335,22 @@ func (p *Pool) Stop() {
 	})
 }
 
+// GetActiveWorkerCount retorna quantos workers estão rodando
 func (p *Pool) GetActiveWorkerCount() int {
-	return p.workerCount
+	count := 0
+	for _, worker := range p.WorkerHealth(time.Now()) {
+		if worker.State != entities.WorkerStopped {
+			count++
+		}
+	}
+	return count
+}
+
+// WorkerHealth retorna o estado de cada worker classificado pela política de saúde
+func (p *Pool) WorkerHealth(now time.Time) []entities.WorkerHealth {
+	health := make([]entities.WorkerHealth, 0, len(p.workers))
+	for _, worker := range p.workers {
+		health = append(health, worker.health(now, p.healthPolicy))
+	}
+	return health
 }
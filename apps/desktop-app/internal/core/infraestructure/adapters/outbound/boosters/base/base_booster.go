@@ -2,6 +2,7 @@ package booster
 
 import (
 	"context"
+	"time"
 
 	"github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
 	"github.com/oLenador/mulltbost/internal/core/domain/dto"
@@ -32,6 +33,12 @@ func (b *BaseBooster) GetEntity() entities.Booster {
 	return b.entity
 }
 
+// Implemented informa se há executor para a plataforma atual; boosters sem
+// executor ficam no catálogo como indisponíveis
+func (b *BaseBooster) Implemented() bool {
+	return b.executor != nil
+}
+
 func (b *BaseBooster) GetEntityDto(lang i18n.Language) dto.BoosterDto {
 	name := b.i18nSvc.Translate(b.entity.NameKey, lang)
 	description := b.i18nSvc.Translate(b.entity.DescriptionKey, lang)
@@ -49,6 +56,7 @@ func (b *BaseBooster) GetEntityDto(lang i18n.Language) dto.BoosterDto {
 		RiskLevel:    b.entity.RiskLevel,
 		Version:      b.entity.Version,
 		Tags:         b.entity.Tags,
+		Params:       b.entity.Params,
 	}
 }
 
@@ -56,6 +64,32 @@ func (b *BaseBooster) Execute(ctx context.Context) (*entities.BoostApplyResult,
 	return b.executor.Execute(ctx, b.entity.ID)
 }
 
+func (b *BaseBooster) Plan(ctx context.Context) (*entities.BoostPlan, error) {
+	changes, err := b.executor.Plan(ctx, b.entity.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.BoostPlan{
+		BoosterID:   b.entity.ID,
+		Operation:   entities.ApplyOperationType,
+		Changes:     changes,
+		GeneratedAt: time.Now(),
+	}, nil
+}
+
+func (b *BaseBooster) Verify(ctx context.Context) (*entities.BoostVerifyResult, error) {
+	drifted, err := b.executor.Verify(ctx, b.entity.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.BoostVerifyResult{
+		BoosterID: b.entity.ID,
+		InEffect:  len(drifted) == 0,
+		Drifted:   drifted,
+		CheckedAt: time.Now(),
+	}, nil
+}
+
 func (b *BaseBooster) Validate(ctx context.Context) error {
 	return b.executor.Validate(ctx)
 }
@@ -68,6 +102,6 @@ func (b *BaseBooster) CanRevert(ctx context.Context) bool {
 	return b.entity.Reversible && b.executor.CanExecute(ctx)
 }
 
-func (b *BaseBooster) Revert(ctx context.Context) (*entities.BoostRevertResult, error) {
-	return b.executor.Revert(ctx, nil)
+func (b *BaseBooster) Revert(ctx context.Context, backupData entities.BackupData) (*entities.BoostRevertResult, error) {
+	return b.executor.Revert(ctx, backupData)
 }
\ No newline at end of file
@@ -1,6 +1,8 @@
 package connection
 
 import (
+	"context"
+
 	"github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
 	"github.com/oLenador/mulltbost/internal/core/domain/entities"
 	"github.com/oLenador/mulltbost/internal/core/domain/services/i18n"
@@ -24,28 +26,41 @@ func NewDNSBooster(services *inbound.ExecutorDepServices) inbound.BoosterUseCase
 		RiskLevel:      entities.RiskLow,
 		Version:        "1.0.0",
 		Tags:           []string{"network", "dns", "speed"},
+		Resources:      dnsResources,
+		Params:         dnsParams,
+		Requirements:   dnsRequirements,
 	}
 
 	translations := map[i18n.Language]i18n.Translation{
 		i18n.English: {
-			"booster.connection.dns.name":        "DNS Optimizer",
-			"booster.connection.dns.description": "Configures faster DNS servers and clears DNS cache",
+			"booster.connection.dns.name":                       "DNS Optimizer",
+			"booster.connection.dns.description":                "Configures faster DNS servers and clears DNS cache",
+			"booster.connection.dns.params.servers":             "DNS servers",
+			"booster.connection.dns.params.servers.description": "DNS servers written to the network configuration, in order of preference",
 		},
 		i18n.Portuguese: {
-			"booster.connection.dns.name":        "Otimizador DNS",
-			"booster.connection.dns.description": "Configura servidores DNS mais rápidos e limpa o cache DNS",
+			"booster.connection.dns.name":                       "Otimizador DNS",
+			"booster.connection.dns.description":                "Configura servidores DNS mais rápidos e limpa o cache DNS",
+			"booster.connection.dns.params.servers":             "Servidores DNS",
+			"booster.connection.dns.params.servers.description": "Servidores DNS gravados na configuração de rede, por ordem de preferência",
 		},
 		i18n.PortugueseBrazil: {
-			"booster.connection.dns.name":        "Otimizador de DNS",
-			"booster.connection.dns.description": "Configura servidores de DNS mais rápidos e limpa o cache do DNS",
+			"booster.connection.dns.name":                       "Otimizador de DNS",
+			"booster.connection.dns.description":                "Configura servidores de DNS mais rápidos e limpa o cache do DNS",
+			"booster.connection.dns.params.servers":             "Servidores de DNS",
+			"booster.connection.dns.params.servers.description": "Servidores de DNS gravados na configuração de rede, em ordem de preferência",
 		},
 		i18n.Spanish: {
-			"booster.connection.dns.name":        "Optimizador DNS",
-			"booster.connection.dns.description": "Configura servidores DNS más rápidos y limpia la caché DNS",
+			"booster.connection.dns.name":                       "Optimizador DNS",
+			"booster.connection.dns.description":                "Configura servidores DNS más rápidos y limpia la caché DNS",
+			"booster.connection.dns.params.servers":             "Servidores DNS",
+			"booster.connection.dns.params.servers.description": "Servidores DNS escritos en la configuración de red, por orden de preferencia",
 		},
 		i18n.Russian: {
-			"booster.connection.dns.name":        "Оптимизатор DNS",
-			"booster.connection.dns.description": "Настраивает более быстрые DNS-серверы и очищает кеш DNS",
+			"booster.connection.dns.name":                       "Оптимизатор DNS",
+			"booster.connection.dns.description":                "Настраивает более быстрые DNS-серверы и очищает кеш DNS",
+			"booster.connection.dns.params.servers":             "DNS-серверы",
+			"booster.connection.dns.params.servers.description": "DNS-серверы, записываемые в настройки сети, в порядке приоритета",
 		},
 	}	
 
@@ -59,4 +74,11 @@ func NewDNSBooster(services *inbound.ExecutorDepServices) inbound.BoosterUseCase
 	
 
 	return baseBooster
-}
\ No newline at end of file
+}
+
+const dnsServersParam = "servers"
+
+// dnsServers retorna os servidores DNS configurados para a execução
+func dnsServers(ctx context.Context) []string {
+	return entities.ParamsFromContext(ctx, dnsParams).StringList(dnsServersParam)
+}
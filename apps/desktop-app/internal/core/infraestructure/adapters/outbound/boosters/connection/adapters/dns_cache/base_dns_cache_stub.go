@@ -1,7 +1,16 @@
 //go:build !windows && !linux
 package connection
 
-import "github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+import (
+	"github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+var dnsResources []string
+
+var dnsParams entities.ParamSchema
+
+var dnsRequirements entities.Requirements
 
 func NewDNSCacheExecutor(services *inbound.ExecutorDepServices) inbound.PlatformExecutor {
     return nil
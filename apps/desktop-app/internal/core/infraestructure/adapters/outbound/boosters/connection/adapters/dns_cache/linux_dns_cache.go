@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 	"time"
 
@@ -15,6 +16,58 @@ import (
 
 type LinuxDNSExecutor struct{}
 
+var dnsResources = []string{entities.FileResource("/etc/resolv.conf")}
+
+// dnsRequirements: o sysctl aplica as otimizações de rede; o systemctl é opcional
+var dnsRequirements = entities.Requirements{
+	Elevation: true,
+	Binaries:  []string{"sysctl"},
+}
+
+var linuxOptimizedDNS = []string{"8.8.8.8", "8.8.4.4", "1.1.1.1", "1.0.0.1", "208.67.222.222", "208.67.220.220"}
+
+// dnsParams permite trocar os servidores padrão (Google, Cloudflare e OpenDNS)
+var dnsParams = entities.ParamSchema{
+	{
+		Key:            dnsServersParam,
+		NameKey:        "booster.connection.dns.params.servers",
+		DescriptionKey: "booster.connection.dns.params.servers.description",
+		Type:           entities.ParamIPList,
+		Default:        linuxOptimizedDNS,
+		MaxItems:       6,
+	},
+}
+
+// Configurações de rede otimizadas
+var linuxOptimizedSysctl = map[string]string{
+	"net.core.rmem_default":     "31457280",
+	"net.core.rmem_max":         "67108864",
+	"net.core.wmem_default":     "31457280", 
+	"net.core.wmem_max":         "67108864",
+	"net.core.somaxconn":        "65535",
+	"net.core.netdev_max_backlog": "5000",
+	"net.ipv4.tcp_congestion_control": "bbr",
+	"net.ipv4.tcp_rmem":         "4096 31457280 67108864",
+	"net.ipv4.tcp_wmem":         "4096 31457280 67108864",
+	"net.ipv4.tcp_fastopen":     "3",
+	"net.ipv4.tcp_slow_start_after_idle": "0",
+}
+
+// Configurações de rede padrão restauradas no revert
+var linuxDefaultSysctl = map[string]string{
+	"net.core.rmem_default":     "212992",
+	"net.core.rmem_max":         "212992", 
+	"net.core.wmem_default":     "212992",
+	"net.core.wmem_max":         "212992",
+	"net.core.somaxconn":        "128",
+	"net.core.netdev_max_backlog": "1000",
+	"net.ipv4.tcp_congestion_control": "cubic",
+	"net.ipv4.tcp_rmem":         "4096 87380 6291456",
+	"net.ipv4.tcp_wmem":         "4096 16384 4194304", 
+	"net.ipv4.tcp_fastopen":     "1",
+	"net.ipv4.tcp_slow_start_after_idle": "1",
+}
+
 func NewDNSCacheExecutor(services *inbound.ExecutorDepServices) inbound.PlatformExecutor {
     return &LinuxDNSExecutor{}
 }
@@ -29,8 +82,15 @@ func (e *LinuxDNSExecutor) Execute(ctx context.Context, boosterID string) (*enti
 		}, err
 	}
 
-	// DNS otimizados (Google DNS, Cloudflare, OpenDNS)
-	optimizedDNS := []string{"8.8.8.8", "8.8.4.4", "1.1.1.1", "1.0.0.1", "208.67.222.222", "208.67.220.220"}
+	// Servidores configurados pelo usuário ou os padrões (Google DNS, Cloudflare, OpenDNS)
+	optimizedDNS := dnsServers(ctx)
+	backupData := map[string]interface{}{
+		"original_resolv_conf": originalDNS,
+		"optimized_dns_servers": optimizedDNS,
+		"backup_timestamp": time.Now().Unix(),
+		"booster_id": boosterID,
+		"platform": "linux",
+	}
 
 	// Aplicar nova configuração DNS
 	if err := e.setDNSServers(optimizedDNS); err != nil {
@@ -40,6 +100,15 @@ func (e *LinuxDNSExecutor) Execute(ctx context.Context, boosterID string) (*enti
 		}, err
 	}
 
+	if err := entities.Checkpoint(ctx, "flush dns cache", backupData); err != nil {
+		return &entities.BoostApplyResult{
+			Success:    false,
+			Message:    "Operação cancelada após configurar os servidores DNS",
+			Error:      err,
+			BackupData: backupData,
+		}, err
+	}
+
 	// Limpar cache DNS (systemd-resolved)
 	if err := e.flushDNSCache(ctx); err != nil {
 		// Não falhar se não conseguir limpar cache
@@ -54,13 +123,58 @@ func (e *LinuxDNSExecutor) Execute(ctx context.Context, boosterID string) (*enti
 	return &entities.BoostApplyResult{
 		Success: true,
 		Message: "Configurações DNS otimizadas com sucesso para Linux. Cache DNS limpo e configurações de rede otimizadas.",
-		BackupData: map[string]interface{}{
-			"original_resolv_conf": originalDNS,
-			"optimized_dns_servers": optimizedDNS,
-			"backup_timestamp": time.Now().Unix(),
-			"booster_id": boosterID,
-			"platform": "linux",
-		},
+		BackupData: backupData,
+	}, nil
+}
+
+func (e *LinuxDNSExecutor) Plan(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	originalDNS, err := e.getCurrentDNSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []entities.PlannedChange{
+		entities.NewPlannedChange("/etc/resolv.conf nameserver",
+			strings.Join(e.parseNameservers(originalDNS), ", "),
+			strings.Join(dnsServers(ctx), ", "), true),
+		entities.NewPlannedChange("/etc/resolv.conf options", nil, "timeout:1 attempts:3 rotate", true),
+	}
+
+	keys := make([]string, 0, len(linuxOptimizedSysctl))
+	for key := range linuxOptimizedSysctl {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		var current interface{}
+		if value, err := e.readSysctl(key); err == nil {
+			current = value
+		}
+		// O revert restaura os valores padrão, não os atuais
+		changes = append(changes, entities.NewPlannedChange("sysctl "+key, current,
+			linuxOptimizedSysctl[key], current == linuxDefaultSysctl[key]))
+	}
+
+	changes = append(changes, entities.NewPlannedChange("dns cache", nil, "flushed", false))
+	return changes, nil
+}
+
+// Verify confere os nameservers do resolv.conf; os ajustes de sysctl são
+// aplicados em modo best-effort e por isso não contam como drift
+func (e *LinuxDNSExecutor) Verify(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	currentDNS, err := e.getCurrentDNSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	current := strings.Join(e.parseNameservers(currentDNS), ", ")
+	expected := strings.Join(dnsServers(ctx), ", ")
+	if current == expected {
+		return nil, nil
+	}
+	return []entities.PlannedChange{
+		entities.NewPlannedChange("/etc/resolv.conf nameserver", current, expected, true),
 	}, nil
 }
 
@@ -107,12 +221,7 @@ func (e *LinuxDNSExecutor) Revert(ctx context.Context, backupData entities.Backu
 }
 
 func (e *LinuxDNSExecutor) Validate(ctx context.Context) error {
-	// Verificar se estamos executando como root
-	if os.Geteuid() != 0 {
-		return fmt.Errorf("permissões de root necessárias para modificar configurações DNS")
-	}
-
-	// Verificar se o arquivo resolv.conf existe e é modificável
+	// Root e o sysctl são requisitos declarados em dnsRequirements
 	resolvConfPath := "/etc/resolv.conf"
 	if _, err := os.Stat(resolvConfPath); err != nil {
 		return fmt.Errorf("arquivo /etc/resolv.conf não acessível: %v", err)
@@ -125,14 +234,6 @@ func (e *LinuxDNSExecutor) Validate(ctx context.Context) error {
 	}
 	file.Close()
 
-	// Verificar se comandos necessários estão disponíveis
-	commands := []string{"systemctl", "sysctl"}
-	for _, cmd := range commands {
-		if _, err := exec.LookPath(cmd); err != nil {
-			fmt.Printf("Aviso: comando %s não encontrado, algumas funcionalidades podem não estar disponíveis\n", cmd)
-		}
-	}
-
 	return nil
 }
 
@@ -152,6 +253,25 @@ func (e *LinuxDNSExecutor) getCurrentDNSConfig() (string, error) {
 	return string(content), nil
 }
 
+func (e *LinuxDNSExecutor) parseNameservers(resolvConf string) []string {
+	var servers []string
+	for _, line := range strings.Split(resolvConf, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "nameserver" {
+			servers = append(servers, fields[1])
+		}
+	}
+	return servers
+}
+
+func (e *LinuxDNSExecutor) readSysctl(key string) (string, error) {
+	content, err := os.ReadFile("/proc/sys/" + strings.ReplaceAll(key, ".", "/"))
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(strings.Fields(string(content)), " "), nil
+}
+
 func (e *LinuxDNSExecutor) setDNSServers(dnsServers []string) error {
 	resolvConfPath := "/etc/resolv.conf"
 	
@@ -234,22 +354,7 @@ func (e *LinuxDNSExecutor) flushDNSCache(ctx context.Context) error {
 }
 
 func (e *LinuxDNSExecutor) optimizeNetworkSettings(ctx context.Context) error {
-	// Configurações de rede otimizadas
-	settings := map[string]string{
-		"net.core.rmem_default":     "31457280",
-		"net.core.rmem_max":         "67108864",
-		"net.core.wmem_default":     "31457280", 
-		"net.core.wmem_max":         "67108864",
-		"net.core.somaxconn":        "65535",
-		"net.core.netdev_max_backlog": "5000",
-		"net.ipv4.tcp_congestion_control": "bbr",
-		"net.ipv4.tcp_rmem":         "4096 31457280 67108864",
-		"net.ipv4.tcp_wmem":         "4096 31457280 67108864",
-		"net.ipv4.tcp_fastopen":     "3",
-		"net.ipv4.tcp_slow_start_after_idle": "0",
-	}
-
-	for key, value := range settings {
+	for key, value := range linuxOptimizedSysctl {
 		cmd := exec.CommandContext(ctx, "sysctl", "-w", fmt.Sprintf("%s=%s", key, value))
 		if err := cmd.Run(); err != nil {
 			fmt.Printf("Aviso: Falha ao configurar %s: %v\n", key, err)
@@ -261,21 +366,7 @@ func (e *LinuxDNSExecutor) optimizeNetworkSettings(ctx context.Context) error {
 
 func (e *LinuxDNSExecutor) restoreNetworkSettings(ctx context.Context) error {
 	// Restaurar configurações de rede padrão
-	defaultSettings := map[string]string{
-		"net.core.rmem_default":     "212992",
-		"net.core.rmem_max":         "212992", 
-		"net.core.wmem_default":     "212992",
-		"net.core.wmem_max":         "212992",
-		"net.core.somaxconn":        "128",
-		"net.core.netdev_max_backlog": "1000",
-		"net.ipv4.tcp_congestion_control": "cubic",
-		"net.ipv4.tcp_rmem":         "4096 87380 6291456",
-		"net.ipv4.tcp_wmem":         "4096 16384 4194304", 
-		"net.ipv4.tcp_fastopen":     "1",
-		"net.ipv4.tcp_slow_start_after_idle": "1",
-	}
-
-	for key, value := range defaultSettings {
+	for key, value := range linuxDefaultSysctl {
 		cmd := exec.CommandContext(ctx, "sysctl", "-w", fmt.Sprintf("%s=%s", key, value))
 		if err := cmd.Run(); err != nil {
 			fmt.Printf("Aviso: Falha ao restaurar %s: %v\n", key, err)
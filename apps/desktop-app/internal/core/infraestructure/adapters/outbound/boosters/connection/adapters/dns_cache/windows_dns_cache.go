@@ -15,6 +15,28 @@ import (
 
 type WindowsDNSExecutor struct{}
 
+// A configuração de DNS dos adaptadores é alterada via netsh
+var dnsResources = []string{entities.SystemResource("dns-client")}
+
+var dnsRequirements = entities.Requirements{
+	Elevation: true,
+	Binaries:  []string{"netsh", "ipconfig"},
+}
+
+var windowsOptimizedDNS = []string{"8.8.8.8", "8.8.4.4", "1.1.1.1", "1.0.0.1"}
+
+// dnsParams permite trocar os servidores padrão (Google e Cloudflare)
+var dnsParams = entities.ParamSchema{
+	{
+		Key:            dnsServersParam,
+		NameKey:        "booster.connection.dns.params.servers",
+		DescriptionKey: "booster.connection.dns.params.servers.description",
+		Type:           entities.ParamIPList,
+		Default:        windowsOptimizedDNS,
+		MaxItems:       6,
+	},
+}
+
 func NewDNSCacheExecutor(services *inbound.ExecutorDepServices) *WindowsDNSExecutor {
 	return &WindowsDNSExecutor{}
 }
@@ -29,8 +51,15 @@ func (e *WindowsDNSExecutor) Execute(ctx context.Context, boosterID string) (*en
 		}, err
 	}
 
-	// Configurar DNS otimizado (Google DNS e Cloudflare)
-	optimizedDNS := []string{"8.8.8.8", "8.8.4.4", "1.1.1.1", "1.0.0.1"}
+	// Servidores configurados pelo usuário ou os padrões (Google DNS e Cloudflare)
+	optimizedDNS := dnsServers(ctx)
+	backupData := map[string]interface{}{
+		"original_dns_servers": originalDNS,
+		"optimized_dns_servers": optimizedDNS,
+		"backup_timestamp": time.Now().Unix(),
+		"booster_id": boosterID,
+		"platform": "windows",
+	}
 	
 	// Aplicar nova configuração DNS
 	if err := e.setDNSServers(ctx, optimizedDNS); err != nil {
@@ -40,6 +69,15 @@ func (e *WindowsDNSExecutor) Execute(ctx context.Context, boosterID string) (*en
 		}, err
 	}
 
+	if err := entities.Checkpoint(ctx, "flush dns cache", backupData); err != nil {
+		return &entities.BoostApplyResult{
+			Success:    false,
+			Message:    "Operação cancelada após configurar os servidores DNS",
+			Error:      err,
+			BackupData: backupData,
+		}, err
+	}
+
 	// Limpar cache DNS
 	if err := e.flushDNSCache(ctx); err != nil {
 		return &entities.BoostApplyResult{
@@ -57,13 +95,50 @@ func (e *WindowsDNSExecutor) Execute(ctx context.Context, boosterID string) (*en
 	return &entities.BoostApplyResult{
 		Success: true,
 		Message: "Configurações DNS otimizadas com sucesso. Cache DNS limpo e configuração de rede renovada.",
-		BackupData: map[string]interface{}{
-			"original_dns_servers": originalDNS,
-			"optimized_dns_servers": optimizedDNS,
-			"backup_timestamp": time.Now().Unix(),
-			"booster_id": boosterID,
-			"platform": "windows",
-		},
+		BackupData: backupData,
+	}, nil
+}
+
+func (e *WindowsDNSExecutor) Plan(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	originalDNS, err := e.getCurrentDNSServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	interfaceName, err := e.getActiveNetworkInterface(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := strings.Join(originalDNS, ", ")
+	if current == "" {
+		current = "dhcp"
+	}
+
+	return []entities.PlannedChange{
+		entities.NewPlannedChange(fmt.Sprintf("netsh interface ipv4 dns %q", interfaceName),
+			current, strings.Join(dnsServers(ctx), ", "), true),
+		entities.NewPlannedChange("dns cache", nil, "flushed", false),
+		entities.NewPlannedChange("ip configuration", nil, "released and renewed", false),
+	}, nil
+}
+
+func (e *WindowsDNSExecutor) Verify(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	currentDNS, err := e.getCurrentDNSServers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	current := strings.Join(currentDNS, ", ")
+	expected := strings.Join(dnsServers(ctx), ", ")
+	if current == expected {
+		return nil, nil
+	}
+	if current == "" {
+		current = "dhcp"
+	}
+	return []entities.PlannedChange{
+		entities.NewPlannedChange("dns servers", current, expected, true),
 	}, nil
 }
 
@@ -118,22 +193,7 @@ func (e *WindowsDNSExecutor) Revert(ctx context.Context, backupData entities.Bac
 }
 
 func (e *WindowsDNSExecutor) Validate(ctx context.Context) error {
-	// Verificar se os comandos necessários estão disponíveis
-	commands := []string{"netsh", "ipconfig"}
-	
-	for _, cmd := range commands {
-		if _, err := exec.LookPath(cmd); err != nil {
-			return fmt.Errorf("comando necessário não encontrado: %s", cmd)
-		}
-	}
-
-	// Verificar se temos permissões administrativas
-	cmd := exec.CommandContext(ctx, "net", "session")
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("permissões administrativas necessárias para modificar configurações DNS")
-	}
-
-	// Verificar se conseguimos listar interfaces de rede
+	// Administrador, netsh e ipconfig são requisitos declarados em dnsRequirements
 	if _, err := e.getActiveNetworkInterface(ctx); err != nil {
 		return fmt.Errorf("não foi possível obter interface de rede ativa: %v", err)
 	}
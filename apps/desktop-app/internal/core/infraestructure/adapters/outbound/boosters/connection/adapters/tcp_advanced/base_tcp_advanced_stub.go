@@ -1,8 +1,20 @@
 //go:build !windows
+
 package connection
 
-import "github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+import (
+	"github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+	windows "github.com/oLenador/mulltbost/internal/core/application/ports/outbound/system"
+)
+
+// Sem executor fora do Windows: o catálogo lista o booster como indisponível na plataforma
+var tcpipResources []string
 
-func NewAdvancedTCPBooster(services *inbound.ExecutorDepServices) inbound.BoosterUseCase {
-    return nil
+func NewTCPAdvancedExecutor(
+	tcpService windows.TCPOptimizationService,
+	registryService windows.RegistryService,
+	systemService windows.SystemAPIService,
+	elevationService windows.ElevationService,
+) inbound.PlatformExecutor {
+	return nil
 }
@@ -4,11 +4,23 @@ package connection
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	windows "github.com/oLenador/mulltbost/internal/core/application/ports/outbound/system"
 	"github.com/oLenador/mulltbost/internal/core/domain/entities"
 )
 
+// Todos os tweaks ficam na chave Tcpip\Parameters, compartilhada com os outros boosters TCP
+var tcpipResources = []string{entities.RegistryResource(`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters`)}
+
+var advancedRegistryTweaks = map[string]interface{}{
+	`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\TcpAckFrequency`: 1,
+	`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\TCPNoDelay`:      1,
+	`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\TcpDelAckTicks`:  0,
+	`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\MaxConnectionsPerServer`: 16,
+	`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\MaxConnectionsPer1_0Server`: 16,
+}
+
 type TCPAdvancedExecutor struct {
 	tcpService    windows.TCPOptimizationService
 	registryService windows.RegistryService
@@ -94,21 +106,22 @@ func (e *TCPAdvancedExecutor) Execute(ctx context.Context, boosterID string) (*e
 	}
 
 	// 4. Configurações avançadas no registro
-	registryTweaks := map[string]interface{}{
-		`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\TcpAckFrequency`: 1,
-		`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\TCPNoDelay`:      1,
-		`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\TcpDelAckTicks`:  0,
-		`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\MaxConnectionsPerServer`: 16,
-		`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\MaxConnectionsPer1_0Server`: 16,
-	}
-
-	for keyPath, value := range registryTweaks {
+	for keyPath, value := range advancedRegistryTweaks {
 		// Fazer backup do valor atual
 		currentValue, _ := e.registryService.ReadRegistryValue(ctx, keyPath, "")
 		if currentValue != nil {
 			backupData[keyPath] = currentValue
 		}
 
+		if err := entities.Checkpoint(ctx, keyPath, backupData); err != nil {
+			return &entities.BoostApplyResult{
+				Success:    false,
+				Message:    fmt.Sprintf("Cancelled before writing registry value: %s", keyPath),
+				Error:      err,
+				BackupData: backupData,
+			}, err
+		}
+
 		// Aplicar nova configuração
 		if err := e.registryService.WriteRegistryValue(ctx, keyPath, "", value); err != nil {
 			return &entities.BoostApplyResult{
@@ -128,6 +141,59 @@ func (e *TCPAdvancedExecutor) Execute(ctx context.Context, boosterID string) (*e
 	}, nil
 }
 
+func (e *TCPAdvancedExecutor) Plan(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	currentConfig, err := e.tcpService.GetTCPConfiguration(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var currentWindowSize, currentNagle interface{}
+	if currentConfig != nil {
+		currentWindowSize = currentConfig.WindowSize
+		currentNagle = "disabled"
+		if currentConfig.NagleAlgorithmEnabled {
+			currentNagle = "enabled"
+		}
+	}
+
+	// O revert restaura os padrões do Windows, não a configuração atual
+	changes := []entities.PlannedChange{
+		entities.NewPlannedChange("tcp gaming profile", nil, "applied", false),
+		entities.NewPlannedChange("tcp window size", currentWindowSize, 65536, false),
+		entities.NewPlannedChange("nagle algorithm", currentNagle, "disabled", false),
+	}
+
+	keyPaths := make([]string, 0, len(advancedRegistryTweaks))
+	for keyPath := range advancedRegistryTweaks {
+		keyPaths = append(keyPaths, keyPath)
+	}
+	sort.Strings(keyPaths)
+
+	for _, keyPath := range keyPaths {
+		currentValue, _ := e.registryService.ReadRegistryValue(ctx, keyPath, "")
+		changes = append(changes, entities.NewPlannedChange(keyPath, currentValue, advancedRegistryTweaks[keyPath], currentValue != nil))
+	}
+
+	return changes, nil
+}
+
+// Verify confere apenas os valores de registro; perfil, janela e Nagle são
+// aplicados pelo TCPOptimizationService e não têm leitura equivalente
+func (e *TCPAdvancedExecutor) Verify(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	changes, err := e.Plan(ctx, boosterID)
+	if err != nil {
+		return nil, err
+	}
+
+	registryChanges := make([]entities.PlannedChange, 0, len(advancedRegistryTweaks))
+	for _, change := range changes {
+		if _, ok := advancedRegistryTweaks[change.Resource]; ok {
+			registryChanges = append(registryChanges, change)
+		}
+	}
+	return entities.DriftedChanges(registryChanges), nil
+}
+
 func (e *TCPAdvancedExecutor) Validate(ctx context.Context) error {
 	// Verificar se os serviços estão funcionando
 	if e.tcpService == nil {
@@ -1,4 +1,3 @@
-//go:build windows
 package connection
 
 import (
@@ -21,28 +20,40 @@ func NewTCPCongestionBooster(services *inbound.ExecutorDepServices) inbound.Boos
 		RiskLevel:      entities.RiskMedium,
 		Version:        "1.0.0",
 		Tags:           []string{"network", "tcp", "congestion"},
+		Resources:      tcpipResources,
+		Params:         tcpCongestionParams,
 	}
 
 	translations := map[i18n.Language]i18n.Translation{
 		i18n.Russian: {
-			"booster.connection.tcp_congestion.name":        "Настроить TCP-перегрузку (CTCP или Cubic)",
-			"booster.connection.tcp_congestion.description": "Настраивает алгоритм управления TCP-перегрузкой для использования более агрессивных методов, таких как CTCP или Cubic, оптимизируя пропускную способность.",
+			"booster.connection.tcp_congestion.name":                         "Настроить TCP-перегрузку (CTCP или Cubic)",
+			"booster.connection.tcp_congestion.description":                  "Настраивает алгоритм управления TCP-перегрузкой для использования более агрессивных методов, таких как CTCP или Cubic, оптимизируя пропускную способность.",
+			"booster.connection.tcp_congestion.params.algorithm":             "Алгоритм",
+			"booster.connection.tcp_congestion.params.algorithm.description": "auto выбирает CUBIC в Windows 11 и CTCP в более ранних версиях",
 		},
 		i18n.Spanish: {
-			"booster.connection.tcp_congestion.name":        "Ajustar el Congestionamiento TCP (CTCP o Cubic)",
-			"booster.connection.tcp_congestion.description": "Configura el algoritmo de control de congestionamiento TCP para usar más agresivo como CTCP (Compound TCP) o Cubic, optimizando el rendimiento en conexiones de alto ancho de banda.",
+			"booster.connection.tcp_congestion.name":                         "Ajustar el Congestionamiento TCP (CTCP o Cubic)",
+			"booster.connection.tcp_congestion.description":                  "Configura el algoritmo de control de congestionamiento TCP para usar más agresivo como CTCP (Compound TCP) o Cubic, optimizando el rendimiento en conexiones de alto ancho de banda.",
+			"booster.connection.tcp_congestion.params.algorithm":             "Algoritmo",
+			"booster.connection.tcp_congestion.params.algorithm.description": "auto elige CUBIC en Windows 11 y CTCP en versiones anteriores",
 		},
 		i18n.Portuguese: {
-			"booster.connection.tcp_congestion.name":        "Ajustar o Congestionamento TCP (CTCP ou Cubic)",
-			"booster.connection.tcp_congestion.description": "Configura o algoritmo de controlo de congestionamento TCP para usar mais agressivo como CTCP (Compound TCP) ou Cubic, otimizando o throughput em conexões de alta largura de banda.",
+			"booster.connection.tcp_congestion.name":                         "Ajustar o Congestionamento TCP (CTCP ou Cubic)",
+			"booster.connection.tcp_congestion.description":                  "Configura o algoritmo de controlo de congestionamento TCP para usar mais agressivo como CTCP (Compound TCP) ou Cubic, otimizando o throughput em conexões de alta largura de banda.",
+			"booster.connection.tcp_congestion.params.algorithm":             "Algoritmo",
+			"booster.connection.tcp_congestion.params.algorithm.description": "auto escolhe CUBIC no Windows 11 e CTCP nas versões anteriores",
 		},
 		i18n.PortugueseBrazil: {
-			"booster.connection.tcp_congestion.name":        "Ajustar o Congestionamento TCP (CTCP ou Cubic)",
-			"booster.connection.tcp_congestion.description": "Configura o algoritmo de controle de congestionamento TCP para usar mais agressivo como CTCP (Compound TCP) ou Cubic, otimizando o throughput em conexões de alta largura de banda.",
+			"booster.connection.tcp_congestion.name":                         "Ajustar o Congestionamento TCP (CTCP ou Cubic)",
+			"booster.connection.tcp_congestion.description":                  "Configura o algoritmo de controle de congestionamento TCP para usar mais agressivo como CTCP (Compound TCP) ou Cubic, otimizando o throughput em conexões de alta largura de banda.",
+			"booster.connection.tcp_congestion.params.algorithm":             "Algoritmo",
+			"booster.connection.tcp_congestion.params.algorithm.description": "auto escolhe CUBIC no Windows 11 e CTCP nas versões anteriores",
 		},
 		i18n.English: {
-			"booster.connection.tcp_congestion.name":        "Adjust TCP Congestion (CTCP or Cubic)",
-			"booster.connection.tcp_congestion.description": "Configures the TCP congestion control algorithm to use more aggressive methods like CTCP (Compound TCP) or Cubic, optimizing throughput on high-bandwidth connections.",
+			"booster.connection.tcp_congestion.name":                         "Adjust TCP Congestion (CTCP or Cubic)",
+			"booster.connection.tcp_congestion.description":                  "Configures the TCP congestion control algorithm to use more aggressive methods like CTCP (Compound TCP) or Cubic, optimizing throughput on high-bandwidth connections.",
+			"booster.connection.tcp_congestion.params.algorithm":             "Algorithm",
+			"booster.connection.tcp_congestion.params.algorithm.description": "auto picks CUBIC on Windows 11 and CTCP on earlier versions",
 		},
 	}
 
@@ -1,8 +1,23 @@
 //go:build !windows
+
 package connection
 
-import "github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+import (
+	"github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+	windows "github.com/oLenador/mulltbost/internal/core/application/ports/outbound/system"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// Sem executor fora do Windows: o catálogo lista o booster como indisponível na plataforma
+var tcpipResources []string
+
+var tcpCongestionParams entities.ParamSchema
 
-func NewTCPCongestionBooster(services *inbound.ExecutorDepServices) inbound.BoosterUseCase {
-    return nil
+func NewTCPCongestionExecutor(
+	tcpService windows.TCPOptimizationService,
+	registryService windows.RegistryService,
+	systemService windows.SystemAPIService,
+	elevationService windows.ElevationService,
+) inbound.PlatformExecutor {
+	return nil
 }
@@ -4,12 +4,41 @@ package connection
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
 
 	windows "github.com/oLenador/mulltbost/internal/core/application/ports/outbound/system"
 	"github.com/oLenador/mulltbost/internal/core/domain/entities"
 )
 
+const congestionRegistryPath = `HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters`
+
+var tcpipResources = []string{entities.RegistryResource(congestionRegistryPath)}
+
+var congestionRegistryTweaks = map[string]interface{}{
+	`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\TcpInitialRtt`:     3000,
+	`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\TcpMaxDupAcks`:     2,
+	`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\EnableWsd`:        0,
+	`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\Tcp1323Opts`:      3,
+}
+
+const (
+	congestionAlgorithmParam = "algorithm"
+	congestionAuto           = "auto"
+)
+
+// tcpCongestionParams permite fixar o algoritmo em vez da escolha pela versão do Windows
+var tcpCongestionParams = entities.ParamSchema{
+	{
+		Key:            congestionAlgorithmParam,
+		NameKey:        "booster.connection.tcp_congestion.params.algorithm",
+		DescriptionKey: "booster.connection.tcp_congestion.params.algorithm.description",
+		Type:           entities.ParamEnum,
+		Default:        congestionAuto,
+		Options:        []string{congestionAuto, "ctcp", "cubic", "dctcp", "newreno"},
+	},
+}
+
 type TCPCongestionExecutor struct {
 	tcpService       windows.TCPOptimizationService
 	registryService  windows.RegistryService
@@ -52,12 +81,7 @@ func (e *TCPCongestionExecutor) Execute(ctx context.Context, boosterID string) (
 		backupData["TcpCongestionControl"] = currentAlgorithm
 	}
 
-	// Verificar se é Windows 10/11 para usar CUBIC, senão usar CTCP
-	isWin11, err := e.systemService.IsWindows11(ctx)
-	algorithm := "ctcp" // Default para versões mais antigas
-	if err == nil && isWin11 {
-		algorithm = "cubic"
-	}
+	algorithm := e.congestionAlgorithm(ctx)
 
 	// Configurar algoritmo de congestionamento TCP
 	if err := e.tcpService.SetTCPCongestionControl(ctx, algorithm); err != nil {
@@ -69,14 +93,7 @@ func (e *TCPCongestionExecutor) Execute(ctx context.Context, boosterID string) (
 		}, err
 	}
 
-	registryTweaks := map[string]interface{}{
-		`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\TcpInitialRtt`:     3000,
-		`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\TcpMaxDupAcks`:     2,
-		`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\EnableWsd`:        0,
-		`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters\Tcp1323Opts`:      3,
-	}
-
-	for keyPath, value := range registryTweaks {
+	for keyPath, value := range congestionRegistryTweaks {
 		key := keyPath[:strings.LastIndex(keyPath, `\`)]
 		valueName := keyPath[strings.LastIndex(keyPath, `\`)+1:]
 		
@@ -86,6 +103,15 @@ func (e *TCPCongestionExecutor) Execute(ctx context.Context, boosterID string) (
 			backupData[keyPath] = currentValue
 		}
 
+		if err := entities.Checkpoint(ctx, keyPath, backupData); err != nil {
+			return &entities.BoostApplyResult{
+				Success:    false,
+				Message:    fmt.Sprintf("Cancelled before writing registry value: %s", keyPath),
+				Error:      err,
+				BackupData: backupData,
+			}, err
+		}
+
 		// Aplicar nova configuração
 		if err := e.registryService.WriteRegistryValue(ctx, key, valueName, value); err != nil {
 			return &entities.BoostApplyResult{
@@ -105,6 +131,48 @@ func (e *TCPCongestionExecutor) Execute(ctx context.Context, boosterID string) (
 	}, nil
 }
 
+func (e *TCPCongestionExecutor) Plan(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	algorithm := e.congestionAlgorithm(ctx)
+
+	currentAlgorithm, _ := e.registryService.ReadRegistryValue(ctx, congestionRegistryPath, "TcpCongestionControl")
+	changes := []entities.PlannedChange{
+		entities.NewPlannedChange(congestionRegistryPath+`\TcpCongestionControl`, currentAlgorithm, algorithm, currentAlgorithm != nil),
+	}
+
+	keyPaths := make([]string, 0, len(congestionRegistryTweaks))
+	for keyPath := range congestionRegistryTweaks {
+		keyPaths = append(keyPaths, keyPath)
+	}
+	sort.Strings(keyPaths)
+
+	for _, keyPath := range keyPaths {
+		key := keyPath[:strings.LastIndex(keyPath, `\`)]
+		valueName := keyPath[strings.LastIndex(keyPath, `\`)+1:]
+
+		currentValue, _ := e.registryService.ReadRegistryValue(ctx, key, valueName)
+		changes = append(changes, entities.NewPlannedChange(keyPath, currentValue, congestionRegistryTweaks[keyPath], currentValue != nil))
+	}
+
+	return changes, nil
+}
+
+// Verify confere os valores de registro gravados diretamente pelo booster; o
+// algoritmo é configurado pelo TCPOptimizationService e nem sempre fica no registro
+func (e *TCPCongestionExecutor) Verify(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	changes, err := e.Plan(ctx, boosterID)
+	if err != nil {
+		return nil, err
+	}
+
+	registryChanges := make([]entities.PlannedChange, 0, len(congestionRegistryTweaks))
+	for _, change := range changes {
+		if _, ok := congestionRegistryTweaks[change.Resource]; ok {
+			registryChanges = append(registryChanges, change)
+		}
+	}
+	return entities.DriftedChanges(registryChanges), nil
+}
+
 func (e *TCPCongestionExecutor) Validate(ctx context.Context) error {
 	if e.tcpService == nil {
 		return fmt.Errorf("TCP optimization service not available")
@@ -142,14 +210,27 @@ func (e *TCPCongestionExecutor) CanExecute(ctx context.Context) bool {
 		return true // Pode ser configurado
 	}
 
-	// Se já está configurado como CUBIC ou CTCP, não precisa executar
-	if current == "cubic" || current == "ctcp" {
+	// Se já está configurado com o algoritmo escolhido, não precisa executar
+	if current == e.congestionAlgorithm(ctx) {
 		return false
 	}
 
 	return true
 }
 
+// congestionAlgorithm retorna o algoritmo configurado; auto usa CUBIC no
+// Windows 11 e CTCP nas versões mais antigas
+func (e *TCPCongestionExecutor) congestionAlgorithm(ctx context.Context) string {
+	algorithm := entities.ParamsFromContext(ctx, tcpCongestionParams).String(congestionAlgorithmParam)
+	if algorithm != congestionAuto {
+		return algorithm
+	}
+	if isWin11, err := e.systemService.IsWindows11(ctx); err == nil && isWin11 {
+		return "cubic"
+	}
+	return "ctcp"
+}
+
 func (e *TCPCongestionExecutor) Revert(ctx context.Context, backupData entities.BackupData) (*entities.BoostRevertResult, error) {
 	if backupData == nil {
 		return &entities.BoostRevertResult{
@@ -1,4 +1,3 @@
-//go:build windows
 package connection
 
 import (
@@ -22,6 +21,7 @@ func NewTCPFastOpenBooster(services *inbound.ExecutorDepServices) inbound.Booste
 		RiskLevel:      entities.RiskMedium,
 		Version:        "1.0.0",
 		Tags:           []string{"network", "tcp", "fast open"},
+		Resources:      tcpipResources,
 	}
 
 	translations := map[i18n.Language]i18n.Translation{
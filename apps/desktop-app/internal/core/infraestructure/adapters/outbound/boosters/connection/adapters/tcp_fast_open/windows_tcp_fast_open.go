@@ -4,11 +4,19 @@ package connection
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/oLenador/mulltbost/internal/core/domain/entities"
 	windows "github.com/oLenador/mulltbost/internal/core/application/ports/outbound/system"
 )
 
+var tcpipResources = []string{entities.RegistryResource(`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters`)}
+
+var fastOpenAdditionalTweaks = map[string]interface{}{
+	"TcpInitialRtt":      1000, // Reduzir RTT inicial
+	"TcpMaxConnectRetransmissions": 2, // Reduzir tentativas de conexão
+}
+
 type TCPFastOpenExecutor struct {
 	registryService  windows.RegistryService
 	systemService    windows.SystemAPIService
@@ -64,6 +72,15 @@ func (e *TCPFastOpenExecutor) Execute(ctx context.Context, boosterID string) (*e
 	}
 
 	// Habilitar TCP Fast Open
+	if err := entities.Checkpoint(ctx, "TcpFastOpen", backupData); err != nil {
+		return &entities.BoostApplyResult{
+			Success:    false,
+			Message:    "Cancelled before enabling TCP Fast Open",
+			Error:      err,
+			BackupData: backupData,
+		}, err
+	}
+
 	if err := e.registryService.WriteRegistryValue(ctx, registryPath, "TcpFastOpen", 1); err != nil {
 		return &entities.BoostApplyResult{
 			Success: false,
@@ -75,6 +92,15 @@ func (e *TCPFastOpenExecutor) Execute(ctx context.Context, boosterID string) (*e
 
 	// Habilitar TCP Fast Open para cliente (Windows 10 1703+)
 	if isWin11 {
+		if err := entities.Checkpoint(ctx, "TcpFastOpenClient", backupData); err != nil {
+			return &entities.BoostApplyResult{
+				Success:    false,
+				Message:    "Cancelled before enabling TCP Fast Open client mode",
+				Error:      err,
+				BackupData: backupData,
+			}, err
+		}
+
 		if err := e.registryService.WriteRegistryValue(ctx, registryPath, "TcpFastOpenClient", 1); err != nil {
 			return &entities.BoostApplyResult{
 				Success: false,
@@ -86,16 +112,19 @@ func (e *TCPFastOpenExecutor) Execute(ctx context.Context, boosterID string) (*e
 	}
 
 	// Configurações adicionais para otimização de latência inicial
-	additionalTweaks := map[string]interface{}{
-		"TcpInitialRtt":      1000, // Reduzir RTT inicial
-		"TcpMaxConnectRetransmissions": 2, // Reduzir tentativas de conexão
-	}
-
-	for valueName, value := range additionalTweaks {
+	for valueName, value := range fastOpenAdditionalTweaks {
 		currentVal, _ := e.registryService.ReadRegistryValue(ctx, registryPath, valueName)
 		if currentVal != nil {
 			backupData[valueName] = currentVal
 		}
+		if err := entities.Checkpoint(ctx, valueName, backupData); err != nil {
+			return &entities.BoostApplyResult{
+				Success:    false,
+				Message:    fmt.Sprintf("Cancelled before setting %s", valueName),
+				Error:      err,
+				BackupData: backupData,
+			}, err
+		}
 
 		if err := e.registryService.WriteRegistryValue(ctx, registryPath, valueName, value); err != nil {
 			return &entities.BoostApplyResult{
@@ -115,6 +144,47 @@ func (e *TCPFastOpenExecutor) Execute(ctx context.Context, boosterID string) (*e
 	}, nil
 }
 
+func (e *TCPFastOpenExecutor) Plan(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	isWin11, err := e.systemService.IsWindows11(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	registryPath := `HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters`
+	tweaks := map[string]interface{}{"TcpFastOpen": 1}
+	if isWin11 {
+		tweaks["TcpFastOpenClient"] = 1
+	}
+	for valueName, value := range fastOpenAdditionalTweaks {
+		tweaks[valueName] = value
+	}
+
+	valueNames := make([]string, 0, len(tweaks))
+	for valueName := range tweaks {
+		valueNames = append(valueNames, valueName)
+	}
+	sort.Strings(valueNames)
+
+	changes := make([]entities.PlannedChange, 0, len(valueNames))
+	for _, valueName := range valueNames {
+		currentValue, err := e.registryService.ReadRegistryValue(ctx, registryPath, valueName)
+		if err != nil {
+			currentValue = nil
+		}
+		changes = append(changes, entities.NewPlannedChange(registryPath+`\`+valueName, currentValue, tweaks[valueName], currentValue != nil))
+	}
+
+	return changes, nil
+}
+
+func (e *TCPFastOpenExecutor) Verify(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	changes, err := e.Plan(ctx, boosterID)
+	if err != nil {
+		return nil, err
+	}
+	return entities.DriftedChanges(changes), nil
+}
+
 func (e *TCPFastOpenExecutor) Validate(ctx context.Context) error {
 	if e.registryService == nil {
 		return fmt.Errorf("registry service not available")
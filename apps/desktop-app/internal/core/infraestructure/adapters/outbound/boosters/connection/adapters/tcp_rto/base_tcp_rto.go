@@ -1,4 +1,3 @@
-//go:build windows
 package connection
 
 import (
@@ -21,6 +20,7 @@ func NewTCPRTOBooster(services *inbound.ExecutorDepServices) inbound.BoosterUseC
 		RiskLevel:      entities.RiskMedium,
 		Version:        "1.0.0",
 		Tags:           []string{"network", "tcp", "rto"},
+		Resources:      tcpipResources,
 	}
 
 	translations := map[i18n.Language]i18n.Translation{
@@ -4,11 +4,32 @@ package connection
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	windows "github.com/oLenador/mulltbost/internal/core/application/ports/outbound/system"
 	"github.com/oLenador/mulltbost/internal/core/domain/entities"
 )
 
+var tcpipResources = []string{entities.RegistryResource(`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters`)}
+
+// Configurações de RTO (Retransmission Timeout) para otimização
+var rtoTweaks = map[string]interface{}{
+	"TcpInitialRtt":           1000, // RTT inicial em ms (padrão: 3000)
+	"TcpMaxDataRetransmissions": 3,   // Máximo de retransmissões (padrão: 5)
+	"TcpMaxConnectRetransmissions": 2, // Retransmissões de conexão (padrão: 3)
+	"TcpTimedWaitDelay":       30,   // Delay em TIME_WAIT (padrão: 240)
+	"TcpFinWait2Timeout":      40,   // Timeout FIN_WAIT_2 (padrão: 240)
+	"KeepAliveTime":           300000, // Keep-alive em ms (padrão: 7200000)
+	"KeepAliveInterval":       1000,   // Intervalo keep-alive (padrão: 1000)
+}
+
+// Configurações globais de TCP que afetam RTO
+var rtoGlobalTweaks = map[string]interface{}{
+	"TcpAckFrequency": 1, // Enviar ACK para cada pacote
+	"TcpDelAckTicks":  0, // Desabilitar delayed ACK
+	"TCPNoDelay":      1, // Desabilitar algoritmo de Nagle
+}
+
 type TCPRTOExecutor struct {
 	registryService  windows.RegistryService
 	systemService    windows.SystemAPIService
@@ -40,17 +61,6 @@ func (e *TCPRTOExecutor) Execute(ctx context.Context, boosterID string) (*entiti
 	backupData := make(map[string]interface{})
 	registryPath := `HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters`
 
-	// Configurações de RTO (Retransmission Timeout) para otimização
-	rtoTweaks := map[string]interface{}{
-		"TcpInitialRtt":           1000, // RTT inicial em ms (padrão: 3000)
-		"TcpMaxDataRetransmissions": 3,   // Máximo de retransmissões (padrão: 5)
-		"TcpMaxConnectRetransmissions": 2, // Retransmissões de conexão (padrão: 3)
-		"TcpTimedWaitDelay":       30,   // Delay em TIME_WAIT (padrão: 240)
-		"TcpFinWait2Timeout":      40,   // Timeout FIN_WAIT_2 (padrão: 240)
-		"KeepAliveTime":           300000, // Keep-alive em ms (padrão: 7200000)
-		"KeepAliveInterval":       1000,   // Intervalo keep-alive (padrão: 1000)
-	}
-
 	// Fazer backup dos valores atuais
 	for valueName := range rtoTweaks {
 		currentValue, err := e.registryService.ReadRegistryValue(ctx, registryPath, valueName)
@@ -61,6 +71,15 @@ func (e *TCPRTOExecutor) Execute(ctx context.Context, boosterID string) (*entiti
 
 	// Aplicar as novas configurações
 	for valueName, value := range rtoTweaks {
+		if err := entities.Checkpoint(ctx, valueName, backupData); err != nil {
+			return &entities.BoostApplyResult{
+				Success:    false,
+				Message:    fmt.Sprintf("Cancelled before setting %s", valueName),
+				Error:      err,
+				BackupData: backupData,
+			}, err
+		}
+
 		if err := e.registryService.WriteRegistryValue(ctx, registryPath, valueName, value); err != nil {
 			return &entities.BoostApplyResult{
 				Success: false,
@@ -72,17 +91,19 @@ func (e *TCPRTOExecutor) Execute(ctx context.Context, boosterID string) (*entiti
 	}
 
 	// Aplicar configurações globais de TCP que afetam RTO
-	globalTweaks := map[string]interface{}{
-		"TcpAckFrequency": 1, // Enviar ACK para cada pacote
-		"TcpDelAckTicks":  0, // Desabilitar delayed ACK
-		"TCPNoDelay":      1, // Desabilitar algoritmo de Nagle
-	}
-
-	for valueName, value := range globalTweaks {
+	for valueName, value := range rtoGlobalTweaks {
 		currentValue, err := e.registryService.ReadRegistryValue(ctx, registryPath, valueName)
 		if err == nil && currentValue != nil {
 			backupData[valueName] = currentValue
 		}
+		if err := entities.Checkpoint(ctx, valueName, backupData); err != nil {
+			return &entities.BoostApplyResult{
+				Success:    false,
+				Message:    fmt.Sprintf("Cancelled before setting global TCP parameter %s", valueName),
+				Error:      err,
+				BackupData: backupData,
+			}, err
+		}
 
 		if err := e.registryService.WriteRegistryValue(ctx, registryPath, valueName, value); err != nil {
 			return &entities.BoostApplyResult{
@@ -102,6 +123,37 @@ func (e *TCPRTOExecutor) Execute(ctx context.Context, boosterID string) (*entiti
 	}, nil
 }
 
+func (e *TCPRTOExecutor) Plan(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	registryPath := `HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters`
+	changes := make([]entities.PlannedChange, 0, len(rtoTweaks)+len(rtoGlobalTweaks))
+
+	for _, tweaks := range []map[string]interface{}{rtoTweaks, rtoGlobalTweaks} {
+		valueNames := make([]string, 0, len(tweaks))
+		for valueName := range tweaks {
+			valueNames = append(valueNames, valueName)
+		}
+		sort.Strings(valueNames)
+
+		for _, valueName := range valueNames {
+			currentValue, err := e.registryService.ReadRegistryValue(ctx, registryPath, valueName)
+			if err != nil {
+				currentValue = nil
+			}
+			changes = append(changes, entities.NewPlannedChange(registryPath+`\`+valueName, currentValue, tweaks[valueName], currentValue != nil))
+		}
+	}
+
+	return changes, nil
+}
+
+func (e *TCPRTOExecutor) Verify(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	changes, err := e.Plan(ctx, boosterID)
+	if err != nil {
+		return nil, err
+	}
+	return entities.DriftedChanges(changes), nil
+}
+
 func (e *TCPRTOExecutor) Validate(ctx context.Context) error {
 	if e.registryService == nil {
 		return fmt.Errorf("registry service not available")
@@ -23,7 +23,7 @@ import (
 
 func GetAllPlugins(services *inbound.ExecutorDepServices) []inbound.BoosterUseCase {
 
-    boosters := []inbound.BoosterUseCase{
+    return []inbound.BoosterUseCase{
 		dnsBooster.NewDNSBooster(services),
 		// arpBooster.NewARPCacheBooster(services),
 		// eeeBooster.NewEEEBooster(services),
@@ -42,13 +42,4 @@ func GetAllPlugins(services *inbound.ExecutorDepServices) []inbound.BoosterUseCa
 		 tcpFastOpenBooster.NewTCPFastOpenBooster(services),
 		 tcpRtoBooster.NewTCPRTOBooster(services),
 	}
-
-	result := []inbound.BoosterUseCase{}
-    for _, b := range boosters {
-        if b != nil {
-            result = append(result, b)
-        }
-    }
-
-    return result
 }
@@ -0,0 +1,8 @@
+package flusher
+
+import "github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+
+// GetAllPlugins retorna os boosters de limpeza; a categoria ainda não tem boosters
+func GetAllPlugins() []inbound.BoosterUseCase {
+	return []inbound.BoosterUseCase{}
+}
@@ -1 +1,8 @@
-boosters=( app_background_disable bluetooth_disable cpu_affinity cpu_game_priority cpu_mitigations cpu_system_services_reduce explorer_optimize error_reporting_disable fast_startup_disable game_dvr_disable gpu_low_latency_mode gpu_scheduling gpu_scheduling_hardware gpu_irq_optimize gpu_power_policy hibernation_disable maps_manager_disable memory_allocation memory_cache_optimize memory_flush memory_free mult_boost_power non_paged_pool_optimize page_file_size prefetch_superfetch_disable process_shutdown_delay search_suggestions_disable services_disable smart_screen_disable standby_list_clean sysmain_optimize telemetry_disable thread_scheduling timer_resolution trim_ssd visual_effects_disable windows_defender_disable xbox_services_disable windows_search_disable)
\ No newline at end of file
+package games
+
+import "github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+
+// GetAllPlugins retorna os boosters específicos de jogos; a categoria ainda não tem boosters
+func GetAllPlugins() []inbound.BoosterUseCase {
+	return []inbound.BoosterUseCase{}
+}
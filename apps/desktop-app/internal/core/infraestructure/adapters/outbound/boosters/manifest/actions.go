@@ -0,0 +1,456 @@
+package manifest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// action é a implementação de um tipo de ação do manifesto. read retorna nil quando
+// o valor não existe no sistema; restore recebe o que read retornou antes do apply.
+type action interface {
+	resource() string
+	target() string
+	desired() interface{}
+	read(ctx context.Context) (interface{}, error)
+	apply(ctx context.Context) error
+	restore(ctx context.Context, previous interface{}) error
+}
+
+// newAction cria a ação; root prefixa os caminhos de arquivo (vazio fora dos testes)
+func newAction(spec Action, root string, services *inbound.ExecutorDepServices) (action, error) {
+	switch spec.Type {
+	case ActionSysctl:
+		return &sysctlAction{
+			key:   spec.Key,
+			path:  filepath.Join(root, "/proc/sys", strings.ReplaceAll(spec.Key, ".", "/")),
+			value: normalizeSysctl(fmt.Sprint(spec.Value)),
+		}, nil
+	case ActionSysfs:
+		return &sysfsAction{
+			name:  spec.Path,
+			path:  filepath.Join(root, spec.Path),
+			value: strings.TrimSpace(fmt.Sprint(spec.Value)),
+		}, nil
+	case ActionFileLine:
+		match, err := regexp.Compile(spec.Match)
+		if err != nil {
+			return nil, err
+		}
+		return &fileLineAction{
+			name:  spec.Path,
+			path:  filepath.Join(root, spec.Path),
+			match: match,
+			line:  spec.Line,
+		}, nil
+	case ActionRegistry:
+		if services == nil || services.RegistryService == nil {
+			return nil, fmt.Errorf("registry service is not available")
+		}
+		value, err := registryValue(spec.Value, spec.ValueType)
+		if err != nil {
+			return nil, err
+		}
+		return &registryAction{
+			services:  services,
+			key:       spec.Key,
+			name:      spec.Name,
+			value:     value,
+			valueType: spec.ValueType,
+		}, nil
+	case ActionService:
+		if services == nil || services.WindowsSvcMgr == nil {
+			return nil, fmt.Errorf("service manager is not available")
+		}
+		return &serviceAction{
+			services: services,
+			name:     spec.Name,
+			startup:  spec.Startup,
+			state:    spec.State,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown action type %q", spec.Type)
+	}
+}
+
+// sysctlAction altera um parâmetro do kernel por /proc/sys
+type sysctlAction struct {
+	key   string
+	path  string
+	value string
+}
+
+func (a *sysctlAction) resource() string     { return entities.SystemResource("sysctl:" + a.key) }
+func (a *sysctlAction) target() string       { return "sysctl " + a.key }
+func (a *sysctlAction) desired() interface{} { return a.value }
+
+func (a *sysctlAction) read(ctx context.Context) (interface{}, error) {
+	data, err := os.ReadFile(a.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sysctl %s: %w", a.key, err)
+	}
+	return normalizeSysctl(string(data)), nil
+}
+
+func (a *sysctlAction) apply(ctx context.Context) error {
+	return a.write(a.value)
+}
+
+func (a *sysctlAction) restore(ctx context.Context, previous interface{}) error {
+	value, ok := previous.(string)
+	if !ok {
+		return fmt.Errorf("no backup value for sysctl %s", a.key)
+	}
+	return a.write(value)
+}
+
+func (a *sysctlAction) write(value string) error {
+	if err := os.WriteFile(a.path, []byte(value+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write sysctl %s: %w", a.key, err)
+	}
+	return nil
+}
+
+// normalizeSysctl junta os campos com um espaço, como o kernel lista valores
+// separados por tab (ex.: net.ipv4.tcp_rmem)
+func normalizeSysctl(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// sysfsAction escreve um valor em /sys. Em arquivos de seleção, como
+// "always [madvise] never", o valor atual é o que está entre colchetes.
+type sysfsAction struct {
+	name  string
+	path  string
+	value string
+}
+
+func (a *sysfsAction) resource() string     { return entities.FileResource(a.name) }
+func (a *sysfsAction) target() string       { return a.name }
+func (a *sysfsAction) desired() interface{} { return a.value }
+
+var sysfsSelected = regexp.MustCompile(`\[([^\]]+)\]`)
+
+func (a *sysfsAction) read(ctx context.Context) (interface{}, error) {
+	data, err := os.ReadFile(a.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", a.name, err)
+	}
+	if selected := sysfsSelected.FindStringSubmatch(string(data)); selected != nil {
+		return selected[1], nil
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func (a *sysfsAction) apply(ctx context.Context) error {
+	return a.write(a.value)
+}
+
+func (a *sysfsAction) restore(ctx context.Context, previous interface{}) error {
+	value, ok := previous.(string)
+	if !ok {
+		return fmt.Errorf("no backup value for %s", a.name)
+	}
+	return a.write(value)
+}
+
+func (a *sysfsAction) write(value string) error {
+	if err := os.WriteFile(a.path, []byte(value), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", a.name, err)
+	}
+	return nil
+}
+
+// fileLineAction substitui a primeira linha do arquivo que casa com match, ou
+// acrescenta a linha no fim se nenhuma casar
+type fileLineAction struct {
+	name  string
+	path  string
+	match *regexp.Regexp
+	line  string
+}
+
+func (a *fileLineAction) resource() string     { return entities.FileResource(a.name) }
+func (a *fileLineAction) target() string       { return a.name + " " + a.match.String() }
+func (a *fileLineAction) desired() interface{} { return a.line }
+
+func (a *fileLineAction) read(ctx context.Context) (interface{}, error) {
+	lines, err := a.lines()
+	if err != nil {
+		return nil, err
+	}
+	if i := a.find(lines); i >= 0 {
+		return lines[i], nil
+	}
+	return nil, nil
+}
+
+func (a *fileLineAction) apply(ctx context.Context) error {
+	lines, err := a.lines()
+	if err != nil {
+		return err
+	}
+	if i := a.find(lines); i >= 0 {
+		lines[i] = a.line
+	} else {
+		lines = append(lines, a.line)
+	}
+	return a.write(lines)
+}
+
+// restore volta a linha original ou remove a linha que o apply acrescentou
+func (a *fileLineAction) restore(ctx context.Context, previous interface{}) error {
+	lines, err := a.lines()
+	if err != nil {
+		return err
+	}
+	i := a.find(lines)
+	if i < 0 {
+		return nil
+	}
+	if original, ok := previous.(string); ok {
+		lines[i] = original
+	} else {
+		lines = append(lines[:i], lines[i+1:]...)
+	}
+	return a.write(lines)
+}
+
+func (a *fileLineAction) find(lines []string) int {
+	for i, line := range lines {
+		if a.match.MatchString(line) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (a *fileLineAction) lines() ([]string, error) {
+	data, err := os.ReadFile(a.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", a.name, err)
+	}
+	content := strings.TrimSuffix(string(data), "\n")
+	if content == "" {
+		return nil, nil
+	}
+	return strings.Split(content, "\n"), nil
+}
+
+func (a *fileLineAction) write(lines []string) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(a.path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	content := strings.Join(lines, "\n")
+	if len(lines) > 0 {
+		content += "\n"
+	}
+	if err := os.WriteFile(a.path, []byte(content), mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", a.name, err)
+	}
+	return nil
+}
+
+// registryAction grava um valor no registro do Windows
+type registryAction struct {
+	services  *inbound.ExecutorDepServices
+	key       string
+	name      string
+	value     interface{}
+	valueType string
+}
+
+func (a *registryAction) resource() string     { return entities.RegistryResource(a.key) }
+func (a *registryAction) target() string       { return a.key + `\` + a.name }
+func (a *registryAction) desired() interface{} { return a.value }
+
+func (a *registryAction) read(ctx context.Context) (interface{}, error) {
+	value, err := a.services.RegistryService.ReadRegistryValue(ctx, a.key, a.name)
+	if err != nil {
+		// Valor inexistente: o revert remove o valor criado
+		return nil, nil
+	}
+	return value, nil
+}
+
+func (a *registryAction) apply(ctx context.Context) error {
+	if err := a.services.RegistryService.WriteRegistryValue(ctx, a.key, a.name, a.value); err != nil {
+		return fmt.Errorf("failed to write %s: %w", a.target(), err)
+	}
+	return nil
+}
+
+func (a *registryAction) restore(ctx context.Context, previous interface{}) error {
+	if previous == nil {
+		if err := a.services.RegistryService.DeleteRegistryValue(ctx, a.key, a.name); err != nil {
+			return fmt.Errorf("failed to delete %s: %w", a.target(), err)
+		}
+		return nil
+	}
+	// O backup passa por JSON: números voltam como float64 ou json.Number
+	value, err := registryValue(previous, a.valueType)
+	if err != nil {
+		return fmt.Errorf("invalid backup value for %s: %w", a.target(), err)
+	}
+	if err := a.services.RegistryService.WriteRegistryValue(ctx, a.key, a.name, value); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", a.target(), err)
+	}
+	return nil
+}
+
+// registryValue converte o valor para o tipo do registro; sem valueType vale dword
+func registryValue(value interface{}, valueType string) (interface{}, error) {
+	switch valueType {
+	case "", "dword":
+		number, err := toUint(value, math.MaxUint32)
+		if err != nil {
+			return nil, err
+		}
+		return uint32(number), nil
+	case "qword":
+		return toUint(value, math.MaxUint64)
+	case "string":
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string value, got %T", value)
+		}
+		return text, nil
+	default:
+		return nil, fmt.Errorf("unknown registry value type %q", valueType)
+	}
+}
+
+func toUint(value interface{}, max uint64) (uint64, error) {
+	var number uint64
+	switch v := value.(type) {
+	case int:
+		if v < 0 {
+			return 0, fmt.Errorf("%d is negative", v)
+		}
+		number = uint64(v)
+	case uint32:
+		number = uint64(v)
+	case uint64:
+		number = v
+	case float64:
+		if v < 0 || v != math.Trunc(v) || v > float64(max) {
+			return 0, fmt.Errorf("%v is not a valid registry number", v)
+		}
+		number = uint64(v)
+	case json.Number:
+		parsed, err := strconv.ParseUint(v.String(), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s is not a valid registry number", v)
+		}
+		number = parsed
+	default:
+		return 0, fmt.Errorf("expected a number value, got %T", value)
+	}
+	if number > max {
+		return 0, fmt.Errorf("%d is out of range", number)
+	}
+	return number, nil
+}
+
+// serviceAction ajusta o tipo de inicialização e o estado de um serviço do Windows.
+// O valor lido descreve só os campos que o manifesto altera: "startup=disabled,state=stopped".
+type serviceAction struct {
+	services *inbound.ExecutorDepServices
+	name     string
+	startup  string
+	state    string
+}
+
+func (a *serviceAction) resource() string {
+	return entities.SystemResource("service:" + strings.ToLower(a.name))
+}
+func (a *serviceAction) target() string { return "service " + a.name }
+
+func (a *serviceAction) desired() interface{} {
+	return formatServiceState(a.startup, a.state)
+}
+
+func (a *serviceAction) read(ctx context.Context) (interface{}, error) {
+	status, err := a.services.WindowsSvcMgr.GetServiceStatus(ctx, a.name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service %s: %w", a.name, err)
+	}
+	var startup, state string
+	if a.startup != "" {
+		startup = status.StartupType
+	}
+	if a.state != "" {
+		state = status.Status
+	}
+	return formatServiceState(startup, state), nil
+}
+
+func (a *serviceAction) apply(ctx context.Context) error {
+	return a.set(ctx, a.startup, a.state)
+}
+
+func (a *serviceAction) restore(ctx context.Context, previous interface{}) error {
+	value, ok := previous.(string)
+	if !ok {
+		return fmt.Errorf("no backup value for service %s", a.name)
+	}
+	startup, state := parseServiceState(value)
+	return a.set(ctx, startup, state)
+}
+
+func (a *serviceAction) set(ctx context.Context, startup, state string) error {
+	svc := a.services.WindowsSvcMgr
+	if startup != "" {
+		if err := svc.SetServiceStartupType(ctx, a.name, startup); err != nil {
+			return fmt.Errorf("failed to set startup of %s: %w", a.name, err)
+		}
+	}
+	switch state {
+	case "running":
+		if err := svc.StartService(ctx, a.name); err != nil {
+			return fmt.Errorf("failed to start %s: %w", a.name, err)
+		}
+	case "stopped":
+		if err := svc.StopService(ctx, a.name); err != nil {
+			return fmt.Errorf("failed to stop %s: %w", a.name, err)
+		}
+	}
+	return nil
+}
+
+func formatServiceState(startup, state string) string {
+	var fields []string
+	if startup != "" {
+		fields = append(fields, "startup="+startup)
+	}
+	if state != "" {
+		fields = append(fields, "state="+state)
+	}
+	return strings.Join(fields, ",")
+}
+
+func parseServiceState(value string) (startup, state string) {
+	for _, field := range strings.Split(value, ",") {
+		key, val, _ := strings.Cut(field, "=")
+		switch key {
+		case "startup":
+			startup = val
+		case "state":
+			state = val
+		}
+	}
+	return startup, state
+}
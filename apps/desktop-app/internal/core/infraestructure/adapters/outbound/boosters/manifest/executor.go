@@ -0,0 +1,140 @@
+package manifest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// Executor aplica as ações de um manifesto em ordem e as reverte na ordem inversa.
+// O backup guarda, por ação, o valor anterior ao apply (nil quando não existia).
+type Executor struct {
+	manifest *Manifest
+	actions  []action
+}
+
+func NewExecutor(manifest *Manifest, actions []action) *Executor {
+	return &Executor{manifest: manifest, actions: actions}
+}
+
+func backupKey(i int) string {
+	return "action_" + strconv.Itoa(i)
+}
+
+func (e *Executor) Execute(ctx context.Context, boosterID string) (*entities.BoostApplyResult, error) {
+	backupData := entities.BackupData{
+		"booster_id":       boosterID,
+		"manifest_version": e.manifest.Version,
+		"backup_timestamp": time.Now().Unix(),
+	}
+
+	for i, action := range e.actions {
+		previous, err := action.read(ctx)
+		if err != nil {
+			return e.failApply(ctx, backupData, i, err)
+		}
+		backupData[backupKey(i)] = previous
+
+		if err := action.apply(ctx); err != nil {
+			// A ação não chegou a alterar nada; só as anteriores são desfeitas
+			delete(backupData, backupKey(i))
+			return e.failApply(ctx, backupData, i, err)
+		}
+
+		if i < len(e.actions)-1 {
+			if err := entities.Checkpoint(ctx, e.actions[i+1].target(), backupData); err != nil {
+				return &entities.BoostApplyResult{
+					Success:    false,
+					Message:    fmt.Sprintf("Operação cancelada após %s", action.target()),
+					Error:      err,
+					BackupData: backupData,
+				}, err
+			}
+		}
+	}
+
+	return &entities.BoostApplyResult{
+		Success:    true,
+		Message:    fmt.Sprintf("%d alterações aplicadas", len(e.actions)),
+		BackupData: backupData,
+	}, nil
+}
+
+// failApply desfaz as ações já aplicadas antes de retornar o erro
+func (e *Executor) failApply(ctx context.Context, backupData entities.BackupData, failed int, err error) (*entities.BoostApplyResult, error) {
+	err = fmt.Errorf("%s: %w", e.actions[failed].target(), err)
+	if revertErr := e.revert(ctx, backupData); revertErr != nil {
+		err = errors.Join(err, fmt.Errorf("rollback failed: %w", revertErr))
+	}
+	return &entities.BoostApplyResult{
+		Success: false,
+		Message: fmt.Sprintf("Falha ao aplicar %s", e.actions[failed].target()),
+		Error:   err,
+	}, err
+}
+
+func (e *Executor) Plan(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	reversible := e.manifest.Reversible == nil || *e.manifest.Reversible
+	changes := make([]entities.PlannedChange, 0, len(e.actions))
+	for _, action := range e.actions {
+		current, err := action.read(ctx)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, entities.NewPlannedChange(action.target(), current, action.desired(), reversible))
+	}
+	return changes, nil
+}
+
+func (e *Executor) Verify(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	changes, err := e.Plan(ctx, boosterID)
+	if err != nil {
+		return nil, err
+	}
+	return entities.DriftedChanges(changes), nil
+}
+
+func (e *Executor) Validate(ctx context.Context) error {
+	if len(e.actions) == 0 {
+		return fmt.Errorf("manifest %s has no actions", e.manifest.ID)
+	}
+	return nil
+}
+
+func (e *Executor) CanExecute(ctx context.Context) bool {
+	return true
+}
+
+func (e *Executor) Revert(ctx context.Context, backupData entities.BackupData) (*entities.BoostRevertResult, error) {
+	if err := e.revert(ctx, backupData); err != nil {
+		return &entities.BoostRevertResult{
+			Success: false,
+			Message: "Falha ao restaurar as alterações do manifesto",
+			Error:   err,
+		}, err
+	}
+	return &entities.BoostRevertResult{
+		Success: true,
+		Message: "Alterações do manifesto restauradas",
+	}, nil
+}
+
+// revert restaura, da última para a primeira, as ações que têm valor no backup.
+// Uma falha não interrompe as demais.
+func (e *Executor) revert(ctx context.Context, backupData entities.BackupData) error {
+	var errs []error
+	for i := len(e.actions) - 1; i >= 0; i-- {
+		previous, ok := backupData[backupKey(i)]
+		if !ok {
+			continue
+		}
+		if err := e.actions[i].restore(ctx, previous); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
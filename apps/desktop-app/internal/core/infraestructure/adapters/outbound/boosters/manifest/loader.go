@@ -0,0 +1,153 @@
+package manifest
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/oLenador/mulltbost/internal/config"
+	"github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	booster "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/base"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/trust"
+	"github.com/wailsapp/wails/lib/logger"
+)
+
+// Manifestos distribuídos com o app
+//
+//go:embed manifests
+var embedded embed.FS
+
+// UserDir é onde o usuário pode adicionar seus próprios manifestos
+func UserDir() string {
+	return filepath.Join(xdg.ConfigHome, config.AppDir, "boosters")
+}
+
+// LoadDir lê os manifestos .json, .yaml e .yml de dir, em ordem de nome.
+// Retorna os manifestos válidos e os erros dos inválidos.
+func LoadDir(fsys fs.FS, dir string) ([]*Manifest, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var manifests []*Manifest
+	var errs []error
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(path.Ext(entry.Name())) {
+		case ".json", ".yaml", ".yml":
+		default:
+			continue
+		}
+
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		manifest, err := Parse(entry.Name(), data)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests, errors.Join(errs...)
+}
+
+// loadUserDir lê os manifestos do usuário e descarta os que têm ações fora de
+// userActionTargets. As ações rodam com os privilégios do app, então o
+// diretório e cada arquivo precisam passar por trust.Check antes de serem lidos.
+func loadUserDir(dir string) ([]*Manifest, error) {
+	manifests, err := LoadDir(trustedDir(dir), ".")
+	errs := []error{err}
+
+	allowed := make([]*Manifest, 0, len(manifests))
+	for _, manifest := range manifests {
+		if err := manifest.checkUserActions(); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		allowed = append(allowed, manifest)
+	}
+	return allowed, errors.Join(errs...)
+}
+
+// trustedDir abre os arquivos do diretório só depois de trust.Check
+type trustedDir string
+
+func (d trustedDir) Open(name string) (fs.File, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	path := filepath.Join(string(d), filepath.FromSlash(name))
+	if err := trust.Check(path); err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+// NewBooster cria o booster de um manifesto com o executor genérico
+func NewBooster(manifest *Manifest, services *inbound.ExecutorDepServices) (inbound.BoosterUseCase, error) {
+	return newBooster(manifest, "", services)
+}
+
+func newBooster(manifest *Manifest, root string, services *inbound.ExecutorDepServices) (inbound.BoosterUseCase, error) {
+	actions := make([]action, 0, len(manifest.Actions))
+	for i, spec := range manifest.Actions {
+		action, err := newAction(spec, root, services)
+		if err != nil {
+			return nil, fmt.Errorf("manifest %s action %d: %w", manifest.ID, i, err)
+		}
+		actions = append(actions, action)
+	}
+	return booster.NewBaseBooster(manifest.Entity(actions), manifest.I18n(), NewExecutor(manifest, actions)), nil
+}
+
+// GetAllPlugins cria os boosters dos manifestos embutidos e dos do usuário.
+// Um manifesto embutido inválido é erro de build; os do usuário inválidos, com
+// ações não permitidas ou que outro usuário possa alterar são ignorados com um
+// aviso. Manifestos de outra plataforma viram boosters sem executor, que o
+// catálogo lista como indisponíveis.
+func GetAllPlugins(services *inbound.ExecutorDepServices) ([]inbound.BoosterUseCase, error) {
+	log := logger.NewCustomLogger("[ManifestLoader]")
+
+	manifests, err := LoadDir(embedded, "manifests")
+	if err != nil {
+		return nil, fmt.Errorf("invalid embedded booster manifest: %w", err)
+	}
+
+	userManifests, err := loadUserDir(UserDir())
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Warnf("some user booster manifests were skipped: %v", err)
+	}
+	manifests = append(manifests, userManifests...)
+
+	platform := entities.Platform(runtime.GOOS)
+	var boosters []inbound.BoosterUseCase
+	for _, manifest := range manifests {
+		if !manifest.supports(platform) {
+			boosters = append(boosters, booster.NewBaseBooster(manifest.Entity(nil), manifest.I18n(), nil))
+			continue
+		}
+		b, err := NewBooster(manifest, services)
+		if err != nil {
+			log.Warnf("failed to create booster from manifest: %v", err)
+			continue
+		}
+		boosters = append(boosters, b)
+	}
+	return boosters, nil
+}
@@ -0,0 +1,420 @@
+package manifest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/oLenador/mulltbost/internal/core/domain/services/i18n"
+	"gopkg.in/yaml.v3"
+)
+
+// ActionType é o tipo de alteração que uma ação do manifesto faz no sistema
+type ActionType string
+
+const (
+	ActionSysctl   ActionType = "sysctl"
+	ActionSysfs    ActionType = "sysfs"
+	ActionFileLine ActionType = "file_line"
+	ActionRegistry ActionType = "registry"
+	ActionService  ActionType = "service"
+)
+
+// actionPlatforms define em quais plataformas cada tipo de ação funciona
+var actionPlatforms = map[ActionType]entities.Platform{
+	ActionSysctl:   entities.PlatformLinux,
+	ActionSysfs:    entities.PlatformLinux,
+	ActionFileLine: entities.PlatformLinux,
+	ActionRegistry: entities.PlatformWindows,
+	ActionService:  entities.PlatformWindows,
+}
+
+var ErrInvalidManifest = errors.New("invalid booster manifest")
+
+// Manifest descreve um booster sem código Go: metadados, traduções e as ações
+// que o executor genérico aplica, salva no backup e reverte
+type Manifest struct {
+	ID           string                     `json:"id" yaml:"id"`
+	Version      string                     `json:"version" yaml:"version"`
+	Category     entities.BoosterCategory   `json:"category" yaml:"category"`
+	Level        entities.BoosterLevel      `json:"level" yaml:"level"`
+	Risk         entities.RiskLevel         `json:"risk" yaml:"risk"`
+	Platforms    []entities.Platform        `json:"platforms" yaml:"platforms"`
+	Reversible   *bool                      `json:"reversible,omitempty" yaml:"reversible,omitempty"`
+	Tags         []string                   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Dependencies []string                   `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	Conflicts    []string                   `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
+	DriftPolicy  entities.DriftPolicy       `json:"driftPolicy,omitempty" yaml:"driftPolicy,omitempty"`
+	Requirements Requirements               `json:"requirements,omitempty" yaml:"requirements,omitempty"`
+	Translations map[i18n.Language]Language `json:"translations" yaml:"translations"`
+	Actions      []Action                   `json:"actions" yaml:"actions"`
+}
+
+// Requirements são as condições da máquina para o booster; ver entities.Requirements
+type Requirements struct {
+	Elevation      bool                    `json:"elevation,omitempty" yaml:"elevation,omitempty"`
+	Binaries       []string                `json:"binaries,omitempty" yaml:"binaries,omitempty"`
+	KernelMin      string                  `json:"kernelMin,omitempty" yaml:"kernelMin,omitempty"`
+	KernelMax      string                  `json:"kernelMax,omitempty" yaml:"kernelMax,omitempty"`
+	InitSystems    []entities.InitSystem   `json:"initSystems,omitempty" yaml:"initSystems,omitempty"`
+	SysfsPaths     []string                `json:"sysfsPaths,omitempty" yaml:"sysfsPaths,omitempty"`
+	DistroFamilies []entities.DistroFamily `json:"distroFamilies,omitempty" yaml:"distroFamilies,omitempty"`
+	RebootRequired bool                    `json:"rebootRequired,omitempty" yaml:"rebootRequired,omitempty"`
+}
+
+func (r Requirements) entity() entities.Requirements {
+	return entities.Requirements{
+		Elevation:      r.Elevation,
+		Binaries:       r.Binaries,
+		Kernel:         entities.VersionRange{Min: r.KernelMin, Max: r.KernelMax},
+		InitSystems:    r.InitSystems,
+		SysfsPaths:     r.SysfsPaths,
+		DistroFamilies: r.DistroFamilies,
+		RebootRequired: r.RebootRequired,
+	}
+}
+
+// Language é o nome e a descrição do booster num idioma
+type Language struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+}
+
+// Action é uma alteração do manifesto. Os campos usados dependem do tipo:
+//   - sysctl: Key (ex.: vm.swappiness) e Value
+//   - sysfs: Path (em /sys) e Value
+//   - file_line: Path, Match (regex da linha a substituir) e Line
+//   - registry: Key, Name, Value e ValueType (dword, qword ou string)
+//   - service: Name, Startup (automatic, manual, disabled) e/ou State (running, stopped)
+type Action struct {
+	Type      ActionType  `json:"type" yaml:"type"`
+	Key       string      `json:"key,omitempty" yaml:"key,omitempty"`
+	Path      string      `json:"path,omitempty" yaml:"path,omitempty"`
+	Name      string      `json:"name,omitempty" yaml:"name,omitempty"`
+	Value     interface{} `json:"value,omitempty" yaml:"value,omitempty"`
+	ValueType string      `json:"valueType,omitempty" yaml:"valueType,omitempty"`
+	Match     string      `json:"match,omitempty" yaml:"match,omitempty"`
+	Line      string      `json:"line,omitempty" yaml:"line,omitempty"`
+	Startup   string      `json:"startup,omitempty" yaml:"startup,omitempty"`
+	State     string      `json:"state,omitempty" yaml:"state,omitempty"`
+}
+
+var manifestIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_]*$`)
+
+// Parse lê um manifesto JSON ou YAML conforme a extensão do arquivo e o valida
+func Parse(name string, data []byte) (*Manifest, error) {
+	var manifest Manifest
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&manifest); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidManifest, name, err)
+		}
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&manifest); err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidManifest, name, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %s: unsupported extension", ErrInvalidManifest, name)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &manifest, nil
+}
+
+// Validate confere os campos obrigatórios e se cada ação é suportada nas plataformas declaradas
+func (m *Manifest) Validate() error {
+	var errs []error
+	invalid := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%w: %s", ErrInvalidManifest, fmt.Sprintf(format, args...)))
+	}
+
+	if !manifestIDPattern.MatchString(m.ID) {
+		invalid("id %q must be lowercase letters, digits and underscores", m.ID)
+	}
+	if m.Version == "" {
+		invalid("version is required")
+	}
+	switch m.Category {
+	case entities.CategoryConnection, entities.CategoryFlusher, entities.CategoryFPSBooster,
+		entities.CategoryGames, entities.CategoryPrecision:
+	default:
+		invalid("unknown category %q", m.Category)
+	}
+	switch m.Level {
+	case "", entities.LevelFree, entities.LevelPremium:
+	default:
+		invalid("unknown level %q", m.Level)
+	}
+	switch m.Risk {
+	case "", entities.RiskLow, entities.RiskMedium, entities.RiskHigh:
+	default:
+		invalid("unknown risk %q", m.Risk)
+	}
+	switch m.DriftPolicy {
+	case "", entities.DriftPolicyIgnore, entities.DriftPolicyNotify, entities.DriftPolicyReapply:
+	default:
+		invalid("unknown drift policy %q", m.DriftPolicy)
+	}
+
+	if len(m.Platforms) == 0 {
+		invalid("at least one platform is required")
+	}
+	for _, platform := range m.Platforms {
+		if platform != entities.PlatformLinux && platform != entities.PlatformWindows {
+			invalid("unknown platform %q", platform)
+		}
+	}
+
+	english, ok := m.Translations[i18n.English]
+	if !ok || english.Name == "" || english.Description == "" {
+		invalid("english name and description are required")
+	}
+
+	if len(m.Actions) == 0 {
+		invalid("at least one action is required")
+	}
+	for i, action := range m.Actions {
+		if err := action.validate(m.Platforms); err != nil {
+			invalid("action %d: %v", i, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (a Action) validate(platforms []entities.Platform) error {
+	platform, ok := actionPlatforms[a.Type]
+	if !ok {
+		return fmt.Errorf("unknown action type %q", a.Type)
+	}
+	for _, p := range platforms {
+		if p != platform {
+			return fmt.Errorf("%s actions are not supported on %s", a.Type, p)
+		}
+	}
+
+	switch a.Type {
+	case ActionSysctl:
+		if a.Key == "" || a.Value == nil {
+			return fmt.Errorf("sysctl requires key and value")
+		}
+		if strings.ContainsAny(a.Key, "/ ") || strings.Contains(a.Key, "..") {
+			return fmt.Errorf("invalid sysctl key %q", a.Key)
+		}
+	case ActionSysfs:
+		if a.Value == nil {
+			return fmt.Errorf("sysfs requires path and value")
+		}
+		if !strings.HasPrefix(filepath.Clean(a.Path), "/sys/") {
+			return fmt.Errorf("sysfs path %q must be under /sys", a.Path)
+		}
+	case ActionFileLine:
+		if !filepath.IsAbs(a.Path) || a.Match == "" || a.Line == "" {
+			return fmt.Errorf("file_line requires an absolute path, match and line")
+		}
+		match, err := regexp.Compile(a.Match)
+		if err != nil {
+			return fmt.Errorf("invalid match: %v", err)
+		}
+		if !match.MatchString(a.Line) {
+			return fmt.Errorf("line %q must match %q, otherwise it cannot be reverted", a.Line, a.Match)
+		}
+	case ActionRegistry:
+		if a.Key == "" || a.Name == "" || a.Value == nil {
+			return fmt.Errorf("registry requires key, name and value")
+		}
+		if _, err := registryValue(a.Value, a.ValueType); err != nil {
+			return err
+		}
+	case ActionService:
+		if a.Name == "" || (a.Startup == "" && a.State == "") {
+			return fmt.Errorf("service requires name and startup or state")
+		}
+		switch a.Startup {
+		case "", "automatic", "manual", "disabled":
+		default:
+			return fmt.Errorf("unknown service startup %q", a.Startup)
+		}
+		switch a.State {
+		case "", "running", "stopped":
+		default:
+			return fmt.Errorf("unknown service state %q", a.State)
+		}
+	}
+	return nil
+}
+
+var ErrActionNotAllowed = errors.New("action is not allowed in user manifests")
+
+// userActionTargets limita o que os manifestos do diretório do usuário podem
+// alterar. O app roda elevado e aplica as ações com esse privilégio: sem o
+// limite, um manifesto editaria /etc/sudoers, kernel.core_pattern ou uma chave
+// de registro que executa programas. Chaves sysctl e arquivos sysfs precisam
+// ser exatamente os que os boosters do app ajustam; chaves de registro incluem
+// as subchaves. file_line e service ficam só para os manifestos embutidos.
+var userActionTargets = map[ActionType][]string{
+	ActionSysctl: {
+		"vm.swappiness",
+		"vm.vfs_cache_pressure",
+		"net.core.netdev_max_backlog",
+		"net.core.rmem_default",
+		"net.core.rmem_max",
+		"net.core.somaxconn",
+		"net.core.wmem_default",
+		"net.core.wmem_max",
+		"net.ipv4.tcp_congestion_control",
+		"net.ipv4.tcp_fastopen",
+		"net.ipv4.tcp_rmem",
+		"net.ipv4.tcp_slow_start_after_idle",
+		"net.ipv4.tcp_wmem",
+	},
+	ActionSysfs: {
+		"/sys/kernel/mm/transparent_hugepage/enabled",
+		"/sys/kernel/mm/transparent_hugepage/defrag",
+	},
+	ActionRegistry: {
+		`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Services\Tcpip\Parameters`,
+		`HKEY_LOCAL_MACHINE\SYSTEM\CurrentControlSet\Control\PriorityControl`,
+		`HKEY_LOCAL_MACHINE\SOFTWARE\Microsoft\Windows NT\CurrentVersion\Multimedia\SystemProfile`,
+	},
+}
+
+// registryHives são as abreviações de hive aceitas pelo serviço de registro
+var registryHives = map[string]string{
+	"HKLM": "HKEY_LOCAL_MACHINE",
+	"HKCU": "HKEY_CURRENT_USER",
+	"HKCR": "HKEY_CLASSES_ROOT",
+	"HKU":  "HKEY_USERS",
+	"HKCC": "HKEY_CURRENT_CONFIG",
+}
+
+// checkUserActions confere as ações de um manifesto do usuário contra userActionTargets
+func (m *Manifest) checkUserActions() error {
+	for i, action := range m.Actions {
+		if !action.allowedForUser() {
+			return fmt.Errorf("%w: manifest %s action %d (%s %s)", ErrActionNotAllowed, m.ID, i, action.Type, action.subject())
+		}
+	}
+	return nil
+}
+
+func (a Action) subject() string {
+	switch a.Type {
+	case ActionSysctl:
+		return a.Key
+	case ActionRegistry:
+		return a.Key
+	case ActionService:
+		return a.Name
+	default:
+		return a.Path
+	}
+}
+
+func (a Action) allowedForUser() bool {
+	for _, target := range userActionTargets[a.Type] {
+		switch a.Type {
+		case ActionSysctl:
+			if a.Key == target {
+				return true
+			}
+		case ActionSysfs:
+			if filepath.Clean(a.Path) == target {
+				return true
+			}
+		case ActionRegistry:
+			key := normalizeRegistryKey(a.Key)
+			if strings.EqualFold(key, target) || (len(key) > len(target) && strings.EqualFold(key[:len(target)+1], target+`\`)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeRegistryKey troca a abreviação do hive pelo nome completo e remove
+// barras repetidas, para comparar chaves escritas de formas diferentes
+func normalizeRegistryKey(key string) string {
+	parts := strings.FieldsFunc(key, func(r rune) bool { return r == '\\' })
+	if len(parts) > 0 {
+		if hive, ok := registryHives[strings.ToUpper(parts[0])]; ok {
+			parts[0] = hive
+		}
+	}
+	return strings.Join(parts, `\`)
+}
+
+// Entity converte o manifesto na entidade do booster
+func (m *Manifest) Entity(actions []action) entities.Booster {
+	reversible := true
+	if m.Reversible != nil {
+		reversible = *m.Reversible
+	}
+	level := m.Level
+	if level == "" {
+		level = entities.LevelFree
+	}
+	risk := m.Risk
+	if risk == "" {
+		risk = entities.RiskLow
+	}
+
+	resources := make([]string, 0, len(actions))
+	for _, action := range actions {
+		resources = append(resources, action.resource())
+	}
+
+	return entities.Booster{
+		ID:             m.ID,
+		NameKey:        m.nameKey(),
+		DescriptionKey: m.descriptionKey(),
+		Category:       m.Category,
+		Level:          level,
+		Platform:       m.Platforms,
+		Dependencies:   m.Dependencies,
+		Conflicts:      m.Conflicts,
+		Reversible:     reversible,
+		RiskLevel:      risk,
+		Version:        m.Version,
+		Tags:           m.Tags,
+		DriftPolicy:    m.DriftPolicy,
+		Resources:      resources,
+		Requirements:   m.Requirements.entity(),
+	}
+}
+
+// I18n converte as traduções do manifesto para o formato do serviço de i18n
+func (m *Manifest) I18n() i18n.Translations {
+	translations := make(i18n.Translations, len(m.Translations))
+	for lang, text := range m.Translations {
+		translations[lang] = i18n.Translation{
+			m.nameKey():        text.Name,
+			m.descriptionKey(): text.Description,
+		}
+	}
+	return translations
+}
+
+func (m *Manifest) nameKey() string        { return "booster.manifest." + m.ID + ".name" }
+func (m *Manifest) descriptionKey() string { return "booster.manifest." + m.ID + ".description" }
+
+// supports informa se o manifesto declara a plataforma
+func (m *Manifest) supports(platform entities.Platform) bool {
+	for _, p := range m.Platforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
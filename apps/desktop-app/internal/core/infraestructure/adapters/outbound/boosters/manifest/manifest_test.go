@@ -0,0 +1,234 @@
+package manifest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/oLenador/mulltbost/internal/core/domain/services/i18n"
+)
+
+const testManifest = `
+id: test_linux_tweaks
+version: "1.0.0"
+category: fps-booster
+platforms: [linux]
+translations:
+  en:
+    name: Test tweaks
+    description: Tweaks used by the tests
+actions:
+  - type: sysctl
+    key: vm.swappiness
+    value: 10
+  - type: sysfs
+    path: /sys/kernel/mm/transparent_hugepage/enabled
+    value: never
+  - type: file_line
+    path: /etc/test.conf
+    match: '^options '
+    line: options rotate
+  - type: file_line
+    path: /etc/test.conf
+    match: '^timeout='
+    line: timeout=1
+`
+
+// writeRoot cria os arquivos das ações do testManifest num diretório temporário
+func writeRoot(t *testing.T) string {
+	root := t.TempDir()
+	files := map[string]string{
+		"/proc/sys/vm/swappiness":                     "60\n",
+		"/sys/kernel/mm/transparent_hugepage/enabled": "always [madvise] never\n",
+		"/etc/test.conf":                              "nameserver 1.1.1.1\noptions edns0\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return root
+}
+
+func readFile(t *testing.T, root, name string) string {
+	data, err := os.ReadFile(filepath.Join(root, name))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestParse_ValidatesManifests(t *testing.T) {
+	manifest, err := Parse("tweaks.yaml", []byte(testManifest))
+	require.NoError(t, err)
+	assert.Equal(t, "test_linux_tweaks", manifest.ID)
+	require.Len(t, manifest.Actions, 4)
+
+	entity := manifest.Entity(nil)
+	assert.Equal(t, entities.LevelFree, entity.Level)
+	assert.Equal(t, entities.RiskLow, entity.RiskLevel)
+	assert.True(t, entity.Reversible)
+	assert.Equal(t, "Test tweaks", manifest.I18n()[i18n.English][entity.NameKey])
+
+	_, err = Parse("tweaks.json", []byte(`{"id": "x", "unknown": true}`))
+	assert.ErrorIs(t, err, ErrInvalidManifest)
+
+	invalid := []string{
+		// ação do Windows num manifesto Linux
+		`{"id":"a","version":"1","category":"games","platforms":["linux"],
+		  "translations":{"en":{"name":"A","description":"A"}},
+		  "actions":[{"type":"registry","key":"HKCU\\X","name":"Y","value":1}]}`,
+		// sysfs fora de /sys
+		`{"id":"a","version":"1","category":"games","platforms":["linux"],
+		  "translations":{"en":{"name":"A","description":"A"}},
+		  "actions":[{"type":"sysfs","path":"/etc/passwd","value":"x"}]}`,
+		// linha que não casa com o match não pode ser revertida
+		`{"id":"a","version":"1","category":"games","platforms":["linux"],
+		  "translations":{"en":{"name":"A","description":"A"}},
+		  "actions":[{"type":"file_line","path":"/etc/a","match":"^a=","line":"b=1"}]}`,
+		// sem tradução em inglês
+		`{"id":"a","version":"1","category":"games","platforms":["linux"],
+		  "translations":{"es":{"name":"A","description":"A"}},
+		  "actions":[{"type":"sysctl","key":"vm.swappiness","value":1}]}`,
+	}
+	for _, data := range invalid {
+		_, err := Parse("invalid.json", []byte(data))
+		assert.ErrorIs(t, err, ErrInvalidManifest)
+	}
+
+	embeddedManifests, err := LoadDir(embedded, "manifests")
+	require.NoError(t, err)
+	assert.NotEmpty(t, embeddedManifests)
+}
+
+func TestExecutor_AppliesAndRevertsActions(t *testing.T) {
+	root := writeRoot(t)
+	manifest, err := Parse("tweaks.yaml", []byte(testManifest))
+	require.NoError(t, err)
+	booster, err := newBooster(manifest, root, nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	plan, err := booster.Plan(ctx)
+	require.NoError(t, err)
+	changes := plan.Changes
+	require.Len(t, changes, 4)
+	assert.Equal(t, "60", changes[0].CurrentValue)
+	assert.Equal(t, "madvise", changes[1].CurrentValue)
+	assert.Equal(t, "options edns0", changes[2].CurrentValue)
+	assert.Equal(t, "", changes[3].CurrentValue)
+
+	result, err := booster.Execute(ctx)
+	require.NoError(t, err)
+	require.True(t, result.Success)
+
+	assert.Equal(t, "10\n", readFile(t, root, "/proc/sys/vm/swappiness"))
+	assert.Equal(t, "never", readFile(t, root, "/sys/kernel/mm/transparent_hugepage/enabled"))
+	assert.Equal(t, "nameserver 1.1.1.1\noptions rotate\ntimeout=1\n", readFile(t, root, "/etc/test.conf"))
+
+	verify, err := booster.Verify(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, verify.Drifted)
+
+	revert, err := booster.Revert(ctx, result.BackupData)
+	require.NoError(t, err)
+	require.True(t, revert.Success)
+
+	assert.Equal(t, "60\n", readFile(t, root, "/proc/sys/vm/swappiness"))
+	assert.Equal(t, "madvise", readFile(t, root, "/sys/kernel/mm/transparent_hugepage/enabled"))
+	assert.Equal(t, "nameserver 1.1.1.1\noptions edns0\n", readFile(t, root, "/etc/test.conf"))
+}
+
+func TestExecutor_RollsBackWhenAnActionFails(t *testing.T) {
+	root := writeRoot(t)
+	manifest, err := Parse("tweaks.yaml", []byte(testManifest))
+	require.NoError(t, err)
+	// O sysfs vira diretório: a leitura falha depois do sysctl já aplicado
+	sysfs := filepath.Join(root, "/sys/kernel/mm/transparent_hugepage/enabled")
+	require.NoError(t, os.Remove(sysfs))
+	require.NoError(t, os.Mkdir(sysfs, 0755))
+
+	booster, err := newBooster(manifest, root, nil)
+	require.NoError(t, err)
+
+	result, err := booster.Execute(context.Background())
+	require.Error(t, err)
+	assert.False(t, result.Success)
+	assert.Equal(t, "60\n", readFile(t, root, "/proc/sys/vm/swappiness"))
+	assert.Equal(t, "nameserver 1.1.1.1\noptions edns0\n", readFile(t, root, "/etc/test.conf"))
+}
+
+func TestLoadUserDir_SkipsActionsOutsideTheAllowlist(t *testing.T) {
+	manifest := func(id, platform, action string) string {
+		return `{"id":"` + id + `","version":"1","category":"games","platforms":["` + platform + `"],
+		  "translations":{"en":{"name":"A","description":"A"}},
+		  "actions":[` + action + `]}`
+	}
+	dir := t.TempDir()
+	files := map[string]string{
+		"allowed_sysctl.json":   manifest("allowed_sysctl", "linux", `{"type":"sysctl","key":"vm.swappiness","value":10}`),
+		"allowed_sysfs.json":    manifest("allowed_sysfs", "linux", `{"type":"sysfs","path":"/sys/kernel/mm/transparent_hugepage/enabled","value":"never"}`),
+		"allowed_registry.json": manifest("allowed_registry", "windows", `{"type":"registry","key":"HKLM\\SYSTEM\\CurrentControlSet\\Services\\Tcpip\\Parameters","name":"DefaultTTL","value":64}`),
+		"sudoers.json":          manifest("sudoers", "linux", `{"type":"file_line","path":"/etc/sudoers","match":"^user ","line":"user ALL=(ALL) NOPASSWD: ALL"}`),
+		"core_pattern.json":     manifest("core_pattern", "linux", `{"type":"sysctl","key":"kernel.core_pattern","value":"|/tmp/x"}`),
+		"uevent_helper.json":    manifest("uevent_helper", "linux", `{"type":"sysfs","path":"/sys/block/../kernel/uevent_helper","value":"/tmp/x"}`),
+		"ifeo.json":             manifest("ifeo", "windows", `{"type":"registry","key":"HKLM\\SOFTWARE\\Microsoft\\Windows NT\\CurrentVersion\\Image File Execution Options\\x.exe","name":"Debugger","value":"c:\\x.exe","valueType":"string"}`),
+		"sibling_key.json":      manifest("sibling_key", "windows", `{"type":"registry","key":"HKLM\\SYSTEM\\CurrentControlSet\\Services\\Tcpip\\ParametersX","name":"A","value":1}`),
+		"service.json":          manifest("service", "windows", `{"type":"service","name":"WinDefend","startup":"disabled"}`),
+		"mmap_min_addr.json":    manifest("mmap_min_addr", "linux", `{"type":"sysctl","key":"vm.mmap_min_addr","value":0}`),
+		"ip_forward.json":       manifest("ip_forward", "linux", `{"type":"sysctl","key":"net.ipv4.ip_forward","value":1}`),
+		"rp_filter.json":        manifest("rp_filter", "linux", `{"type":"sysctl","key":"net.ipv4.conf.all.rp_filter","value":0}`),
+		"cpu_online.json":       manifest("cpu_online", "linux", `{"type":"sysfs","path":"/sys/devices/system/cpu/cpu1/online","value":"0"}`),
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	manifests, err := loadUserDir(dir)
+	assert.ErrorIs(t, err, ErrActionNotAllowed)
+
+	var ids []string
+	for _, m := range manifests {
+		ids = append(ids, m.ID)
+	}
+	assert.ElementsMatch(t, []string{"allowed_sysctl", "allowed_sysfs", "allowed_registry"}, ids)
+
+	// os manifestos embutidos estariam dentro do limite
+	embeddedManifests, err := LoadDir(embedded, "manifests")
+	require.NoError(t, err)
+	for _, m := range embeddedManifests {
+		assert.NoError(t, m.checkUserActions())
+	}
+}
+
+func TestLoadUserDir_SkipsManifestsOtherUsersCanChange(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the permission bits are checked on unix")
+	}
+	manifest := func(id string) []byte {
+		return []byte(`{"id":"` + id + `","version":"1","category":"games","platforms":["linux"],
+		  "translations":{"en":{"name":"A","description":"A"}},
+		  "actions":[{"type":"sysctl","key":"vm.swappiness","value":10}]}`)
+	}
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "trusted.json"), manifest("trusted"), 0o644))
+	writable := filepath.Join(dir, "writable.json")
+	require.NoError(t, os.WriteFile(writable, manifest("writable"), 0o644))
+	require.NoError(t, os.Chmod(writable, 0o666))
+
+	manifests, err := loadUserDir(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "writable.json")
+	require.Len(t, manifests, 1)
+	assert.Equal(t, "trusted", manifests[0].ID)
+
+	// um diretório que outros podem alterar é ignorado por inteiro
+	require.NoError(t, os.Chmod(dir, 0o777))
+	manifests, err = loadUserDir(dir)
+	require.Error(t, err)
+	assert.Empty(t, manifests)
+}
@@ -0,0 +1,243 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/lib/logger"
+)
+
+var (
+	ErrPluginTimeout = errors.New("plugin call timed out")
+	ErrPluginCrashed = errors.New("plugin process exited")
+	// ErrPluginDisabled indica que o plugin caiu vezes seguidas e não é mais iniciado
+	ErrPluginDisabled = errors.New("plugin disabled after repeated crashes")
+)
+
+// maxConsecutiveCrashes é quantas quedas seguidas, sem nenhuma chamada bem
+// sucedida entre elas, desativam o plugin até o app reiniciar
+const maxConsecutiveCrashes = 3
+
+// Client fala com um processo de plugin. As chamadas são serializadas; um
+// processo que trava é encerrado no timeout e um que cai é reiniciado na chamada
+// seguinte, sem afetar o app nem os outros plugins.
+type Client struct {
+	path string
+	args []string
+	// check roda antes de cada início do processo; o host confere as permissões do executável
+	check   func(path string) error
+	mu      sync.Mutex
+	proc    *process
+	nextID  int64
+	crashes int
+	logger  *logger.CustomLogger
+}
+
+type process struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	responses chan Response
+	done      chan struct{}
+}
+
+func NewClient(path string, args ...string) *Client {
+	return &Client{
+		path:   path,
+		args:   args,
+		logger: logger.NewCustomLogger("[Plugin " + path + "]"),
+	}
+}
+
+// Call envia a requisição e decodifica o resultado em result. Sem deadline no
+// contexto, vale timeout.
+func (c *Client) Call(ctx context.Context, method string, params interface{}, result interface{}, timeout time.Duration) error {
+	if _, ok := ctx.Deadline(); !ok && timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.crashes >= maxConsecutiveCrashes {
+		return ErrPluginDisabled
+	}
+	if c.proc == nil {
+		if err := c.start(); err != nil {
+			return err
+		}
+	}
+	proc := c.proc
+
+	c.nextID++
+	request := Request{JSONRPC: "2.0", ID: c.nextID, Method: method}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s params: %w", method, err)
+		}
+		request.Params = data
+	}
+	line, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	if _, err := proc.stdin.Write(append(line, '\n')); err != nil {
+		c.crashed()
+		return fmt.Errorf("%w: %s: %v", ErrPluginCrashed, method, err)
+	}
+
+	for {
+		select {
+		case response := <-proc.responses:
+			if response.ID != request.ID {
+				continue
+			}
+			return c.decode(method, response, result)
+		case <-proc.done:
+			// A resposta pode ter chegado antes do processo sair
+			select {
+			case response := <-proc.responses:
+				if response.ID == request.ID {
+					c.crashed()
+					return c.decode(method, response, result)
+				}
+			default:
+			}
+			c.crashed()
+			return fmt.Errorf("%w during %s", ErrPluginCrashed, method)
+		case <-ctx.Done():
+			// O processo pode estar travado: encerra para a próxima chamada começar limpa
+			c.kill()
+			return fmt.Errorf("%w: %s: %w", ErrPluginTimeout, method, ctx.Err())
+		}
+	}
+}
+
+func (c *Client) decode(method string, response Response, result interface{}) error {
+	c.crashes = 0
+	if response.Error != nil {
+		return fmt.Errorf("%s: %w", method, response.Error)
+	}
+	if result == nil || len(response.Result) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return fmt.Errorf("invalid %s result: %w", method, err)
+	}
+	return nil
+}
+
+func (c *Client) start() error {
+	if c.check != nil {
+		if err := c.check(c.path); err != nil {
+			return fmt.Errorf("refusing to start plugin: %w", err)
+		}
+	}
+	cmd := exec.Command(c.path, c.args...)
+	cmd.Stderr = &stderrLogger{logger: c.logger}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start plugin %s: %w", c.path, err)
+	}
+
+	proc := &process{
+		cmd:       cmd,
+		stdin:     stdin,
+		responses: make(chan Response, 1),
+		done:      make(chan struct{}),
+	}
+	go proc.read(stdout, c.logger)
+	c.proc = proc
+	return nil
+}
+
+// read entrega as respostas do stdout até o processo sair
+func (p *process) read(stdout io.Reader, log *logger.CustomLogger) {
+	defer func() {
+		_ = p.cmd.Wait()
+		close(p.done)
+	}()
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	for scanner.Scan() {
+		var response Response
+		if err := json.Unmarshal(scanner.Bytes(), &response); err != nil {
+			log.Errorf("invalid response from plugin: %v", err)
+			continue
+		}
+		select {
+		case p.responses <- response:
+		case <-time.After(time.Second):
+			// Resposta de uma chamada que já desistiu
+		}
+	}
+}
+
+func (c *Client) crashed() {
+	c.crashes++
+	c.kill()
+	c.logger.Errorf("plugin exited unexpectedly (%d/%d)", c.crashes, maxConsecutiveCrashes)
+}
+
+func (c *Client) kill() {
+	if c.proc == nil {
+		return
+	}
+	_ = c.proc.stdin.Close()
+	if c.proc.cmd.Process != nil {
+		_ = c.proc.cmd.Process.Kill()
+	}
+	<-c.proc.done
+	c.proc = nil
+}
+
+// Close encerra o processo; o plugin deve sair ao fim do stdin
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.proc == nil {
+		return nil
+	}
+	_ = c.proc.stdin.Close()
+	select {
+	case <-c.proc.done:
+	case <-time.After(2 * time.Second):
+		_ = c.proc.cmd.Process.Kill()
+		<-c.proc.done
+	}
+	c.proc = nil
+	return nil
+}
+
+// stderrLogger repassa o stderr do plugin para o log do app
+type stderrLogger struct {
+	logger *logger.CustomLogger
+}
+
+func (w *stderrLogger) Write(p []byte) (int, error) {
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		if line != "" {
+			w.logger.Info(line)
+		}
+	}
+	return len(p), nil
+}
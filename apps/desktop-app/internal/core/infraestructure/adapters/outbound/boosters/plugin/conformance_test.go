@@ -0,0 +1,213 @@
+package plugin_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/oLenador/mulltbost/internal/core/domain/services/i18n"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/plugin"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/plugin/reference"
+)
+
+const (
+	// externalPluginEnv aponta o teste de conformidade para o executável de um plugin:
+	// MULLTBOOST_PLUGIN=/caminho/do/plugin go test ./.../boosters/plugin -run Conformance
+	externalPluginEnv = "MULLTBOOST_PLUGIN"
+	// helperModeEnv faz o binário de teste se comportar como plugin
+	helperModeEnv = "MULLTBOOST_PLUGIN_HELPER"
+)
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(helperModeEnv) {
+	case "":
+		os.Exit(m.Run())
+	case "hang":
+		serve(hangingBooster{reference.New()})
+	case "crash":
+		serve(crashingBooster{reference.New()})
+	case "params":
+		serve(paramsBooster{reference.New()})
+	default:
+		serve(reference.New())
+	}
+}
+
+func serve(handler plugin.Handler) {
+	if err := plugin.Serve(handler, os.Stdin, os.Stdout); err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// hangingBooster nunca termina o execute
+type hangingBooster struct{ *reference.Booster }
+
+func (hangingBooster) Execute(ctx context.Context) (*plugin.ExecuteResult, error) {
+	select {}
+}
+
+// crashingBooster encerra o processo no execute
+type crashingBooster struct{ *reference.Booster }
+
+func (crashingBooster) Execute(ctx context.Context) (*plugin.ExecuteResult, error) {
+	os.Exit(3)
+	return nil, nil
+}
+
+// paramsBooster declara um parâmetro e devolve o valor recebido no plan e no execute
+type paramsBooster struct{ *reference.Booster }
+
+var levelParam = entities.ParamSchema{
+	{Key: "level", NameKey: "level", Type: entities.ParamInt, Default: 1, Min: 1, Max: 10},
+}
+
+func (b paramsBooster) Metadata() plugin.Metadata {
+	metadata := b.Booster.Metadata()
+	metadata.Booster.Params = levelParam
+	return metadata
+}
+
+func (paramsBooster) Plan(ctx context.Context) ([]plugin.Change, error) {
+	level := entities.ParamsFromContext(ctx, levelParam).Int("level")
+	return []plugin.Change{{Resource: "level", NewValue: strconv.Itoa(level)}}, nil
+}
+
+func (paramsBooster) Execute(ctx context.Context) (*plugin.ExecuteResult, error) {
+	level := entities.ParamsFromContext(ctx, levelParam).Int("level")
+	return &plugin.ExecuteResult{BackupData: map[string]interface{}{"level": level}}, nil
+}
+
+// helperPlugin usa o próprio binário de teste como plugin no modo pedido
+func helperPlugin(t *testing.T, mode string) string {
+	t.Setenv(helperModeEnv, mode)
+	t.Setenv(reference.FileEnv, filepath.Join(t.TempDir(), "reference.conf"))
+	return os.Args[0]
+}
+
+func loadPlugin(t *testing.T, path string, options plugin.Options) inbound.BoosterUseCase {
+	host := plugin.NewHost(t.TempDir(), options)
+	t.Cleanup(host.Close)
+	b, err := host.Load(context.Background(), path)
+	require.NoError(t, err)
+	return b
+}
+
+func TestConformance(t *testing.T) {
+	path := os.Getenv(externalPluginEnv)
+	if path == "" {
+		path = helperPlugin(t, "reference")
+	}
+	b := loadPlugin(t, path, plugin.DefaultOptions())
+	ctx := context.Background()
+
+	entity := b.GetEntity()
+	assert.NotEmpty(t, b.GetEntityDto(i18n.English).Name, "metadata must include an english name")
+	require.NoError(t, b.Validate(ctx))
+	require.True(t, b.CanApply(ctx), "plugin must be able to apply in the test environment")
+
+	before, err := b.Plan(ctx)
+	require.NoError(t, err)
+
+	result, err := b.Execute(ctx)
+	require.NoError(t, err)
+	require.True(t, result.Success)
+
+	verify, err := b.Verify(ctx)
+	require.NoError(t, err)
+	assert.True(t, verify.InEffect, "verify must report no drift right after execute: %v", verify.Drifted)
+
+	if !entity.Reversible {
+		return
+	}
+
+	// O app persiste o backup como JSON antes de reverter
+	data, err := json.Marshal(result.BackupData)
+	require.NoError(t, err)
+	var backup entities.BackupData
+	require.NoError(t, json.Unmarshal(data, &backup))
+
+	revert, err := b.Revert(ctx, backup)
+	require.NoError(t, err)
+	require.True(t, revert.Success)
+
+	after, err := b.Plan(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, before.Changes, after.Changes, "revert must restore the state seen by plan before execute")
+
+	var rpcErr *plugin.RPCError
+	client := plugin.NewClient(path)
+	defer client.Close()
+	err = client.Call(ctx, "booster.unknown", nil, nil, time.Second)
+	require.True(t, errors.As(err, &rpcErr), "unknown methods must return a JSON-RPC error, got %v", err)
+	assert.Equal(t, plugin.CodeMethodNotFound, rpcErr.Code)
+}
+
+func TestClient_KillsHungPluginOnTimeout(t *testing.T) {
+	b := loadPlugin(t, helperPlugin(t, "hang"), plugin.DefaultOptions())
+	ctx := context.Background()
+
+	// Como no processador, o timeout da tentativa chega pelo contexto
+	execCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	_, err := b.Execute(execCtx)
+	require.ErrorIs(t, err, plugin.ErrPluginTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// o processo travado foi encerrado e o próximo é iniciado na chamada seguinte
+	plan, err := b.Plan(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, plan.Changes)
+}
+
+func TestClient_IsolatesCrashes(t *testing.T) {
+	b := loadPlugin(t, helperPlugin(t, "crash"), plugin.DefaultOptions())
+	ctx := context.Background()
+
+	_, err := b.Execute(ctx)
+	require.ErrorIs(t, err, plugin.ErrPluginCrashed)
+	assert.True(t, entities.IsTransient(err), "a crash is retried with a fresh process")
+
+	plan, err := b.Plan(ctx)
+	require.NoError(t, err, "the plugin restarts after a crash")
+	assert.NotEmpty(t, plan.Changes)
+
+	for i := 0; i < 4; i++ {
+		_, err = b.Execute(ctx)
+	}
+	assert.ErrorIs(t, err, plugin.ErrPluginDisabled)
+}
+
+func TestClient_SendsConfiguredParams(t *testing.T) {
+	b := loadPlugin(t, helperPlugin(t, "params"), plugin.DefaultOptions())
+	params := b.GetEntity().Params
+	require.Len(t, params, 1)
+	assert.Equal(t, "level", params[0].Key)
+
+	// o processador coloca no contexto os valores configurados do booster
+	ctx := entities.WithBoosterParams(context.Background(), entities.BoosterParams{"level": 7})
+
+	plan, err := b.Plan(ctx)
+	require.NoError(t, err)
+	require.Len(t, plan.Changes, 1)
+	assert.Equal(t, "7", plan.Changes[0].NewValue)
+
+	result, err := b.Execute(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, result.BackupData["level"])
+
+	// sem valores no contexto o plugin recebe os padrões do schema
+	plan, err = b.Plan(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "1", plan.Changes[0].NewValue)
+}
@@ -0,0 +1,163 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// Executor implementa o PlatformExecutor repassando as chamadas ao plugin
+type Executor struct {
+	client         *Client
+	metadata       Metadata
+	callTimeout    time.Duration
+	executeTimeout time.Duration
+
+	mu sync.Mutex
+	// verified indica que o processo já confirmou os metadados da descoberta
+	verified bool
+}
+
+func NewExecutor(client *Client, metadata Metadata, options Options) *Executor {
+	executeTimeout := options.ExecuteTimeout
+	if seconds := metadata.Booster.TimeoutSeconds; seconds > 0 {
+		executeTimeout = time.Duration(seconds) * time.Second
+	}
+	return &Executor{
+		client:         client,
+		metadata:       metadata,
+		callTimeout:    options.CallTimeout,
+		executeTimeout: executeTimeout,
+	}
+}
+
+// call repassa a chamada ao plugin. Na primeira, confere que o processo declara
+// os mesmos metadados do arquivo lido na descoberta.
+func (e *Executor) call(ctx context.Context, method string, params interface{}, result interface{}, timeout time.Duration) error {
+	if err := e.verifyMetadata(ctx); err != nil {
+		return err
+	}
+	return e.client.Call(ctx, method, params, result, timeout)
+}
+
+// params retorna os valores que o processador resolveu e colocou no contexto,
+// como os boosters embutidos os leem; sem schema o plugin não recebe parâmetros
+func (e *Executor) params(ctx context.Context) interface{} {
+	schema := e.metadata.Booster.Params
+	if len(schema) == 0 {
+		return nil
+	}
+	return ExecuteParams{Params: entities.ParamsFromContext(ctx, schema)}
+}
+
+func (e *Executor) verifyMetadata(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.verified {
+		return nil
+	}
+
+	var metadata Metadata
+	if err := e.client.Call(ctx, MethodMetadata, nil, &metadata, e.callTimeout); err != nil {
+		return err
+	}
+	if !e.metadata.sameBooster(metadata) {
+		e.client.Close()
+		return fmt.Errorf("%w: plugin reports %s %s, its metadata file declares %s %s", ErrInvalidMetadata,
+			metadata.Booster.ID, metadata.Booster.Version, e.metadata.Booster.ID, e.metadata.Booster.Version)
+	}
+	e.verified = true
+	return nil
+}
+
+func (e *Executor) Execute(ctx context.Context, boosterID string) (*entities.BoostApplyResult, error) {
+	var result ExecuteResult
+	if err := e.call(ctx, MethodExecute, e.params(ctx), &result, e.executeTimeout); err != nil {
+		err = classify(err)
+		return &entities.BoostApplyResult{
+			Success: false,
+			Message: fmt.Sprintf("Plugin %s falhou ao aplicar", boosterID),
+			Error:   err,
+		}, err
+	}
+	return &entities.BoostApplyResult{
+		Success:    true,
+		Message:    result.Message,
+		BackupData: result.BackupData,
+	}, nil
+}
+
+func (e *Executor) Plan(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	if !e.metadata.Has(CapabilityPlan) {
+		return nil, nil
+	}
+	return e.changes(ctx, MethodPlan)
+}
+
+func (e *Executor) Verify(ctx context.Context, boosterID string) ([]entities.PlannedChange, error) {
+	// Sem verify o app não tem como saber se o booster saiu de efeito
+	if !e.metadata.Has(CapabilityVerify) {
+		return nil, nil
+	}
+	return e.changes(ctx, MethodVerify)
+}
+
+func (e *Executor) changes(ctx context.Context, method string) ([]entities.PlannedChange, error) {
+	var result ChangesResult
+	if err := e.call(ctx, method, e.params(ctx), &result, e.callTimeout); err != nil {
+		return nil, classify(err)
+	}
+	changes := make([]entities.PlannedChange, len(result.Changes))
+	for i, change := range result.Changes {
+		changes[i] = entities.PlannedChange{
+			Resource:     change.Resource,
+			CurrentValue: change.CurrentValue,
+			NewValue:     change.NewValue,
+			Reversible:   change.Reversible,
+		}
+	}
+	return changes, nil
+}
+
+func (e *Executor) Validate(ctx context.Context) error {
+	return classify(e.call(ctx, MethodValidate, e.params(ctx), nil, e.callTimeout))
+}
+
+func (e *Executor) CanExecute(ctx context.Context) bool {
+	var result CanExecuteResult
+	if err := e.call(ctx, MethodCanExecute, e.params(ctx), &result, e.callTimeout); err != nil {
+		return false
+	}
+	return result.CanExecute
+}
+
+func (e *Executor) Revert(ctx context.Context, backupData entities.BackupData) (*entities.BoostRevertResult, error) {
+	if !e.metadata.Has(CapabilityRevert) {
+		err := fmt.Errorf("plugin %s does not support revert", e.metadata.Booster.ID)
+		return &entities.BoostRevertResult{Success: false, Message: err.Error(), Error: err}, err
+	}
+
+	var result RevertResult
+	if err := e.call(ctx, MethodRevert, RevertParams{BackupData: backupData}, &result, e.executeTimeout); err != nil {
+		err = classify(err)
+		return &entities.BoostRevertResult{
+			Success: false,
+			Message: fmt.Sprintf("Plugin %s falhou ao reverter", e.metadata.Booster.ID),
+			Error:   err,
+		}, err
+	}
+	return &entities.BoostRevertResult{Success: true, Message: result.Message}, nil
+}
+
+// classify marca a queda do processo como temporária: o plugin é reiniciado na
+// próxima tentativa
+func classify(err error) error {
+	if errors.Is(err, ErrPluginCrashed) {
+		return entities.NewTransientError(err)
+	}
+	return err
+}
@@ -0,0 +1,288 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/oLenador/mulltbost/internal/config"
+	"github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/oLenador/mulltbost/internal/core/domain/services/i18n"
+	booster "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/base"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/trust"
+	"github.com/wailsapp/wails/lib/logger"
+)
+
+var ErrInvalidMetadata = errors.New("invalid plugin metadata")
+
+// Options controla os timeouts das chamadas aos plugins
+type Options struct {
+	// CallTimeout limita metadata, validate, canExecute, plan e verify
+	CallTimeout time.Duration
+	// ExecuteTimeout limita execute e revert quando o plugin não declara o seu
+	ExecuteTimeout time.Duration
+}
+
+func DefaultOptions() Options {
+	return Options{
+		CallTimeout:    10 * time.Second,
+		ExecuteTimeout: 2 * time.Minute,
+	}
+}
+
+// Dir é onde o app procura os executáveis de plugin
+func Dir() string {
+	return filepath.Join(xdg.DataHome, config.AppDir, "plugins")
+}
+
+// Host descobre os plugins de um diretório e mantém seus processos
+type Host struct {
+	dir     string
+	options Options
+	mu      sync.Mutex
+	clients []*Client
+	logger  *logger.CustomLogger
+}
+
+func NewHost(dir string, options Options) *Host {
+	return &Host{
+		dir:     dir,
+		options: options,
+		logger:  logger.NewCustomLogger("[PluginHost]"),
+	}
+}
+
+// GetAllPlugins cria um booster para cada executável do diretório que tem o
+// arquivo de metadados MetadataPath. Nenhum plugin é iniciado aqui: o processo
+// só sobe na primeira chamada ao booster. O diretório, os executáveis e os
+// metadados precisam passar por trust.Check, porque os plugins rodam com os
+// privilégios do app. Plugins que falham são ignorados com um aviso; um
+// diretório inexistente não tem plugins.
+func (h *Host) GetAllPlugins(ctx context.Context) []inbound.BoosterUseCase {
+	entries, err := os.ReadDir(h.dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			h.logger.Errorf("failed to read plugin directory %s: %v", h.dir, err)
+		}
+		return nil
+	}
+	if err := trust.Check(h.dir); err != nil {
+		h.logger.Errorf("ignoring plugin directory: %v", err)
+		return nil
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var boosters []inbound.BoosterUseCase
+	for _, entry := range entries {
+		path := filepath.Join(h.dir, entry.Name())
+		if !isExecutable(path) {
+			continue
+		}
+
+		b, err := h.discover(path)
+		if err != nil {
+			h.logger.Warnf("skipping plugin %s: %v", entry.Name(), err)
+			continue
+		}
+		boosters = append(boosters, b)
+	}
+	return boosters
+}
+
+// MetadataPath é o arquivo com a resposta de booster.metadata do executável,
+// lido na descoberta no lugar de iniciar o plugin
+func MetadataPath(executable string) string {
+	if strings.EqualFold(filepath.Ext(executable), ".exe") {
+		executable = strings.TrimSuffix(executable, filepath.Ext(executable))
+	}
+	return executable + ".json"
+}
+
+// discover cria o booster a partir do arquivo de metadados, sem iniciar o plugin
+func (h *Host) discover(path string) (inbound.BoosterUseCase, error) {
+	metadataPath := MetadataPath(path)
+	for _, file := range []string{path, metadataPath} {
+		if err := trust.Check(file); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := os.ReadFile(metadataPath)
+	if err != nil {
+		return nil, err
+	}
+	var metadata Metadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidMetadata, metadataPath, err)
+	}
+
+	client := NewClient(path)
+	client.check = trust.Check
+	return h.newBooster(client, metadata, false)
+}
+
+// Load inicia o plugin e lê os metadados do próprio processo. É o caminho do
+// teste de conformidade; o app descobre os plugins com GetAllPlugins.
+func (h *Host) Load(ctx context.Context, path string, args ...string) (inbound.BoosterUseCase, error) {
+	client := NewClient(path, args...)
+
+	var metadata Metadata
+	if err := client.Call(ctx, MethodMetadata, nil, &metadata, h.options.CallTimeout); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return h.newBooster(client, metadata, true)
+}
+
+// newBooster valida os metadados e cria o booster. Um plugin de outra
+// plataforma é encerrado e vira um booster sem executor, que o catálogo lista
+// como indisponível. verified indica que os metadados vieram do processo.
+func (h *Host) newBooster(client *Client, metadata Metadata, verified bool) (inbound.BoosterUseCase, error) {
+	if err := metadata.Validate(); err != nil {
+		client.Close()
+		return nil, err
+	}
+	if !metadata.supports(entities.Platform(runtime.GOOS)) {
+		client.Close()
+		return booster.NewBaseBooster(metadata.Entity(), metadata.I18n(), nil), nil
+	}
+
+	h.mu.Lock()
+	h.clients = append(h.clients, client)
+	h.mu.Unlock()
+
+	executor := NewExecutor(client, metadata, h.options)
+	executor.verified = verified
+	return booster.NewBaseBooster(metadata.Entity(), metadata.I18n(), executor), nil
+}
+
+// Close encerra os processos de todos os plugins carregados
+func (h *Host) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, client := range h.clients {
+		client.Close()
+	}
+	h.clients = nil
+}
+
+var boosterIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_]*$`)
+
+// Validate confere a versão do protocolo e os campos obrigatórios do booster
+func (m Metadata) Validate() error {
+	if m.ProtocolVersion != ProtocolVersion {
+		return fmt.Errorf("%w: protocol version %d is not supported, expected %d", ErrInvalidMetadata, m.ProtocolVersion, ProtocolVersion)
+	}
+	info := m.Booster
+	if !boosterIDPattern.MatchString(info.ID) {
+		return fmt.Errorf("%w: id %q must be lowercase letters, digits and underscores", ErrInvalidMetadata, info.ID)
+	}
+	if info.Version == "" || info.Category == "" || len(info.Platforms) == 0 {
+		return fmt.Errorf("%w: version, category and platforms are required", ErrInvalidMetadata)
+	}
+	if english, ok := info.Translations[i18n.English]; !ok || english.Name == "" {
+		return fmt.Errorf("%w: english name is required", ErrInvalidMetadata)
+	}
+	if info.Reversible && !m.Has(CapabilityRevert) {
+		return fmt.Errorf("%w: reversible boosters must declare the revert capability", ErrInvalidMetadata)
+	}
+	for _, spec := range info.Params {
+		if _, err := spec.Normalize(spec.Default); err != nil {
+			return fmt.Errorf("%w: default of parameter %s: %v", ErrInvalidMetadata, spec.Key, err)
+		}
+	}
+	return nil
+}
+
+// sameBooster compara os metadados da descoberta com os que o processo declara
+func (m Metadata) sameBooster(other Metadata) bool {
+	if m.ProtocolVersion != other.ProtocolVersion || m.Booster.ID != other.Booster.ID ||
+		m.Booster.Version != other.Booster.Version || len(m.Capabilities) != len(other.Capabilities) {
+		return false
+	}
+	for _, capability := range m.Capabilities {
+		if !other.Has(capability) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m Metadata) supports(platform entities.Platform) bool {
+	for _, p := range m.Booster.Platforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
+
+// Entity converte os metadados na entidade do booster
+func (m Metadata) Entity() entities.Booster {
+	info := m.Booster
+	level := info.Level
+	if level == "" {
+		level = entities.LevelFree
+	}
+	risk := info.Risk
+	if risk == "" {
+		risk = entities.RiskMedium
+	}
+	entity := entities.Booster{
+		ID:             info.ID,
+		NameKey:        m.nameKey(),
+		DescriptionKey: m.descriptionKey(),
+		Category:       info.Category,
+		Level:          level,
+		Platform:       info.Platforms,
+		Dependencies:   info.Dependencies,
+		Conflicts:      info.Conflicts,
+		Reversible:     info.Reversible,
+		RiskLevel:      risk,
+		Version:        info.Version,
+		Tags:           append([]string{"plugin"}, info.Tags...),
+		Resources:      info.Resources,
+		Params:         info.Params,
+	}
+	if info.TimeoutSeconds > 0 {
+		entity.Timeout = time.Duration(info.TimeoutSeconds) * time.Second
+	}
+	return entity
+}
+
+// I18n converte as traduções do plugin para o formato do serviço de i18n
+func (m Metadata) I18n() i18n.Translations {
+	translations := make(i18n.Translations, len(m.Booster.Translations))
+	for lang, text := range m.Booster.Translations {
+		translations[lang] = i18n.Translation{
+			m.nameKey():        text.Name,
+			m.descriptionKey(): text.Description,
+		}
+	}
+	return translations
+}
+
+func (m Metadata) nameKey() string        { return "booster.plugin." + m.Booster.ID + ".name" }
+func (m Metadata) descriptionKey() string { return "booster.plugin." + m.Booster.ID + ".description" }
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(path), ".exe")
+	}
+	return info.Mode().Perm()&0111 != 0
+}
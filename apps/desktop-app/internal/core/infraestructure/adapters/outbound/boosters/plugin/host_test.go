@@ -0,0 +1,102 @@
+package plugin_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/plugin"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/plugin/reference"
+)
+
+// installPlugin grava em dir um script que registra cada início do plugin e
+// executa o binário de teste como o plugin de referência, junto com o arquivo
+// de metadados. Retorna o executável e o arquivo que marca o início.
+func installPlugin(t *testing.T, dir string, metadata plugin.Metadata) (string, string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the plugin script needs a unix shell")
+	}
+	binary := helperPlugin(t, "reference")
+	started := filepath.Join(t.TempDir(), "started")
+
+	path := filepath.Join(dir, "reference")
+	script := "#!/bin/sh\necho started >> '" + started + "'\nexec '" + binary + "'\n"
+	require.NoError(t, os.WriteFile(path, []byte(script), 0o755))
+
+	data, err := json.Marshal(metadata)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(plugin.MetadataPath(path), data, 0o644))
+	return path, started
+}
+
+func TestHost_StartsPluginsOnlyWhenUsed(t *testing.T) {
+	dir := t.TempDir()
+	_, started := installPlugin(t, dir, reference.New().Metadata())
+	host := plugin.NewHost(dir, plugin.DefaultOptions())
+	t.Cleanup(host.Close)
+
+	boosters := host.GetAllPlugins(context.Background())
+	require.Len(t, boosters, 1)
+	assert.Equal(t, reference.BoosterID, boosters[0].GetEntity().ID)
+	assert.NoFileExists(t, started, "discovery must not start the plugin")
+
+	plan, err := boosters[0].Plan(context.Background())
+	require.NoError(t, err)
+	assert.NotEmpty(t, plan.Changes)
+	assert.FileExists(t, started)
+}
+
+func TestHost_SkipsPluginsOtherUsersCanChange(t *testing.T) {
+	for name, tamper := range map[string]func(t *testing.T, dir, path string){
+		"writable directory": func(t *testing.T, dir, path string) {
+			require.NoError(t, os.Chmod(dir, 0o777))
+		},
+		"writable executable": func(t *testing.T, dir, path string) {
+			require.NoError(t, os.Chmod(path, 0o775))
+		},
+		"writable metadata": func(t *testing.T, dir, path string) {
+			require.NoError(t, os.Chmod(plugin.MetadataPath(path), 0o666))
+		},
+		"executable of another user": func(t *testing.T, dir, path string) {
+			if os.Geteuid() != 0 {
+				t.Skip("changing the owner needs root")
+			}
+			require.NoError(t, os.Chown(path, 12345, 12345))
+		},
+		"missing metadata": func(t *testing.T, dir, path string) {
+			require.NoError(t, os.Remove(plugin.MetadataPath(path)))
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			path, started := installPlugin(t, dir, reference.New().Metadata())
+			tamper(t, dir, path)
+
+			host := plugin.NewHost(dir, plugin.DefaultOptions())
+			t.Cleanup(host.Close)
+			assert.Empty(t, host.GetAllPlugins(context.Background()))
+			assert.NoFileExists(t, started)
+		})
+	}
+}
+
+func TestHost_RefusesPluginThatDoesNotMatchItsMetadata(t *testing.T) {
+	dir := t.TempDir()
+	metadata := reference.New().Metadata()
+	metadata.Booster.Version = "2.0.0"
+	installPlugin(t, dir, metadata)
+	host := plugin.NewHost(dir, plugin.DefaultOptions())
+	t.Cleanup(host.Close)
+
+	boosters := host.GetAllPlugins(context.Background())
+	require.Len(t, boosters, 1)
+
+	_, err := boosters[0].Plan(context.Background())
+	assert.ErrorIs(t, err, plugin.ErrInvalidMetadata)
+}
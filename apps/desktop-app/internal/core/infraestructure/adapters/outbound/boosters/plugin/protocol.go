@@ -0,0 +1,152 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/oLenador/mulltbost/internal/core/domain/services/i18n"
+)
+
+// ProtocolVersion é a versão do protocolo entre o app e os plugins. Um plugin
+// que declara outra versão não é carregado.
+const ProtocolVersion = 1
+
+// Métodos JSON-RPC que o plugin atende. Cada linha do stdin é uma requisição e
+// cada linha do stdout a resposta correspondente.
+const (
+	MethodMetadata   = "booster.metadata"
+	MethodValidate   = "booster.validate"
+	MethodCanExecute = "booster.canExecute"
+	MethodPlan       = "booster.plan"
+	MethodVerify     = "booster.verify"
+	MethodExecute    = "booster.execute"
+	MethodRevert     = "booster.revert"
+)
+
+// Capability declara um método opcional que o plugin implementa
+type Capability string
+
+const (
+	CapabilityPlan   Capability = "plan"
+	CapabilityVerify Capability = "verify"
+	CapabilityRevert Capability = "revert"
+)
+
+// Códigos de erro do JSON-RPC 2.0
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int64           `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int64           `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("plugin error %d: %s", e.Code, e.Message)
+}
+
+// Metadata é a resposta de booster.metadata
+type Metadata struct {
+	ProtocolVersion int          `json:"protocolVersion"`
+	Booster         BoosterInfo  `json:"booster"`
+	Capabilities    []Capability `json:"capabilities"`
+}
+
+// BoosterInfo descreve o booster do plugin; os textos ficam em Translations
+type BoosterInfo struct {
+	ID           string                     `json:"id"`
+	Version      string                     `json:"version"`
+	Category     entities.BoosterCategory   `json:"category"`
+	Level        entities.BoosterLevel      `json:"level,omitempty"`
+	Risk         entities.RiskLevel         `json:"risk,omitempty"`
+	Platforms    []entities.Platform        `json:"platforms"`
+	Reversible   bool                       `json:"reversible"`
+	Tags         []string                   `json:"tags,omitempty"`
+	Dependencies []string                   `json:"dependencies,omitempty"`
+	Conflicts    []string                   `json:"conflicts,omitempty"`
+	Resources    []string                   `json:"resources,omitempty"`
+	Translations map[i18n.Language]Language `json:"translations"`
+	// TimeoutSeconds limita execute e revert; zero usa o padrão do app
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+	// Params são os parâmetros configuráveis; os valores chegam em ExecuteParams
+	Params entities.ParamSchema `json:"params,omitempty"`
+}
+
+// Language é o nome e a descrição do booster num idioma
+type Language struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Has informa se o plugin declarou a capacidade
+func (m Metadata) Has(capability Capability) bool {
+	for _, c := range m.Capabilities {
+		if c == capability {
+			return true
+		}
+	}
+	return false
+}
+
+// CanExecuteResult é a resposta de booster.canExecute
+type CanExecuteResult struct {
+	CanExecute bool   `json:"canExecute"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+// Change é uma alteração planejada, retornada por booster.plan e booster.verify
+type Change struct {
+	Resource     string `json:"resource"`
+	CurrentValue string `json:"currentValue"`
+	NewValue     string `json:"newValue"`
+	Reversible   bool   `json:"reversible"`
+}
+
+// ChangesResult é a resposta de booster.plan e booster.verify
+type ChangesResult struct {
+	Changes []Change `json:"changes"`
+}
+
+// ExecuteResult é a resposta de booster.execute; BackupData volta em booster.revert
+type ExecuteResult struct {
+	Message    string                 `json:"message,omitempty"`
+	BackupData map[string]interface{} `json:"backupData,omitempty"`
+}
+
+// ExecuteParams são os parâmetros de booster.validate, booster.canExecute,
+// booster.plan, booster.verify e booster.execute: os valores configurados dos
+// parâmetros declarados em BoosterInfo.Params. Serve os entrega ao Handler
+// pelo contexto, lidos com entities.ParamsFromContext.
+type ExecuteParams struct {
+	Params entities.BoosterParams `json:"params,omitempty"`
+}
+
+// RevertParams são os parâmetros de booster.revert
+type RevertParams struct {
+	BackupData map[string]interface{} `json:"backupData"`
+}
+
+// RevertResult é a resposta de booster.revert
+type RevertResult struct {
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,34 @@
+// Executável do plugin de referência. Para testar no app, compile, copie para o
+// diretório de plugins (plugin.Dir()) e grave ao lado os metadados que a
+// descoberta lê (plugin.MetadataPath):
+//
+//	mulltboost-reference-plugin --metadata > mulltboost-reference-plugin.json
+//
+// O diretório, o executável e o JSON precisam pertencer ao usuário que roda o
+// app e não podem ser graváveis por outros usuários.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/plugin"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/plugin/reference"
+)
+
+func main() {
+	booster := reference.New()
+	if len(os.Args) > 1 && os.Args[1] == "--metadata" {
+		if err := json.NewEncoder(os.Stdout).Encode(booster.Metadata()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := plugin.Serve(booster, os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,152 @@
+// Package reference é o plugin de exemplo do protocolo de boosters externos.
+// Ele liga uma opção num arquivo de configuração próprio e serve de modelo para
+// plugins reais e de alvo para o teste de conformidade.
+package reference
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/oLenador/mulltbost/internal/core/domain/services/i18n"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/boosters/plugin"
+)
+
+const (
+	BoosterID = "plugin_reference_low_latency"
+	// FileEnv troca o arquivo alterado pelo plugin (usado nos testes)
+	FileEnv = "MULLTBOOST_REFERENCE_PLUGIN_FILE"
+
+	optionKey     = "low_latency"
+	enabledValue  = "enabled"
+	disabledValue = "disabled"
+)
+
+// Booster implementa plugin.Handler sobre uma linha "low_latency=<valor>" do arquivo
+type Booster struct {
+	path string
+}
+
+func New() *Booster {
+	path := os.Getenv(FileEnv)
+	if path == "" {
+		path = filepath.Join(os.TempDir(), "mulltboost-reference-plugin.conf")
+	}
+	return &Booster{path: path}
+}
+
+func (b *Booster) Metadata() plugin.Metadata {
+	return plugin.Metadata{
+		ProtocolVersion: plugin.ProtocolVersion,
+		Booster: plugin.BoosterInfo{
+			ID:         BoosterID,
+			Version:    "1.0.0",
+			Category:   entities.CategoryPrecision,
+			Level:      entities.LevelFree,
+			Risk:       entities.RiskLow,
+			Platforms:  []entities.Platform{entities.PlatformLinux, entities.PlatformWindows},
+			Reversible: true,
+			Tags:       []string{"example"},
+			Resources:  []string{entities.FileResource(b.path)},
+			Translations: map[i18n.Language]plugin.Language{
+				i18n.English: {
+					Name:        "Reference Plugin",
+					Description: "Example external booster that enables a low latency option in its own config file.",
+				},
+				i18n.PortugueseBrazil: {
+					Name:        "Plugin de Referência",
+					Description: "Booster externo de exemplo que ativa uma opção de baixa latência no próprio arquivo de configuração.",
+				},
+			},
+			TimeoutSeconds: 30,
+		},
+		Capabilities: []plugin.Capability{plugin.CapabilityPlan, plugin.CapabilityVerify, plugin.CapabilityRevert},
+	}
+}
+
+func (b *Booster) Validate(ctx context.Context) error {
+	if _, err := os.Stat(filepath.Dir(b.path)); err != nil {
+		return fmt.Errorf("config directory is not available: %w", err)
+	}
+	return nil
+}
+
+func (b *Booster) CanExecute(ctx context.Context) plugin.CanExecuteResult {
+	if err := b.Validate(ctx); err != nil {
+		return plugin.CanExecuteResult{CanExecute: false, Reason: err.Error()}
+	}
+	return plugin.CanExecuteResult{CanExecute: true}
+}
+
+func (b *Booster) Plan(ctx context.Context) ([]plugin.Change, error) {
+	current, err := b.read()
+	if err != nil {
+		return nil, err
+	}
+	return []plugin.Change{{
+		Resource:     b.path + " " + optionKey,
+		CurrentValue: current,
+		NewValue:     enabledValue,
+		Reversible:   true,
+	}}, nil
+}
+
+func (b *Booster) Verify(ctx context.Context) ([]plugin.Change, error) {
+	changes, err := b.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if changes[0].CurrentValue == changes[0].NewValue {
+		return nil, nil
+	}
+	return changes, nil
+}
+
+func (b *Booster) Execute(ctx context.Context) (*plugin.ExecuteResult, error) {
+	previous, err := b.read()
+	if err != nil {
+		return nil, err
+	}
+	if err := b.write(enabledValue); err != nil {
+		return nil, err
+	}
+	return &plugin.ExecuteResult{
+		Message:    "Low latency option enabled",
+		BackupData: map[string]interface{}{optionKey: previous},
+	}, nil
+}
+
+func (b *Booster) Revert(ctx context.Context, backupData map[string]interface{}) (*plugin.RevertResult, error) {
+	previous, ok := backupData[optionKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("backup has no %s value", optionKey)
+	}
+	if err := b.write(previous); err != nil {
+		return nil, err
+	}
+	return &plugin.RevertResult{Message: "Low latency option restored"}, nil
+}
+
+// read retorna o valor da opção; sem arquivo ou sem a linha, a opção está desativada
+func (b *Booster) read() (string, error) {
+	data, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		return disabledValue, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), optionKey+"="); ok {
+			return value, nil
+		}
+	}
+	return disabledValue, nil
+}
+
+func (b *Booster) write(value string) error {
+	return os.WriteFile(b.path, []byte(optionKey+"="+value+"\n"), 0644)
+}
@@ -0,0 +1,132 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// maxMessageSize limita o tamanho de uma linha do protocolo
+const maxMessageSize = 4 << 20
+
+// Handler é o booster de um plugin. Serve traduz as requisições do app para
+// estes métodos; os opcionais só são chamados se declarados em Capabilities.
+// Os valores dos parâmetros declarados em BoosterInfo.Params chegam pelo
+// contexto e são lidos com entities.ParamsFromContext.
+type Handler interface {
+	Metadata() Metadata
+	Validate(ctx context.Context) error
+	CanExecute(ctx context.Context) CanExecuteResult
+	Plan(ctx context.Context) ([]Change, error)
+	Verify(ctx context.Context) ([]Change, error)
+	Execute(ctx context.Context) (*ExecuteResult, error)
+	Revert(ctx context.Context, backupData map[string]interface{}) (*RevertResult, error)
+}
+
+// Serve atende as requisições do app até o fim de in. É o main de um plugin:
+//
+//	func main() {
+//		if err := plugin.Serve(myBooster{}, os.Stdin, os.Stdout); err != nil {
+//			os.Exit(1)
+//		}
+//	}
+func Serve(handler Handler, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+	encoder := json.NewEncoder(out)
+	metadata := handler.Metadata()
+
+	for scanner.Scan() {
+		var request Request
+		if err := json.Unmarshal(scanner.Bytes(), &request); err != nil {
+			if err := encoder.Encode(errorResponse(0, CodeParseError, err.Error())); err != nil {
+				return err
+			}
+			continue
+		}
+
+		response := dispatch(context.Background(), handler, metadata, request)
+		if err := encoder.Encode(response); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func dispatch(ctx context.Context, handler Handler, metadata Metadata, request Request) Response {
+	if request.JSONRPC != "2.0" {
+		return errorResponse(request.ID, CodeInvalidRequest, "jsonrpc must be 2.0")
+	}
+
+	if request.Method != MethodRevert && len(request.Params) > 0 {
+		var params ExecuteParams
+		if err := json.Unmarshal(request.Params, &params); err != nil {
+			return errorResponse(request.ID, CodeInvalidParams, err.Error())
+		}
+		ctx = entities.WithBoosterParams(ctx, params.Params)
+	}
+
+	var (
+		result interface{}
+		err    error
+	)
+	switch request.Method {
+	case MethodMetadata:
+		result = metadata
+	case MethodValidate:
+		err = handler.Validate(ctx)
+		result = struct{}{}
+	case MethodCanExecute:
+		result = handler.CanExecute(ctx)
+	case MethodPlan:
+		if !metadata.Has(CapabilityPlan) {
+			return methodNotFound(request)
+		}
+		var changes []Change
+		changes, err = handler.Plan(ctx)
+		result = ChangesResult{Changes: changes}
+	case MethodVerify:
+		if !metadata.Has(CapabilityVerify) {
+			return methodNotFound(request)
+		}
+		var changes []Change
+		changes, err = handler.Verify(ctx)
+		result = ChangesResult{Changes: changes}
+	case MethodExecute:
+		result, err = handler.Execute(ctx)
+	case MethodRevert:
+		if !metadata.Has(CapabilityRevert) {
+			return methodNotFound(request)
+		}
+		var params RevertParams
+		if len(request.Params) > 0 {
+			if err := json.Unmarshal(request.Params, &params); err != nil {
+				return errorResponse(request.ID, CodeInvalidParams, err.Error())
+			}
+		}
+		result, err = handler.Revert(ctx, params.BackupData)
+	default:
+		return methodNotFound(request)
+	}
+
+	if err != nil {
+		return errorResponse(request.ID, CodeInternalError, err.Error())
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(request.ID, CodeInternalError, err.Error())
+	}
+	return Response{JSONRPC: "2.0", ID: request.ID, Result: data}
+}
+
+func methodNotFound(request Request) Response {
+	return errorResponse(request.ID, CodeMethodNotFound, fmt.Sprintf("method %q not found", request.Method))
+}
+
+func errorResponse(id int64, code int, message string) Response {
+	return Response{JSONRPC: "2.0", ID: id, Error: &RPCError{Code: code, Message: message}}
+}
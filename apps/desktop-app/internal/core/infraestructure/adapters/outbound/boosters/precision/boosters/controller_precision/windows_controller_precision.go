@@ -0,0 +1,10 @@
+//go:build windows
+
+package precision
+
+import "github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+
+// O executor ainda não foi implementado: o catálogo lista o booster como indisponível
+func NewControllerPrecisionExecutor() inbound.PlatformExecutor {
+	return nil
+}
@@ -0,0 +1,10 @@
+//go:build !windows
+
+package precision
+
+import "github.com/oLenador/mulltbost/internal/core/application/ports/inbound"
+
+// Booster exclusivo do Windows: o catálogo lista como indisponível na plataforma
+func NewKeyboardPrecisionExecutor() inbound.PlatformExecutor {
+	return nil
+}
@@ -0,0 +1,34 @@
+//go:build !windows
+
+package trust
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// Check recusa arquivos que outro usuário possa ter colocado ou alterado.
+// O app roda como root para alterar o sistema e plugins e manifestos herdam esse
+// privilégio: o arquivo precisa pertencer ao usuário do processo (ou ao root) e
+// não pode ser gravável pelo grupo nem pelos outros.
+func Check(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return fmt.Errorf("%s must not be a symlink", path)
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return fmt.Errorf("cannot read the owner of %s", path)
+	}
+	if uid := int(stat.Uid); uid != os.Geteuid() && uid != 0 {
+		return fmt.Errorf("%s is owned by uid %d, expected uid %d", path, uid, os.Geteuid())
+	}
+	if perm := info.Mode().Perm(); perm&0o022 != 0 {
+		return fmt.Errorf("%s must not be writable by group or others (mode %04o)", path, perm)
+	}
+	return nil
+}
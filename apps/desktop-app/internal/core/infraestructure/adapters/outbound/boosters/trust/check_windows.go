@@ -0,0 +1,94 @@
+//go:build windows
+
+package trust
+
+import (
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// writeAccess são os direitos que permitem trocar o conteúdo, as permissões ou
+// o dono de um arquivo, ou os arquivos de um diretório
+const writeAccess = windows.FILE_WRITE_DATA | windows.FILE_APPEND_DATA | fileDeleteChild |
+	windows.DELETE | windows.WRITE_DAC | windows.WRITE_OWNER | windows.GENERIC_WRITE | windows.GENERIC_ALL
+
+const fileDeleteChild = 0x40
+
+// Check recusa arquivos que outro usuário possa ter colocado ou alterado.
+// O dono e quem pode gravar precisam ser SYSTEM, Administradores ou, se o app
+// não estiver elevado, o próprio usuário. Elevado, o usuário não conta: um
+// processo dele sem elevação poderia trocar o plugin ou o manifesto que o app
+// usa como administrador.
+func Check(path string) error {
+	sd, err := windows.GetNamedSecurityInfo(path, windows.SE_FILE_OBJECT,
+		windows.OWNER_SECURITY_INFORMATION|windows.DACL_SECURITY_INFORMATION)
+	if err != nil {
+		return fmt.Errorf("cannot read the permissions of %s: %w", path, err)
+	}
+	trusted, err := trustedSIDs()
+	if err != nil {
+		return err
+	}
+
+	owner, _, err := sd.Owner()
+	if err != nil {
+		return fmt.Errorf("cannot read the owner of %s: %w", path, err)
+	}
+	if !isTrustedSID(owner, trusted) {
+		return fmt.Errorf("%s is owned by %s, expected an administrator", path, owner)
+	}
+
+	dacl, _, err := sd.DACL()
+	if err != nil {
+		return fmt.Errorf("cannot read the permissions of %s: %w", path, err)
+	}
+	if dacl == nil {
+		return fmt.Errorf("%s has no access control list and is writable by everyone", path)
+	}
+	for i := uint32(0); i < uint32(dacl.AceCount); i++ {
+		var ace *windows.ACCESS_ALLOWED_ACE
+		if err := windows.GetAce(dacl, i, &ace); err != nil {
+			return fmt.Errorf("cannot read the permissions of %s: %w", path, err)
+		}
+		if ace.Header.AceType != windows.ACCESS_ALLOWED_ACE_TYPE || ace.Header.AceFlags&windows.INHERIT_ONLY_ACE != 0 || ace.Mask&writeAccess == 0 {
+			continue
+		}
+		sid := (*windows.SID)(unsafe.Pointer(&ace.SidStart))
+		if !isTrustedSID(sid, trusted) {
+			return fmt.Errorf("%s must not be writable by %s", path, sid)
+		}
+	}
+	return nil
+}
+
+func trustedSIDs() ([]*windows.SID, error) {
+	var trusted []*windows.SID
+	for _, sidType := range []windows.WELL_KNOWN_SID_TYPE{windows.WinLocalSystemSid, windows.WinBuiltinAdministratorsSid} {
+		sid, err := windows.CreateWellKnownSid(sidType)
+		if err != nil {
+			return nil, err
+		}
+		trusted = append(trusted, sid)
+	}
+
+	token := windows.GetCurrentProcessToken()
+	if !token.IsElevated() {
+		user, err := token.GetTokenUser()
+		if err != nil {
+			return nil, err
+		}
+		trusted = append(trusted, user.User.Sid)
+	}
+	return trusted, nil
+}
+
+func isTrustedSID(sid *windows.SID, trusted []*windows.SID) bool {
+	for _, t := range trusted {
+		if sid.Equals(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package capabilities
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/adrg/xdg"
+	"github.com/oLenador/mulltbost/internal/config"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// CachePath é onde fica o resultado do probe do boot atual
+func CachePath() string {
+	return filepath.Join(xdg.CacheHome, config.AppDir, "capabilities.json")
+}
+
+type cacheFile struct {
+	BootID  string                                  `json:"boot_id"`
+	Results map[string][]entities.UnsupportedReason `json:"results"`
+}
+
+// FileCache implementa o booster.CapabilityCache num arquivo JSON; o conteúdo de
+// outro boot é descartado
+type FileCache struct {
+	path string
+}
+
+func NewFileCache(path string) *FileCache {
+	return &FileCache{path: path}
+}
+
+func (c *FileCache) Load(ctx context.Context, bootID string) (map[string][]entities.UnsupportedReason, error) {
+	data, err := os.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var file cacheFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if file.BootID != bootID {
+		return nil, nil
+	}
+	return file.Results, nil
+}
+
+func (c *FileCache) Save(ctx context.Context, bootID string, results map[string][]entities.UnsupportedReason) error {
+	data, err := json.Marshal(cacheFile{BootID: bootID, Results: results})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	// Grava num temporário e renomeia para não deixar um cache pela metade
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
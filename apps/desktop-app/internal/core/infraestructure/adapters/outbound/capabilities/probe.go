@@ -0,0 +1,37 @@
+// Package capabilities lê as características da máquina avaliadas nos requisitos
+// dos boosters e guarda o resultado do boot atual.
+package capabilities
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// Probe implementa o booster.SystemProbe sobre o sistema atual
+type Probe struct {
+	// root prefixa os caminhos lidos (/proc, /etc, /sys); vazio usa a raiz real
+	root string
+}
+
+func NewProbe() *Probe {
+	return &Probe{}
+}
+
+func (p *Probe) HasBinary(ctx context.Context, name string) bool {
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+func (p *Probe) PathExists(ctx context.Context, path string) bool {
+	_, err := os.Stat(p.path(path))
+	return err == nil
+}
+
+func (p *Probe) path(path string) string {
+	if p.root == "" {
+		return path
+	}
+	return filepath.Join(p.root, path)
+}
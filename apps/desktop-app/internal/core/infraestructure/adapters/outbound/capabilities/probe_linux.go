@@ -0,0 +1,94 @@
+//go:build linux
+
+package capabilities
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+func (p *Probe) BootID(ctx context.Context) (string, error) {
+	return p.readTrimmed("/proc/sys/kernel/random/boot_id")
+}
+
+func (p *Probe) IsElevated(ctx context.Context) bool {
+	return os.Geteuid() == 0
+}
+
+func (p *Probe) KernelVersion(ctx context.Context) (string, error) {
+	return p.readTrimmed("/proc/sys/kernel/osrelease")
+}
+
+// InitSystem identifica o init pelo processo 1 e pelos diretórios de runtime de cada um
+func (p *Probe) InitSystem(ctx context.Context) (entities.InitSystem, error) {
+	if p.PathExists(ctx, "/run/systemd/system") {
+		return entities.InitSystemd, nil
+	}
+	if p.PathExists(ctx, "/run/openrc") {
+		return entities.InitOpenRC, nil
+	}
+	if p.PathExists(ctx, "/run/runit") {
+		return entities.InitRunit, nil
+	}
+
+	comm, err := p.readTrimmed("/proc/1/comm")
+	if err != nil {
+		return "", err
+	}
+	switch filepath.Base(comm) {
+	case "systemd":
+		return entities.InitSystemd, nil
+	case "openrc-init":
+		return entities.InitOpenRC, nil
+	case "runit":
+		return entities.InitRunit, nil
+	case "init":
+		return entities.InitSysVinit, nil
+	}
+	return entities.InitSystem(comm), nil
+}
+
+// DistroFamilies retorna o ID e o ID_LIKE do os-release
+func (p *Probe) DistroFamilies(ctx context.Context) ([]entities.DistroFamily, error) {
+	file, err := os.Open(p.path("/etc/os-release"))
+	if os.IsNotExist(err) {
+		file, err = os.Open(p.path("/usr/lib/os-release"))
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var families []entities.DistroFamily
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok || (key != "ID" && key != "ID_LIKE") {
+			continue
+		}
+		for _, id := range strings.Fields(strings.Trim(value, `"'`)) {
+			families = append(families, entities.DistroFamily(strings.ToLower(id)))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(families) == 0 {
+		return nil, fmt.Errorf("os-release has no ID")
+	}
+	return families, nil
+}
+
+func (p *Probe) readTrimmed(path string) (string, error) {
+	data, err := os.ReadFile(p.path(path))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
@@ -0,0 +1,81 @@
+//go:build linux
+
+package capabilities
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+func writeFixture(t *testing.T, root, path, content string) {
+	t.Helper()
+	full := filepath.Join(root, path)
+	require.NoError(t, os.MkdirAll(filepath.Dir(full), 0755))
+	require.NoError(t, os.WriteFile(full, []byte(content), 0644))
+}
+
+func TestProbe_ReadsLinuxFacts(t *testing.T) {
+	root := t.TempDir()
+	writeFixture(t, root, "/proc/sys/kernel/random/boot_id", "3f1c5e2a-boot\n")
+	writeFixture(t, root, "/proc/sys/kernel/osrelease", "6.1.0-13-amd64\n")
+	writeFixture(t, root, "/proc/1/comm", "init\n")
+	writeFixture(t, root, "/etc/os-release", "NAME=\"Linux Mint\"\nID=linuxmint\nID_LIKE=\"ubuntu debian\"\n")
+	writeFixture(t, root, "/sys/kernel/mm/transparent_hugepage/enabled", "always [madvise] never\n")
+
+	probe := &Probe{root: root}
+	ctx := context.Background()
+
+	bootID, err := probe.BootID(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "3f1c5e2a-boot", bootID)
+
+	kernel, err := probe.KernelVersion(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "6.1.0-13-amd64", kernel)
+
+	init, err := probe.InitSystem(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, entities.InitSysVinit, init)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "/run/systemd/system"), 0755))
+	init, err = probe.InitSystem(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, entities.InitSystemd, init)
+
+	families, err := probe.DistroFamilies(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []entities.DistroFamily{"linuxmint", "ubuntu", entities.DistroDebian}, families)
+
+	assert.True(t, probe.PathExists(ctx, "/sys/kernel/mm/transparent_hugepage/enabled"))
+	assert.False(t, probe.PathExists(ctx, "/sys/module/usbcore/parameters/autosuspend"))
+}
+
+func TestFileCache_DiscardsOtherBoots(t *testing.T) {
+	ctx := context.Background()
+	cache := NewFileCache(filepath.Join(t.TempDir(), "cache", "capabilities.json"))
+
+	results, err := cache.Load(ctx, "boot-1")
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	saved := map[string][]entities.UnsupportedReason{
+		"abc": {{Code: entities.RequirementBinary, Detail: "ethtool"}},
+		"def": {},
+	}
+	require.NoError(t, cache.Save(ctx, "boot-1", saved))
+
+	results, err = cache.Load(ctx, "boot-1")
+	require.NoError(t, err)
+	assert.Equal(t, saved, results)
+
+	results, err = cache.Load(ctx, "boot-2")
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
@@ -0,0 +1,33 @@
+//go:build !linux && !windows
+
+package capabilities
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+var errUnsupportedPlatform = errors.New("capability probe is not supported on this platform")
+
+func (p *Probe) BootID(ctx context.Context) (string, error) {
+	return "", errUnsupportedPlatform
+}
+
+func (p *Probe) IsElevated(ctx context.Context) bool {
+	return os.Geteuid() == 0
+}
+
+func (p *Probe) KernelVersion(ctx context.Context) (string, error) {
+	return "", errUnsupportedPlatform
+}
+
+func (p *Probe) InitSystem(ctx context.Context) (entities.InitSystem, error) {
+	return "", errUnsupportedPlatform
+}
+
+func (p *Probe) DistroFamilies(ctx context.Context) ([]entities.DistroFamily, error) {
+	return nil, errUnsupportedPlatform
+}
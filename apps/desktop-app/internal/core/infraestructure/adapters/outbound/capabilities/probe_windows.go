@@ -0,0 +1,44 @@
+//go:build windows
+
+package capabilities
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/sys/windows"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+var procGetTickCount64 = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetTickCount64")
+
+// BootID é o horário do boot em minutos; o arredondamento absorve a imprecisão
+// de calcular o boot pelo uptime
+func (p *Probe) BootID(ctx context.Context) (string, error) {
+	if err := procGetTickCount64.Find(); err != nil {
+		return "", err
+	}
+	uptime, _, _ := procGetTickCount64.Call()
+	boot := time.Now().Add(-time.Duration(uptime) * time.Millisecond).Truncate(time.Minute)
+	return boot.UTC().Format(time.RFC3339), nil
+}
+
+func (p *Probe) IsElevated(ctx context.Context) bool {
+	return windows.GetCurrentProcessToken().IsElevated()
+}
+
+func (p *Probe) KernelVersion(ctx context.Context) (string, error) {
+	info := windows.RtlGetVersion()
+	return fmt.Sprintf("%d.%d.%d", info.MajorVersion, info.MinorVersion, info.BuildNumber), nil
+}
+
+// InitSystem e DistroFamilies não se aplicam ao Windows
+func (p *Probe) InitSystem(ctx context.Context) (entities.InitSystem, error) {
+	return "", nil
+}
+
+func (p *Probe) DistroFamilies(ctx context.Context) ([]entities.DistroFamily, error) {
+	return nil, nil
+}
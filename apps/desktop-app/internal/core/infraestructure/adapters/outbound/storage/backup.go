@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/oLenador/mulltbost/internal/config"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultBackupInterval = time.Hour
+	DefaultBackupsKept    = 5
+
+	backupPrefix     = "data_store-"
+	backupSuffix     = ".db"
+	backupTimeLayout = "20060102T150405Z"
+)
+
+// BackupDir é onde ficam os backups rotacionados do data_store.db
+func BackupDir() string {
+	return filepath.Join(xdg.DataHome, config.UserDir, "backups")
+}
+
+// Backup é uma cópia do banco; TakenAt vem do nome do arquivo
+type Backup struct {
+	Path    string
+	TakenAt time.Time
+}
+
+// Backups gerencia as cópias do banco em dir, mantendo as keep mais recentes
+type Backups struct {
+	dir  string
+	keep int
+}
+
+func NewBackups(dir string, keep int) *Backups {
+	if keep < 1 {
+		keep = 1
+	}
+	return &Backups{dir: dir, keep: keep}
+}
+
+// Create copia o banco com VACUUM INTO e remove as cópias excedentes. Um banco
+// que não passa na verificação de integridade não é copiado, para não
+// substituir os backups bons por um ruim.
+func (b *Backups) Create(db *gorm.DB, now time.Time) (*Backup, error) {
+	if err := CheckIntegrity(db); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(b.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create backup dir: %w", err)
+	}
+
+	takenAt := now.UTC().Truncate(time.Second)
+	path := filepath.Join(b.dir, backupPrefix+takenAt.Format(backupTimeLayout)+backupSuffix)
+	tmp := path + ".tmp"
+	if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err := db.Exec("VACUUM INTO ?", tmp).Error; err != nil {
+		os.Remove(tmp)
+		return nil, fmt.Errorf("failed to back up database: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return nil, fmt.Errorf("failed to back up database: %w", err)
+	}
+
+	if err := b.rotate(); err != nil {
+		return nil, err
+	}
+	return &Backup{Path: path, TakenAt: takenAt}, nil
+}
+
+// List retorna os backups do mais novo para o mais antigo
+func (b *Backups) List() ([]Backup, error) {
+	entries, err := os.ReadDir(b.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list backups: %w", err)
+	}
+
+	var backups []Backup
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, backupPrefix) || !strings.HasSuffix(name, backupSuffix) {
+			continue
+		}
+		takenAt, err := time.Parse(backupTimeLayout, strings.TrimSuffix(strings.TrimPrefix(name, backupPrefix), backupSuffix))
+		if err != nil {
+			continue
+		}
+		backups = append(backups, Backup{Path: filepath.Join(b.dir, name), TakenAt: takenAt})
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].TakenAt.After(backups[j].TakenAt) })
+	return backups, nil
+}
+
+func (b *Backups) rotate() error {
+	backups, err := b.List()
+	if err != nil {
+		return err
+	}
+	for i := b.keep; i < len(backups); i++ {
+		if err := os.Remove(backups[i].Path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove old backup: %w", err)
+		}
+	}
+	return nil
+}
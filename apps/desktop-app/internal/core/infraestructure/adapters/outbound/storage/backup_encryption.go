@@ -0,0 +1,476 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	model "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/secrets"
+	"gorm.io/datatypes"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+var (
+	ErrBackupKeyMissing = errors.New("rollback backup encryption key is not configured")
+	// ErrBackupKeyLost indica backups cifrados com chaves que nenhum store tem.
+	// O app não inicia: criar uma chave nova deixaria as reversões impossíveis.
+	ErrBackupKeyLost = errors.New("rollback backups are encrypted with keys that were not found")
+)
+
+// DefaultKeyRotationInterval é a idade da chave primária a partir da qual ela é trocada
+const DefaultKeyRotationInterval = 90 * 24 * time.Hour
+
+type backupCipherKey struct{}
+
+// WithBackupCipher associa ao contexto o cipher dos backups de rollback; as
+// migrações que cifram ou decifram esses backups o recebem pelo contexto do banco
+func WithBackupCipher(ctx context.Context, c *secrets.Cipher) context.Context {
+	return context.WithValue(ctx, backupCipherKey{}, c)
+}
+
+func backupCipherFrom(tx *gorm.DB) (*secrets.Cipher, error) {
+	if tx.Statement.Context != nil {
+		if c, ok := tx.Statement.Context.Value(backupCipherKey{}).(*secrets.Cipher); ok && c != nil {
+			return c, nil
+		}
+	}
+	return nil, ErrBackupKeyMissing
+}
+
+// OpenBackupCipher carrega as chaves dos backups e confere que todo backup
+// cifrado no banco tem a sua. Um keyset novo só é criado se o banco não tem
+// backup cifrado; se tem e a chave não foi encontrada (chaveiro bloqueado, sem
+// sessão D-Bus, arquivo de chaves apagado) retorna ErrBackupKeyLost.
+func OpenBackupCipher(db *gorm.DB, now time.Time, stores ...secrets.KeyStore) (*secrets.Cipher, error) {
+	keyIDs, err := sealedKeyIDs(db)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := secrets.Load(stores...)
+	if errors.Is(err, secrets.ErrNoKeys) {
+		if len(keyIDs) > 0 {
+			return nil, fmt.Errorf("%w: no keys in %s", ErrBackupKeyLost, storeNames(stores))
+		}
+		return secrets.Create(now, stores...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, id := range keyIDs {
+		if !c.HasKey(id) {
+			missing = append(missing, id)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: keys %s not in %s", ErrBackupKeyLost, strings.Join(missing, ", "), storeNames(stores))
+	}
+	return c, nil
+}
+
+// sealedKeyIDs retorna, ordenadas, as chaves usadas nos backups cifrados do banco
+func sealedKeyIDs(db *gorm.DB) ([]string, error) {
+	seen := make(map[string]bool)
+	for _, rewrite := range backupTables {
+		err := rewrite(db, func(_ string, data datatypes.JSONMap) (datatypes.JSONMap, bool, error) {
+			sealed, ok := secrets.SealedValue(data)
+			if !ok {
+				return nil, false, nil
+			}
+			id, err := secrets.KeyID(sealed)
+			if err != nil {
+				return nil, false, err
+			}
+			seen[id] = true
+			return nil, false, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backups: %w", err)
+		}
+	}
+
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func storeNames(stores []secrets.KeyStore) string {
+	names := make([]string, 0, len(stores))
+	for _, store := range stores {
+		names = append(names, store.Name())
+	}
+	return strings.Join(names, ", ")
+}
+
+// backupRewrite recebe o aad e o backup de uma linha e retorna o backup novo,
+// ou false para deixar a linha como está
+type backupRewrite func(aad string, data datatypes.JSONMap) (datatypes.JSONMap, bool, error)
+
+// backupRewriter passa rewrite por todos os backups de uma tabela. O aad de
+// cada backup é o mesmo usado pelo repositório da tabela.
+type backupRewriter func(tx *gorm.DB, rewrite backupRewrite) error
+
+// backupTables são todas as tabelas que guardam backups cifrados
+var backupTables = []backupRewriter{rewriteRollbackBackups, rewriteJournalBackups, rewriteSnapshotBackups}
+
+// rollbackBackup são só as colunas de rollback_states usadas na cifragem
+type rollbackBackup struct {
+	ID         string
+	BackupData datatypes.JSONMap
+}
+
+func (rollbackBackup) TableName() string { return "rollback_states" }
+
+func rewriteRollbackBackups(tx *gorm.DB, rewrite backupRewrite) error {
+	if !tx.Migrator().HasTable("rollback_states") {
+		return nil
+	}
+	var rows []rollbackBackup
+	if err := tx.Find(&rows).Error; err != nil {
+		return err
+	}
+	for _, row := range rows {
+		data, changed, err := rewrite(row.ID, row.BackupData)
+		if err != nil {
+			return fmt.Errorf("rollback state %s: %w", row.ID, err)
+		}
+		if !changed {
+			continue
+		}
+		if err := tx.Table("rollback_states").Where("id = ?", row.ID).Update("backup_data", data).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type journalBackup struct {
+	OperationID string
+	BackupData  datatypes.JSONMap
+}
+
+func (journalBackup) TableName() string { return "operation_journal" }
+
+func rewriteJournalBackups(tx *gorm.DB, rewrite backupRewrite) error {
+	if !tx.Migrator().HasTable("operation_journal") {
+		return nil
+	}
+	var rows []journalBackup
+	if err := tx.Find(&rows).Error; err != nil {
+		return err
+	}
+	for _, row := range rows {
+		data, changed, err := rewrite(model.JournalBackupAAD(row.OperationID), row.BackupData)
+		if err != nil {
+			return fmt.Errorf("journal entry %s: %w", row.OperationID, err)
+		}
+		if !changed {
+			continue
+		}
+		if err := tx.Table("operation_journal").Where("operation_id = ?", row.OperationID).Update("backup_data", data).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type snapshotBackups struct {
+	ID       string
+	Boosters datatypes.JSON
+}
+
+func (snapshotBackups) TableName() string { return "system_snapshots" }
+
+// rewriteSnapshotBackups reescreve o backupData de cada booster dos snapshots.
+// Os boosters são lidos como JSON genérico para que os outros campos sejam
+// gravados de volta como estavam.
+func rewriteSnapshotBackups(tx *gorm.DB, rewrite backupRewrite) error {
+	if !tx.Migrator().HasTable("system_snapshots") {
+		return nil
+	}
+	var rows []snapshotBackups
+	if err := tx.Find(&rows).Error; err != nil {
+		return err
+	}
+	for _, row := range rows {
+		var boosters []map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(row.Boosters))
+		decoder.UseNumber()
+		if err := decoder.Decode(&boosters); err != nil {
+			return fmt.Errorf("snapshot %s: %w", row.ID, err)
+		}
+
+		changed := false
+		for _, booster := range boosters {
+			backup, ok := booster["backupData"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			boosterID, _ := booster["boosterId"].(string)
+			data, rewritten, err := rewrite(model.SnapshotBackupAAD(row.ID, boosterID), backup)
+			if err != nil {
+				return fmt.Errorf("snapshot %s, booster %s: %w", row.ID, boosterID, err)
+			}
+			if rewritten {
+				booster["backupData"] = map[string]interface{}(data)
+				changed = true
+			}
+		}
+		if !changed {
+			continue
+		}
+
+		raw, err := json.Marshal(boosters)
+		if err != nil {
+			return fmt.Errorf("snapshot %s: %w", row.ID, err)
+		}
+		if err := tx.Table("system_snapshots").Where("id = ?", row.ID).Update("boosters", datatypes.JSON(raw)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sealBackups cifra com a chave primária os backups em texto puro e os
+// cifrados com outra chave. secure_delete zera no arquivo o conteúdo que as
+// linhas reescritas deixam para trás.
+func sealBackups(tx *gorm.DB, c *secrets.Cipher, tables ...backupRewriter) error {
+	if err := tx.Exec("PRAGMA secure_delete = ON").Error; err != nil {
+		return err
+	}
+	primary, _ := c.PrimaryKey()
+	for _, rewrite := range tables {
+		err := rewrite(tx, func(aad string, data datatypes.JSONMap) (datatypes.JSONMap, bool, error) {
+			if sealed, ok := secrets.SealedValue(data); ok {
+				if keyID, err := secrets.KeyID(sealed); err == nil && keyID == primary {
+					return nil, false, nil
+				}
+			}
+			opened, err := c.OpenMap(data, aad)
+			if err != nil {
+				return nil, false, err
+			}
+			sealed, err := c.SealMap(opened, aad)
+			return sealed, err == nil, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func openBackups(tx *gorm.DB, c *secrets.Cipher, tables ...backupRewriter) error {
+	for _, rewrite := range tables {
+		err := rewrite(tx, func(aad string, data datatypes.JSONMap) (datatypes.JSONMap, bool, error) {
+			if _, ok := secrets.SealedValue(data); !ok {
+				return nil, false, nil
+			}
+			opened, err := c.OpenMap(data, aad)
+			return opened, err == nil, err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RotateBackupKey cria uma chave primária nova, recifra com ela os backups do
+// banco e das cópias em copies, e só então remove as chaves antigas. Se alguma
+// recifragem falhar, as chaves antigas ficam no keyset e a próxima chamada de
+// RotateBackupKeyIfDue termina o trabalho.
+func RotateBackupKey(db *gorm.DB, c *secrets.Cipher, now time.Time, copies ...string) error {
+	if err := c.Rotate(now); err != nil {
+		return err
+	}
+	return finishKeyRotation(db, c, copies)
+}
+
+// RotateBackupKeyIfDue troca a chave se a primária tiver mais que interval e
+// conclui uma rotação interrompida; um interval <= 0 só conclui
+func RotateBackupKeyIfDue(db *gorm.DB, c *secrets.Cipher, now time.Time, interval time.Duration, copies ...string) error {
+	if _, createdAt := c.PrimaryKey(); interval > 0 && now.Sub(createdAt) >= interval {
+		return RotateBackupKey(db, c, now, copies...)
+	}
+	return finishKeyRotation(db, c, copies)
+}
+
+// finishKeyRotation recifra com a chave primária o banco e as cópias dele. Uma
+// cópia restaurada depois da rotação precisa ser legível, então as chaves
+// antigas só são removidas quando nenhuma cópia depende mais delas.
+func finishKeyRotation(db *gorm.DB, c *secrets.Cipher, copies []string) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		return sealBackups(tx, c, backupTables...)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to re-encrypt rollback backups: %w", err)
+	}
+	if !c.HasOldKeys() {
+		return nil
+	}
+	for _, path := range copies {
+		if err := resealCopy(path, c); err != nil {
+			return fmt.Errorf("failed to re-encrypt rollback backups in %s: %w", path, err)
+		}
+	}
+	return c.Retire()
+}
+
+// resealCopy recifra com a chave primária os backups que uma cópia do banco
+// guarda com outras chaves. Backups em texto puro ficam como estão, para não
+// mudar o que a versão do schema da cópia espera. Uma cópia que não existe
+// mais ou que está corrompida (e nunca seria restaurada) é ignorada.
+func resealCopy(path string, c *secrets.Cipher) error {
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err := checkFile(path); err != nil {
+		dbLogger.Warnf("skipping backup %s: %v", path, err)
+		return nil
+	}
+
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		return err
+	}
+	defer closeDB(db)
+
+	primary, _ := c.PrimaryKey()
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, rewrite := range backupTables {
+			err := rewrite(tx, func(aad string, data datatypes.JSONMap) (datatypes.JSONMap, bool, error) {
+				sealed, ok := secrets.SealedValue(data)
+				if !ok {
+					return nil, false, nil
+				}
+				if keyID, err := secrets.KeyID(sealed); err == nil && keyID == primary {
+					return nil, false, nil
+				}
+				opened, err := c.OpenMap(data, aad)
+				if err != nil {
+					return nil, false, err
+				}
+				resealed, err := c.SealMap(opened, aad)
+				return resealed, err == nil, err
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// VACUUM reescreve o arquivo sem as páginas com o texto cifrado antigo
+	return db.Exec("VACUUM").Error
+}
+
+// DatabaseCopies são as cópias do banco em dbPath que podem ser restauradas:
+// os backups periódicos e as cópias feitas antes das migrações
+func DatabaseCopies(dbPath string, backups *Backups) ([]string, error) {
+	var copies []string
+	if backups != nil {
+		list, err := backups.List()
+		if err != nil {
+			return nil, err
+		}
+		for _, backup := range list {
+			copies = append(copies, backup.Path)
+		}
+	}
+	if dbPath == "" {
+		return copies, nil
+	}
+	premigration, err := preMigrationCopies(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	for _, pm := range premigration {
+		copies = append(copies, pm.path)
+	}
+	return copies, nil
+}
+
+// encryptedSchemaVersion é a primeira versão do schema em que todos os
+// backups guardados no banco são cifrados
+const encryptedSchemaVersion = 10
+
+// RemovePlaintextCopies tira de disco as cópias do banco com backups em texto
+// puro. As cópias feitas antes de migrar de uma versão sem cifragem são
+// apagadas, já que cifrá-las mudaria o que o schema delas espera; os backups
+// periódicos são migrados no lugar, como seriam ao ser restaurados. Uma cópia
+// que falha não impede as outras.
+func RemovePlaintextCopies(dbPath string, backups *Backups, c *secrets.Cipher) error {
+	var errs []error
+	premigration, err := preMigrationCopies(dbPath)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, pm := range premigration {
+		if pm.version >= encryptedSchemaVersion {
+			continue
+		}
+		if err := os.Remove(pm.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+			continue
+		}
+		dbLogger.Infof("removed unencrypted database copy %s", pm.path)
+	}
+
+	if backups != nil {
+		list, err := backups.List()
+		if err != nil {
+			errs = append(errs, err)
+		}
+		for _, backup := range list {
+			if err := encryptCopy(backup.Path, c); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", backup.Path, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// encryptCopy migra uma cópia anterior a encryptedSchemaVersion, cifrando os
+// backups dela, e a reescreve com VACUUM para não sobrar o texto puro
+func encryptCopy(path string, c *secrets.Cipher) error {
+	if err := checkFile(path); err != nil {
+		dbLogger.Warnf("skipping backup %s: %v", path, err)
+		return nil
+	}
+
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{Logger: gormlogger.Discard})
+	if err != nil {
+		return err
+	}
+	defer closeDB(db)
+
+	version, err := SchemaVersion(db)
+	if err != nil || version >= encryptedSchemaVersion {
+		return err
+	}
+	if err := Migrate(db.WithContext(WithBackupCipher(context.Background(), c)), ""); err != nil {
+		return err
+	}
+	if err := db.Exec("VACUUM").Error; err != nil {
+		return err
+	}
+	dbLogger.Infof("encrypted backups in database copy %s", path)
+	return nil
+}
@@ -0,0 +1,323 @@
+package storage_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	storage "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage"
+	repos "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/repositories"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/secrets"
+)
+
+func saveRollbackStates(t *testing.T, repo *repos.RollbackRepo, ids ...string) {
+	for _, id := range ids {
+		require.NoError(t, repo.Save(context.Background(), &entities.BoosterRollbackState{
+			ID: id, Applied: true, Version: "1.0.0", BackupData: entities.BackupData{"resolv_conf": "nameserver 10.0.0.53 # " + id},
+		}))
+	}
+}
+
+func backupKeyID(t *testing.T, db *gorm.DB, id string) string {
+	var data map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(rawBackup(t, db, id)), &data))
+	sealed, ok := secrets.SealedValue(data)
+	require.True(t, ok, "backup of %s must be encrypted", id)
+	keyID, err := secrets.KeyID(sealed)
+	require.NoError(t, err)
+	return keyID
+}
+
+func TestMigrate_EncryptingBackupsRequiresKey(t *testing.T) {
+	db, path := openTestDBWithoutKey(t)
+	loadFixture(t, db, lastLegacyVersion)
+
+	err := storage.Migrate(db, path)
+	assert.ErrorIs(t, err, storage.ErrBackupKeyMissing)
+	version, err := storage.SchemaVersion(db)
+	require.NoError(t, err)
+	assert.Equal(t, 8, version, "the failed migration must not be recorded")
+	assert.Contains(t, rawBackup(t, db, "dns_flush"), "nameserver 1.1.1.1")
+
+	c, _ := newTestCipher(t)
+	require.NoError(t, storage.Migrate(db.WithContext(storage.WithBackupCipher(context.Background(), c)), path))
+	assert.NotContains(t, rawBackup(t, db, "dns_flush"), "nameserver")
+}
+
+func TestRollbackRepo_EncryptedBackupIsBoundToItsState(t *testing.T) {
+	db, _ := newMigratedDB(t)
+	repo := repos.NewRollbackRepo(db)
+	repo.SetCipher(testCipher(t))
+	saveRollbackStates(t, repo, "dns_flush", "tcp_fast_open")
+	assert.NotContains(t, rawBackup(t, db, "dns_flush"), "10.0.0.53")
+
+	states, err := repo.GetAll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, states, 2)
+
+	// um backup copiado para outro estado não decifra
+	require.NoError(t, db.Exec("UPDATE rollback_states SET backup_data = (SELECT backup_data FROM rollback_states WHERE id = ?) WHERE id = ?",
+		"dns_flush", "tcp_fast_open").Error)
+	_, err = repo.GetByID(context.Background(), "tcp_fast_open")
+	assert.ErrorIs(t, err, secrets.ErrDecrypt)
+
+	_, err = repos.NewRollbackRepo(db).GetByID(context.Background(), "dns_flush")
+	assert.Error(t, err, "an encrypted backup must not be read without a key")
+}
+
+func TestRotateBackupKey_ReencryptsAndRetiresOldKeys(t *testing.T) {
+	db, _ := newMigratedDB(t)
+	c, store := newTestCipher(t)
+	repo := repos.NewRollbackRepo(db)
+	repo.SetCipher(c)
+	saveRollbackStates(t, repo, "dns_flush", "tcp_fast_open")
+	oldKey, _ := c.PrimaryKey()
+
+	require.NoError(t, storage.RotateBackupKey(db, c, time.Now()))
+
+	newKey, _ := c.PrimaryKey()
+	assert.NotEqual(t, oldKey, newKey)
+	assert.Equal(t, newKey, backupKeyID(t, db, "dns_flush"))
+	assert.Equal(t, newKey, backupKeyID(t, db, "tcp_fast_open"))
+	require.Len(t, store.keys.Keys, 1)
+	assert.Equal(t, newKey, store.keys.Primary)
+
+	state, err := repo.GetByID(context.Background(), "dns_flush")
+	require.NoError(t, err)
+	assert.Equal(t, "nameserver 10.0.0.53 # dns_flush", state.BackupData["resolv_conf"])
+}
+
+func TestRotateBackupKeyIfDue(t *testing.T) {
+	db, _ := newMigratedDB(t)
+	c, store := newTestCipher(t)
+	repo := repos.NewRollbackRepo(db)
+	repo.SetCipher(c)
+	saveRollbackStates(t, repo, "dns_flush")
+	key, createdAt := c.PrimaryKey()
+
+	require.NoError(t, storage.RotateBackupKeyIfDue(db, c, createdAt.Add(time.Hour), 24*time.Hour))
+	current, _ := c.PrimaryKey()
+	assert.Equal(t, key, current, "a key younger than the interval is kept")
+
+	// uma rotação interrompida antes de recifrar é concluída na próxima chamada
+	require.NoError(t, c.Rotate(createdAt.Add(2*time.Hour)))
+	require.Len(t, store.keys.Keys, 2)
+	assert.Equal(t, key, backupKeyID(t, db, "dns_flush"))
+
+	require.NoError(t, storage.RotateBackupKeyIfDue(db, c, createdAt.Add(3*time.Hour), 24*time.Hour))
+	current, _ = c.PrimaryKey()
+	assert.Equal(t, current, backupKeyID(t, db, "dns_flush"))
+	assert.Len(t, store.keys.Keys, 1)
+
+	require.NoError(t, storage.RotateBackupKeyIfDue(db, c, createdAt.Add(48*time.Hour), 24*time.Hour))
+	rotated, _ := c.PrimaryKey()
+	assert.NotEqual(t, current, rotated)
+	assert.Equal(t, rotated, backupKeyID(t, db, "dns_flush"))
+}
+
+func TestOpenBackupCipher_RefusesToReplaceLostKeys(t *testing.T) {
+	db, _ := newMigratedDB(t)
+
+	// banco sem backup cifrado: sem chaves, um keyset é criado
+	fresh := &memoryKeyStore{}
+	c, err := storage.OpenBackupCipher(db, time.Now(), fresh)
+	require.NoError(t, err)
+	require.NotNil(t, fresh.keys)
+
+	repo := repos.NewRollbackRepo(db)
+	repo.SetCipher(c)
+	saveRollbackStates(t, repo, "dns_flush")
+
+	// chaveiro indisponível e arquivo vazio: nenhuma chave nova é criada
+	empty := &memoryKeyStore{}
+	_, err = storage.OpenBackupCipher(db, time.Now(), empty)
+	assert.ErrorIs(t, err, storage.ErrBackupKeyLost)
+	assert.Nil(t, empty.keys, "a fresh key must not be created over sealed backups")
+
+	// um keyset que não tem a chave dos backups também é recusado
+	_, otherStore := newTestCipher(t)
+	_, err = storage.OpenBackupCipher(db, time.Now(), otherStore)
+	assert.ErrorIs(t, err, storage.ErrBackupKeyLost)
+
+	loaded, err := storage.OpenBackupCipher(db, time.Now(), fresh)
+	require.NoError(t, err)
+	key, _ := c.PrimaryKey()
+	assert.True(t, loaded.HasKey(key))
+}
+
+func TestRotateBackupKey_ReencryptsDatabaseCopiesBeforeRetiring(t *testing.T) {
+	db, path := newMigratedDB(t)
+	c, store := newTestCipher(t)
+	repo := repos.NewRollbackRepo(db)
+	repo.SetCipher(c)
+	saveRollbackStates(t, repo, "dns_flush")
+
+	backups := storage.NewBackups(filepath.Join(t.TempDir(), "backups"), 3)
+	backup, err := backups.Create(db, time.Now())
+	require.NoError(t, err)
+	copies, err := storage.DatabaseCopies(path, backups)
+	require.NoError(t, err)
+	require.Contains(t, copies, backup.Path)
+
+	require.NoError(t, storage.RotateBackupKey(db, c, time.Now(), copies...))
+	newKey, _ := c.PrimaryKey()
+	require.Len(t, store.keys.Keys, 1)
+
+	// a cópia restaurada depois da rotação continua legível
+	restored, err := gorm.Open(sqlite.Open(backup.Path), &gorm.Config{})
+	require.NoError(t, err)
+	defer closeTestDB(t, restored)
+	assert.Equal(t, newKey, backupKeyID(t, restored, "dns_flush"))
+	restoredRepo := repos.NewRollbackRepo(restored)
+	restoredRepo.SetCipher(c)
+	state, err := restoredRepo.GetByID(context.Background(), "dns_flush")
+	require.NoError(t, err)
+	assert.Equal(t, "nameserver 10.0.0.53 # dns_flush", state.BackupData["resolv_conf"])
+}
+
+func TestRotateBackupKey_KeepsOldKeysWhileACopyCannotBeReencrypted(t *testing.T) {
+	db, path := newMigratedDB(t)
+	c, store := newTestCipher(t)
+	repo := repos.NewRollbackRepo(db)
+	repo.SetCipher(c)
+	saveRollbackStates(t, repo, "dns_flush")
+	oldKey, _ := c.PrimaryKey()
+
+	// uma cópia com um backup que este keyset não decifra
+	copyPath := path + ".copy"
+	require.NoError(t, db.Exec("VACUUM INTO ?", copyPath).Error)
+	copyDB, err := gorm.Open(sqlite.Open(copyPath), &gorm.Config{})
+	require.NoError(t, err)
+	other, _ := newTestCipher(t)
+	copyRepo := repos.NewRollbackRepo(copyDB)
+	copyRepo.SetCipher(other)
+	saveRollbackStates(t, copyRepo, "dns_flush")
+	closeTestDB(t, copyDB)
+
+	err = storage.RotateBackupKey(db, c, time.Now(), copyPath)
+	assert.ErrorIs(t, err, secrets.ErrUnknownKey)
+	require.Len(t, store.keys.Keys, 2, "old keys stay while a copy still needs them")
+	assert.True(t, c.HasKey(oldKey))
+}
+
+func TestMigrate_EncryptsJournalAndSnapshotBackups(t *testing.T) {
+	db, path := openTestDB(t)
+	loadFixture(t, db, lastLegacyVersion)
+	require.NoError(t, db.Exec(`UPDATE system_snapshots SET boosters = '[{"boosterId":"dns_flush","version":"1.0.0","backupData":{"resolv_conf":"nameserver 1.1.1.1"},"liveValues":{"resolv_conf":"nameserver 9.9.9.9"}}]' WHERE id = 'snap-1'`).Error)
+
+	require.NoError(t, storage.Migrate(db, path))
+	assert.NotContains(t, rawJournalBackup(t, db, "op-1"), "nameserver")
+	assert.NotContains(t, rawSnapshotBoosters(t, db, "snap-1"), "nameserver 1.1.1.1")
+	assert.Contains(t, rawSnapshotBoosters(t, db, "snap-1"), "nameserver 9.9.9.9", "only the backup is encrypted")
+
+	journalRepo := repos.NewOperationJournalRepo(db)
+	journalRepo.SetCipher(testCipher(t))
+	entry, err := journalRepo.GetByID(context.Background(), "op-1")
+	require.NoError(t, err)
+	assert.Equal(t, "nameserver 1.1.1.1", entry.BackupData["resolv_conf"])
+
+	snapshotRepo := repos.NewSnapshotRepo(db)
+	snapshotRepo.SetCipher(testCipher(t))
+	snapshot, err := snapshotRepo.GetByID(context.Background(), "snap-1")
+	require.NoError(t, err)
+	require.Len(t, snapshot.Boosters, 1)
+	assert.Equal(t, "nameserver 1.1.1.1", snapshot.Boosters[0].BackupData["resolv_conf"])
+
+	require.NoError(t, storage.MigrateTo(db, path, 9))
+	assert.Contains(t, rawJournalBackup(t, db, "op-1"), "nameserver 1.1.1.1", "backups must be decrypted below v10")
+	assert.Contains(t, rawSnapshotBoosters(t, db, "snap-1"), "nameserver 1.1.1.1")
+	assert.NotContains(t, rawBackup(t, db, "dns_flush"), "nameserver", "rollback backups stay encrypted in v9")
+}
+
+func TestJournalAndSnapshotRepos_EncryptBackups(t *testing.T) {
+	db, _ := newMigratedDB(t)
+	c := testCipher(t)
+	ctx := context.Background()
+
+	journalRepo := repos.NewOperationJournalRepo(db)
+	journalRepo.SetCipher(c)
+	require.NoError(t, journalRepo.Save(ctx, &entities.JournalEntry{
+		OperationID: "op-1", BoosterID: "dns_flush", Operation: entities.ApplyOperationType, Phase: entities.JournalStarted, QueuedAt: time.Now(),
+	}))
+	require.NoError(t, journalRepo.RecordExecutorResult(ctx, "op-1", true, "", entities.BackupData{"resolv_conf": "nameserver 10.0.0.53"}))
+	assert.NotContains(t, rawJournalBackup(t, db, "op-1"), "10.0.0.53")
+
+	entries, err := journalRepo.GetUnfinished(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "nameserver 10.0.0.53", entries[0].BackupData["resolv_conf"])
+
+	snapshotRepo := repos.NewSnapshotRepo(db)
+	snapshotRepo.SetCipher(c)
+	snapshot := &entities.Snapshot{
+		ID: "snap-1", Name: "gaming", Origin: entities.SnapshotManual, CreatedAt: time.Now(),
+		Boosters: []entities.SnapshotBooster{{BoosterID: "dns_flush", Version: "1.0.0", BackupData: entities.BackupData{"resolv_conf": "nameserver 10.0.0.53"}}},
+	}
+	require.NoError(t, snapshotRepo.Save(ctx, snapshot))
+	assert.Equal(t, "nameserver 10.0.0.53", snapshot.Boosters[0].BackupData["resolv_conf"], "the caller's snapshot must not change")
+	assert.NotContains(t, rawSnapshotBoosters(t, db, "snap-1"), "10.0.0.53")
+
+	snapshots, err := snapshotRepo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, "nameserver 10.0.0.53", snapshots[0].Boosters[0].BackupData["resolv_conf"])
+
+	// o backup de um booster copiado para outro snapshot não decifra
+	require.NoError(t, db.Exec("INSERT INTO system_snapshots (id, name, origin, boosters, created_at) SELECT 'snap-2', name, origin, boosters, created_at FROM system_snapshots WHERE id = 'snap-1'").Error)
+	_, err = snapshotRepo.GetByID(ctx, "snap-2")
+	assert.ErrorIs(t, err, secrets.ErrDecrypt)
+}
+
+func TestRemovePlaintextCopies(t *testing.T) {
+	db, path := openTestDB(t)
+	loadFixture(t, db, lastLegacyVersion)
+	require.NoError(t, storage.MigrateTo(db, path, lastLegacyVersion))
+	backups := storage.NewBackups(filepath.Join(t.TempDir(), "backups"), 3)
+	hourly, err := backups.Create(db, time.Now())
+	require.NoError(t, err)
+
+	require.NoError(t, storage.Migrate(db, path))
+	premigration := storage.BackupPath(path, lastLegacyVersion)
+	require.FileExists(t, premigration)
+
+	require.NoError(t, storage.RemovePlaintextCopies(path, backups, testCipher(t)))
+	assert.NoFileExists(t, premigration)
+
+	for _, file := range []string{path, hourly.Path} {
+		raw, err := os.ReadFile(file)
+		require.NoError(t, err)
+		assert.False(t, bytes.Contains(raw, []byte("nameserver 1.1.1.1")), "%s must not keep plaintext backups", file)
+	}
+
+	copyDB := reopen(t, hourly.Path)
+	version, err := storage.SchemaVersion(copyDB)
+	require.NoError(t, err)
+	assert.Equal(t, storage.LatestVersion(), version, "the backup is migrated as a restore would")
+	rollbackRepo := repos.NewRollbackRepo(copyDB)
+	rollbackRepo.SetCipher(testCipher(t))
+	state, err := rollbackRepo.GetByID(context.Background(), "dns_flush")
+	require.NoError(t, err)
+	assert.Equal(t, "nameserver 1.1.1.1", state.BackupData["resolv_conf"])
+}
+
+func rawJournalBackup(t *testing.T, db *gorm.DB, operationID string) string {
+	var data string
+	require.NoError(t, db.Raw("SELECT backup_data FROM operation_journal WHERE operation_id = ?", operationID).Scan(&data).Error)
+	return data
+}
+
+func rawSnapshotBoosters(t *testing.T, db *gorm.DB, id string) string {
+	var data string
+	require.NoError(t, db.Raw("SELECT boosters FROM system_snapshots WHERE id = ?", id).Scan(&data).Error)
+	return data
+}
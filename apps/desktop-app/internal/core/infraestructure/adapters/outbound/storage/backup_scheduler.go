@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"sync"
+	"time"
+
+	"github.com/wailsapp/wails/lib/logger"
+	"gorm.io/gorm"
+)
+
+// BackupScheduler faz backups periódicos do banco. Um backup também é feito ao
+// iniciar se o mais recente for mais antigo que o intervalo.
+type BackupScheduler struct {
+	db        *gorm.DB
+	backups   *Backups
+	interval  time.Duration
+	stopCh    chan struct{}
+	wg        sync.WaitGroup
+	startOnce sync.Once
+	stopOnce  sync.Once
+	logger    *logger.CustomLogger
+}
+
+func NewBackupScheduler(db *gorm.DB, backups *Backups, interval time.Duration) *BackupScheduler {
+	return &BackupScheduler{
+		db:       db,
+		backups:  backups,
+		interval: interval,
+		stopCh:   make(chan struct{}),
+		logger:   logger.NewCustomLogger("[BackupScheduler]"),
+	}
+}
+
+// Start inicia os backups periódicos; um intervalo <= 0 desativa o agendador
+func (s *BackupScheduler) Start() {
+	if s.interval <= 0 {
+		return
+	}
+	s.startOnce.Do(func() {
+		s.wg.Add(1)
+		go s.loop()
+	})
+}
+
+// Stop interrompe os backups e aguarda o que estiver em andamento
+func (s *BackupScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+	})
+	s.wg.Wait()
+}
+
+func (s *BackupScheduler) loop() {
+	defer s.wg.Done()
+
+	s.BackupIfDue(time.Now())
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopCh:
+			return
+		case now := <-ticker.C:
+			s.BackupIfDue(now)
+		}
+	}
+}
+
+// BackupIfDue faz um backup se o mais recente tiver pelo menos um intervalo de idade
+func (s *BackupScheduler) BackupIfDue(now time.Time) {
+	backups, err := s.backups.List()
+	if err != nil {
+		s.logger.Errorf("backup failed: %v", err)
+		return
+	}
+	if len(backups) > 0 && now.Sub(backups[0].TakenAt) < s.interval {
+		return
+	}
+
+	backup, err := s.backups.Create(s.db, now)
+	if err != nil {
+		s.logger.Errorf("backup failed: %v", err)
+		return
+	}
+	s.logger.Infof("database backed up to %s", backup.Path)
+}
@@ -0,0 +1,175 @@
+package storage_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	storage "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage"
+	model "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+)
+
+func newMigratedDB(t *testing.T) (*gorm.DB, string) {
+	db, path := openTestDB(t)
+	require.NoError(t, storage.Migrate(db, path))
+	return db, path
+}
+
+func saveOperation(t *testing.T, db *gorm.DB, id string, at time.Time) {
+	require.NoError(t, db.Create(&model.BoostOperation{
+		ID: id, BoosterID: "dns_flush", Type: entities.ApplyOperationType, AppliedAt: at, QueuedAt: at, CreatedAt: at,
+	}).Error)
+}
+
+func closeTestDB(t *testing.T, db *gorm.DB) {
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	require.NoError(t, sqlDB.Close())
+}
+
+// corruptIndex sobrescreve a página de um índice: o banco abre e a tabela
+// continua legível, mas o integrity_check acusa o problema
+func corruptIndex(t *testing.T, db *gorm.DB, path, index string) {
+	var rootPage, pageSize int64
+	require.NoError(t, db.Raw("SELECT rootpage FROM sqlite_master WHERE name = ?", index).Scan(&rootPage).Error)
+	require.NoError(t, db.Raw("PRAGMA page_size").Scan(&pageSize).Error)
+	closeTestDB(t, db)
+
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	require.NoError(t, err)
+	defer f.Close()
+	garbage := make([]byte, pageSize)
+	for i := range garbage {
+		garbage[i] = 0xA5
+	}
+	_, err = f.WriteAt(garbage, (rootPage-1)*pageSize)
+	require.NoError(t, err)
+}
+
+func TestBackups_CreateKeepsNewestAndSkipsCorruptedDatabase(t *testing.T) {
+	db, path := newMigratedDB(t)
+	backups := storage.NewBackups(filepath.Join(t.TempDir(), "backups"), 3)
+
+	start := time.Date(2025, 1, 10, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		_, err := backups.Create(db, start.Add(time.Duration(i)*time.Hour))
+		require.NoError(t, err)
+	}
+
+	list, err := backups.List()
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	assert.Equal(t, start.Add(4*time.Hour), list[0].TakenAt)
+	assert.Equal(t, start.Add(2*time.Hour), list[2].TakenAt)
+
+	corruptIndex(t, db, path, "idx_boosts_operations_booster_id")
+	db = reopen(t, path)
+
+	_, err = backups.Create(db, start.Add(5*time.Hour))
+	assert.ErrorIs(t, err, storage.ErrDatabaseCorrupted)
+
+	list, err = backups.List()
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(4*time.Hour), list[0].TakenAt, "a corrupted database must not replace good backups")
+}
+
+func TestOpenDB_RestoresNewestGoodBackupAndReportsLostOperations(t *testing.T) {
+	db, path := newMigratedDB(t)
+	backups := storage.NewBackups(filepath.Join(t.TempDir(), "backups"), 5)
+
+	now := time.Now().UTC()
+	saveOperation(t, db, "op-before", now.Add(-2*time.Hour))
+	good, err := backups.Create(db, now.Add(-time.Hour))
+	require.NoError(t, err)
+
+	// um backup mais novo, mas ilegível, é ignorado
+	bad := filepath.Join(filepath.Dir(good.Path), "data_store-"+now.Add(-time.Minute).Format("20060102T150405Z")+".db")
+	require.NoError(t, os.WriteFile(bad, []byte("not a database"), 0o644))
+
+	saveOperation(t, db, "op-after", now.Add(-30*time.Minute))
+	require.NoError(t, db.Create(&model.OperationJournalEntry{
+		OperationID: "op-journal", BoosterID: "dns_flush", Operation: entities.RevertOperationType,
+		Phase: entities.JournalStarted, QueuedAt: now.Add(-10 * time.Minute),
+	}).Error)
+	corruptIndex(t, db, path, "idx_boosts_operations_booster_id")
+
+	restored, recovery, err := storage.OpenDB(path, backups)
+	require.NoError(t, err)
+	t.Cleanup(func() { closeTestDB(t, restored) })
+	require.NotNil(t, recovery)
+
+	assert.NotEmpty(t, recovery.Issues)
+	assert.Equal(t, good.Path, recovery.RestoredFrom)
+	assert.False(t, recovery.LostOperationsUnknown)
+	require.Len(t, recovery.LostOperations, 2)
+	assert.Equal(t, "op-after", recovery.LostOperations[0].OperationID)
+	assert.Equal(t, "op-journal", recovery.LostOperations[1].OperationID)
+	assert.Equal(t, entities.RevertOperationType, recovery.LostOperations[1].Operation)
+
+	_, err = os.Stat(recovery.CorruptedCopy)
+	assert.NoError(t, err, "the corrupted database must be kept")
+
+	require.NoError(t, storage.CheckIntegrity(restored))
+	var ids []string
+	require.NoError(t, restored.Model(&model.BoostOperation{}).Pluck("id", &ids).Error)
+	assert.Equal(t, []string{"op-before"}, ids)
+}
+
+func TestOpenDB_UnreadableDatabaseWithoutBackupStartsEmpty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "data_store.db")
+	require.NoError(t, os.WriteFile(path, []byte("this is not a sqlite database at all, just garbage"), 0o644))
+
+	db, recovery, err := storage.OpenDB(path, storage.NewBackups(filepath.Join(t.TempDir(), "backups"), 5))
+	require.NoError(t, err)
+	t.Cleanup(func() { closeTestDB(t, db) })
+
+	require.NotNil(t, recovery)
+	assert.Empty(t, recovery.RestoredFrom)
+	assert.True(t, recovery.LostOperationsUnknown)
+	require.NoError(t, storage.Migrate(db.WithContext(storage.WithBackupCipher(context.Background(), testCipher(t))), path))
+	require.NoError(t, storage.CheckIntegrity(db))
+}
+
+func TestOpenDB_HealthyDatabaseIsNotRestored(t *testing.T) {
+	db, path := newMigratedDB(t)
+	saveOperation(t, db, "op-1", time.Now())
+	closeTestDB(t, db)
+
+	db, recovery, err := storage.OpenDB(path, storage.NewBackups(filepath.Join(t.TempDir(), "backups"), 5))
+	require.NoError(t, err)
+	t.Cleanup(func() { closeTestDB(t, db) })
+	assert.Nil(t, recovery)
+}
+
+func TestBackupScheduler_BacksUpOncePerInterval(t *testing.T) {
+	db, _ := newMigratedDB(t)
+	backups := storage.NewBackups(filepath.Join(t.TempDir(), "backups"), 5)
+	scheduler := storage.NewBackupScheduler(db, backups, time.Hour)
+
+	now := time.Now()
+	scheduler.BackupIfDue(now)
+	scheduler.BackupIfDue(now.Add(30 * time.Minute))
+	list, err := backups.List()
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	scheduler.BackupIfDue(now.Add(61 * time.Minute))
+	list, err = backups.List()
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+}
+
+func reopen(t *testing.T, path string) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	require.NoError(t, err)
+	t.Cleanup(func() { closeTestDB(t, db) })
+	return db
+}
@@ -1,39 +1,67 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/adrg/xdg"
 	"github.com/oLenador/mulltbost/internal/config"
-	"gorm.io/driver/sqlite"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/secrets"
 	"gorm.io/gorm"
 )
 
-func NewDB() (*gorm.DB, error) {
-    // Diretório onde o banco vai ficar
-    dbDir := filepath.Join(xdg.DataHome, config.UserDir)
+// DBPath é o caminho do data_store.db do usuário
+func DBPath() string {
+    return filepath.Join(xdg.DataHome, config.UserDir, config.UserDbName)
+}
+
+// NewDB abre o banco, restaura o backup mais recente se ele estiver corrompido
+// e aplica as migrações pendentes. Um banco de uma versão mais nova do app é
+// recusado com ErrDatabaseTooNew. O relatório só é retornado se houve restauração.
+// As chaves dos backups de rollback vêm de stores (veja OpenBackupCipher) e são
+// trocadas quando vencem.
+func NewDB(stores []secrets.KeyStore) (*gorm.DB, *secrets.Cipher, *entities.DatabaseRecovery, error) {
+    // Caminho completo do arquivo
+    dbPath := DBPath()
 
-    if err := os.MkdirAll(dbDir, 0o755); err != nil {
-        return nil, fmt.Errorf("criar diretório do banco: %w", err)
+    if err := os.MkdirAll(filepath.Dir(dbPath), 0o755); err != nil {
+        return nil, nil, nil, fmt.Errorf("criar diretório do banco: %w", err)
     }
 
-    // Caminho completo do arquivo
-    dbPath := filepath.Join(dbDir, config.UserDbName)
+    backups := NewBackups(BackupDir(), DefaultBackupsKept)
+    gormDB, recovery, err := OpenDB(dbPath, backups)
+    if err != nil {
+        return nil, nil, nil, fmt.Errorf("abrir sqlite com gorm: %w", err)
+    }
 
-    gormDB, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+    cipher, err := OpenBackupCipher(gormDB, time.Now(), stores...)
     if err != nil {
-        return nil, fmt.Errorf("abrir sqlite com gorm: %w", err)
+        closeDB(gormDB)
+        return nil, nil, nil, fmt.Errorf("carregar chaves dos backups: %w", err)
     }
 
-    return gormDB, nil
-}
+    if err := Migrate(gormDB.WithContext(WithBackupCipher(context.Background(), cipher)), dbPath); err != nil {
+        closeDB(gormDB)
+        return nil, nil, nil, fmt.Errorf("migrar banco: %w", err)
+    }
 
+    if err := RemovePlaintextCopies(dbPath, backups, cipher); err != nil {
+        dbLogger.Errorf("failed to remove unencrypted database copies: %v", err)
+    }
 
-func AutoMigrateModels(db *gorm.DB, models ...interface{}) error {
-	if db == nil {
-		return fmt.Errorf("db is nil")
-	}
-	return db.AutoMigrate(models...)
-}
\ No newline at end of file
+    // Uma rotação que falhou não impede o app de abrir: as chaves antigas
+    // continuam no keyset e a próxima inicialização tenta de novo
+    copies, err := DatabaseCopies(dbPath, backups)
+    if err == nil {
+        err = RotateBackupKeyIfDue(gormDB, cipher, time.Now(), DefaultKeyRotationInterval, copies...)
+    }
+    if err != nil {
+        dbLogger.Errorf("backup key rotation failed: %v", err)
+    }
+
+    return gormDB, cipher, recovery, nil
+}
@@ -0,0 +1,257 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+var ErrDatabaseCorrupted = errors.New("database is corrupted")
+
+// CheckIntegrity roda PRAGMA integrity_check e retorna ErrDatabaseCorrupted com
+// os problemas encontrados
+func CheckIntegrity(db *gorm.DB) error {
+	issues, err := integrityIssues(db)
+	if err != nil {
+		return err
+	}
+	if len(issues) > 0 {
+		return fmt.Errorf("%w: %s", ErrDatabaseCorrupted, strings.Join(issues, "; "))
+	}
+	return nil
+}
+
+// integrityIssues retorna os problemas do banco; um arquivo que o SQLite nem
+// reconhece como banco também vira um problema, não um erro
+func integrityIssues(db *gorm.DB) ([]string, error) {
+	var rows []string
+	if err := db.Raw("PRAGMA integrity_check").Scan(&rows).Error; err != nil {
+		if isCorruption(err) {
+			return []string{err.Error()}, nil
+		}
+		return nil, fmt.Errorf("failed to check database integrity: %w", err)
+	}
+	if len(rows) == 1 && rows[0] == "ok" {
+		return nil, nil
+	}
+	return rows, nil
+}
+
+// isCorruption reconhece SQLITE_CORRUPT e SQLITE_NOTADB pela mensagem, que é
+// a mesma em qualquer build do driver
+func isCorruption(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "database disk image is malformed") || strings.Contains(msg, "file is not a database")
+}
+
+// OpenDB abre o banco em dbPath e verifica a integridade. Se estiver
+// corrompido, o arquivo é guardado ao lado com o sufixo .corrupt-<data>, o
+// backup bom mais recente é restaurado e o relatório da restauração é
+// retornado; sem corrupção o relatório é nil.
+func OpenDB(dbPath string, backups *Backups) (*gorm.DB, *entities.DatabaseRecovery, error) {
+	var issues []string
+	db, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	switch {
+	case err != nil && isCorruption(err):
+		issues = []string{err.Error()}
+	case err != nil:
+		return nil, nil, fmt.Errorf("failed to open database: %w", err)
+	default:
+		issues, err = integrityIssues(db)
+		if err != nil {
+			closeDB(db)
+			return nil, nil, err
+		}
+		if len(issues) == 0 {
+			return db, nil, nil
+		}
+		closeDB(db)
+	}
+
+	dbLogger.Errorf("database %s is corrupted: %s", dbPath, strings.Join(issues, "; "))
+
+	recovery, err := recoverDatabase(dbPath, backups, issues, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+	db, err = gorm.Open(sqlite.Open(dbPath), &gorm.Config{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open restored database: %w", err)
+	}
+	return db, recovery, nil
+}
+
+func recoverDatabase(dbPath string, backups *Backups, issues []string, now time.Time) (*entities.DatabaseRecovery, error) {
+	recovery := &entities.DatabaseRecovery{
+		RecoveredAt:   now,
+		Issues:        issues,
+		CorruptedCopy: fmt.Sprintf("%s.corrupt-%s", dbPath, now.UTC().Format(backupTimeLayout)),
+	}
+	if err := moveAside(dbPath, recovery.CorruptedCopy); err != nil {
+		return nil, err
+	}
+
+	backup, err := newestGoodBackup(backups)
+	if err != nil {
+		return nil, err
+	}
+	if backup == nil {
+		dbLogger.Errorf("no usable backup found, starting with an empty database")
+		recovery.LostOperationsUnknown = true
+		return recovery, nil
+	}
+	if err := copyFile(backup.Path, dbPath); err != nil {
+		return nil, fmt.Errorf("failed to restore backup %s: %w", backup.Path, err)
+	}
+	takenAt := backup.TakenAt
+	recovery.RestoredFrom = backup.Path
+	recovery.BackupTakenAt = &takenAt
+
+	lost, err := operationsSince(recovery.CorruptedCopy, takenAt)
+	if err != nil {
+		dbLogger.Warnf("could not read operations from the corrupted database: %v", err)
+		recovery.LostOperationsUnknown = true
+	}
+	recovery.LostOperations = lost
+
+	dbLogger.Warnf("database restored from %s; %d operation(s) recorded after the backup were lost",
+		backup.Path, len(lost))
+	return recovery, nil
+}
+
+// moveAside renomeia o banco e os arquivos de journal que pertencem a ele
+func moveAside(dbPath, target string) error {
+	for _, suffix := range []string{"", "-journal", "-wal", "-shm"} {
+		err := os.Rename(dbPath+suffix, target+suffix)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to move corrupted database aside: %w", err)
+		}
+	}
+	return nil
+}
+
+func newestGoodBackup(backups *Backups) (*Backup, error) {
+	if backups == nil {
+		return nil, nil
+	}
+	list, err := backups.List()
+	if err != nil {
+		return nil, err
+	}
+	for i := range list {
+		if err := checkFile(list[i].Path); err != nil {
+			dbLogger.Warnf("skipping backup %s: %v", list[i].Path, err)
+			continue
+		}
+		return &list[i], nil
+	}
+	return nil, nil
+}
+
+func checkFile(path string) error {
+	db, err := openReadOnly(path)
+	if err != nil {
+		return err
+	}
+	defer closeDB(db)
+	return CheckIntegrity(db)
+}
+
+func openReadOnly(path string) (*gorm.DB, error) {
+	return gorm.Open(sqlite.Open("file:"+path+"?mode=ro"), &gorm.Config{Logger: gormlogger.Discard})
+}
+
+// operationsSince lê, do banco corrompido, as operações registradas depois de
+// since. A leitura é feita por tabela e ignora as que estiverem ilegíveis; só
+// retorna erro se nenhuma puder ser lida.
+func operationsSince(path string, since time.Time) ([]entities.LostOperation, error) {
+	db, err := openReadOnly(path)
+	if err != nil {
+		return nil, err
+	}
+	defer closeDB(db)
+
+	found := make(map[string]entities.LostOperation)
+	var errs []error
+
+	var operations []struct {
+		ID        string
+		BoosterID string
+		Type      string
+		CreatedAt time.Time
+	}
+	if err := db.Raw("SELECT id, booster_id, type, created_at FROM boosts_operations").Scan(&operations).Error; err != nil {
+		errs = append(errs, err)
+	}
+	for _, op := range operations {
+		if op.CreatedAt.After(since) {
+			found[op.ID] = entities.LostOperation{OperationID: op.ID, BoosterID: op.BoosterID,
+				Operation: entities.BoosterOperationType(op.Type), At: op.CreatedAt}
+		}
+	}
+
+	var journal []struct {
+		OperationID string
+		BoosterID   string
+		Operation   string
+		QueuedAt    time.Time
+	}
+	if err := db.Raw("SELECT operation_id, booster_id, operation, queued_at FROM operation_journal").Scan(&journal).Error; err != nil {
+		errs = append(errs, err)
+	}
+	for _, entry := range journal {
+		if _, ok := found[entry.OperationID]; ok || !entry.QueuedAt.After(since) {
+			continue
+		}
+		found[entry.OperationID] = entities.LostOperation{OperationID: entry.OperationID, BoosterID: entry.BoosterID,
+			Operation: entities.BoosterOperationType(entry.Operation), At: entry.QueuedAt}
+	}
+
+	if len(errs) == 2 {
+		return nil, errors.Join(errs...)
+	}
+
+	lost := make([]entities.LostOperation, 0, len(found))
+	for _, op := range found {
+		lost = append(lost, op)
+	}
+	sort.Slice(lost, func(i, j int) bool { return lost[i].At.Before(lost[j].At) })
+	return lost, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func closeDB(db *gorm.DB) {
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
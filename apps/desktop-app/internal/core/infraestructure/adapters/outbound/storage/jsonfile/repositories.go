@@ -0,0 +1,77 @@
+package jsonfile
+
+import (
+	"context"
+	"time"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/memory"
+)
+
+// Os repositórios abaixo delegam ao repositório em memória e gravam o arquivo
+// depois de cada método que altera o estado
+
+type RollbackRepo struct {
+	*memory.RollbackRepo
+	store *Store
+}
+
+func (r *RollbackRepo) Save(ctx context.Context, s *entities.BoosterRollbackState) error {
+	if err := r.RollbackRepo.Save(ctx, s); err != nil {
+		return err
+	}
+	return r.store.flush(ctx)
+}
+
+func (r *RollbackRepo) Delete(ctx context.Context, id string) error {
+	if err := r.RollbackRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	return r.store.flush(ctx)
+}
+
+type ActivationRepo struct {
+	*memory.ActivationRepo
+	store *Store
+}
+
+func (r *ActivationRepo) SyncWithAvailableBoosts(ctx context.Context, boosts []entities.Booster) error {
+	if err := r.ActivationRepo.SyncWithAvailableBoosts(ctx, boosts); err != nil {
+		return err
+	}
+	return r.store.flush(ctx)
+}
+
+func (r *ActivationRepo) SetAppliedState(ctx context.Context, boosterID string, applied bool, errMsg string) error {
+	if err := r.ActivationRepo.SetAppliedState(ctx, boosterID, applied, errMsg); err != nil {
+		return err
+	}
+	return r.store.flush(ctx)
+}
+
+type OperationsRepo struct {
+	*memory.OperationsRepo
+	store *Store
+}
+
+func (r *OperationsRepo) Save(ctx context.Context, entity *entities.BoostOperation) error {
+	if err := r.OperationsRepo.Save(ctx, entity); err != nil {
+		return err
+	}
+	return r.store.flush(ctx)
+}
+
+func (r *OperationsRepo) CloseUnfinished(ctx context.Context, status entities.OperationStatus, errMsg string, completedAt time.Time) (int64, error) {
+	closed, err := r.OperationsRepo.CloseUnfinished(ctx, status, errMsg, completedAt)
+	if err != nil || closed == 0 {
+		return closed, err
+	}
+	return closed, r.store.flush(ctx)
+}
+
+func (r *OperationsRepo) DeleteOlderThan(ctx context.Context, olderThan time.Time) error {
+	if err := r.OperationsRepo.DeleteOlderThan(ctx, olderThan); err != nil {
+		return err
+	}
+	return r.store.flush(ctx)
+}
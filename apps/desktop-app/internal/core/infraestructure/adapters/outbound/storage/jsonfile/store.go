@@ -0,0 +1,102 @@
+// Package jsonfile guarda os repositórios do booster num arquivo JSON, para
+// ferramentas que não usam o SQLite. Os dados ficam nos repositórios em memória
+// e o arquivo inteiro é regravado a cada alteração.
+package jsonfile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/memory"
+)
+
+const fileVersion = 1
+
+type storeFile struct {
+	Version    int                               `json:"version"`
+	Rollback   []entities.BoosterRollbackState   `json:"rollback"`
+	Activation []entities.BoosterActivationState `json:"activation"`
+	Operations []entities.BoostOperation         `json:"operations"`
+}
+
+// Store é um arquivo com os três repositórios. Se a gravação falhar, a
+// alteração continua valendo em memória e o erro é retornado a quem a fez.
+type Store struct {
+	path string
+	// mu serializa as gravações; cada uma lê o estado atual, então a última
+	// gravação sempre inclui todas as alterações anteriores
+	mu         sync.Mutex
+	rollback   *RollbackRepo
+	activation *ActivationRepo
+	operations *OperationsRepo
+}
+
+// Open carrega o arquivo em path; um arquivo inexistente é um store vazio e só
+// é criado na primeira alteração
+func Open(path string) (*Store, error) {
+	var file storeFile
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if file.Version > fileVersion {
+			return nil, fmt.Errorf("%s has version %d, this build supports up to %d", path, file.Version, fileVersion)
+		}
+	}
+
+	s := &Store{path: path}
+	s.rollback = &RollbackRepo{RollbackRepo: memory.NewRollbackRepo(file.Rollback...), store: s}
+	s.activation = &ActivationRepo{ActivationRepo: memory.NewActivationRepo(file.Activation...), store: s}
+	s.operations = &OperationsRepo{OperationsRepo: memory.NewOperationsRepo(file.Operations...), store: s}
+	return s, nil
+}
+
+func (s *Store) Rollback() *RollbackRepo     { return s.rollback }
+func (s *Store) Activation() *ActivationRepo { return s.activation }
+func (s *Store) Operations() *OperationsRepo { return s.operations }
+
+func (s *Store) flush(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file := storeFile{Version: fileVersion}
+	var err error
+	if file.Rollback, err = s.rollback.GetAll(ctx); err != nil {
+		return err
+	}
+	if file.Activation, err = s.activation.GetAll(ctx); err != nil {
+		return err
+	}
+	operations, err := s.operations.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+	file.Operations = *operations
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	// Grava num temporário e renomeia para não deixar o arquivo pela metade
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", s.path, err)
+	}
+	return nil
+}
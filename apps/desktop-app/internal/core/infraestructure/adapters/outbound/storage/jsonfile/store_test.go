@@ -0,0 +1,80 @@
+package jsonfile_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/application/ports/outbound"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/jsonfile"
+)
+
+func TestStore_PersistsAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "booster.json")
+	ctx := context.Background()
+
+	store, err := jsonfile.Open(path)
+	require.NoError(t, err)
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "opening must not create the file")
+
+	var rollback outbound.BoosterStateRepository = store.Rollback()
+	var activation outbound.BoosterActivationRepository = store.Activation()
+	var operations outbound.OperationHistoryRepository = store.Operations()
+
+	require.NoError(t, activation.SyncWithAvailableBoosts(ctx, []entities.Booster{{ID: "dns_flush", Version: "1.0.0"}}))
+	require.NoError(t, activation.SetAppliedState(ctx, "dns_flush", true, ""))
+	require.NoError(t, rollback.Save(ctx, &entities.BoosterRollbackState{
+		ID: "dns_flush", Applied: true, BackupData: entities.BackupData{"resolv_conf": "nameserver 1.1.1.1"},
+	}))
+	queuedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, operations.Save(ctx, &entities.BoostOperation{
+		ID: "op-1", BoosterID: "dns_flush", Type: entities.ApplyOperationType, Status: entities.OperationQueued,
+		AppliedAt: queuedAt, QueuedAt: queuedAt,
+	}))
+
+	reopened, err := jsonfile.Open(path)
+	require.NoError(t, err)
+
+	state, err := reopened.Activation().GetBoostState(ctx, "dns_flush")
+	require.NoError(t, err)
+	assert.True(t, state.IsApplied)
+	assert.Equal(t, entities.StatusActive, state.Status)
+
+	backup, err := reopened.Rollback().GetByID(ctx, "dns_flush")
+	require.NoError(t, err)
+	require.NotNil(t, backup)
+	assert.Equal(t, "nameserver 1.1.1.1", backup.BackupData["resolv_conf"])
+
+	closed, err := reopened.Operations().CloseUnfinished(ctx, entities.OperationFailed, "interrupted", queuedAt.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), closed)
+
+	reopened, err = jsonfile.Open(path)
+	require.NoError(t, err)
+	op, err := reopened.Operations().GetByID(ctx, "op-1")
+	require.NoError(t, err)
+	require.NotNil(t, op)
+	assert.Equal(t, entities.OperationFailed, op.Status)
+	assert.True(t, queuedAt.Equal(op.QueuedAt))
+}
+
+func TestOpen_RejectsUnreadableAndNewerFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	garbage := filepath.Join(dir, "garbage.json")
+	require.NoError(t, os.WriteFile(garbage, []byte("{not json"), 0o644))
+	_, err := jsonfile.Open(garbage)
+	assert.Error(t, err)
+
+	newer := filepath.Join(dir, "newer.json")
+	require.NoError(t, os.WriteFile(newer, []byte(`{"version": 99}`), 0o644))
+	_, err = jsonfile.Open(newer)
+	assert.Error(t, err)
+}
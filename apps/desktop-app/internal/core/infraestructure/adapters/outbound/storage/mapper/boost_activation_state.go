@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	model "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+)
+
+func MapActivationToDomain(m *model.BoostActivationState) *entities.BoosterActivationState {
+	if m == nil {
+		return nil
+	}
+	return &entities.BoosterActivationState{
+		ID:           m.ID,
+		IsApplied:    m.IsApplied,
+		AppliedAt:    m.AppliedAt,
+		RevertedAt:   m.RevertedAt,
+		Version:      m.Version,
+		Status:       m.Status,
+		ErrorMessage: m.ErrorMessage,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+func MapActivationFromDomain(e *entities.BoosterActivationState) *model.BoostActivationState {
+	if e == nil {
+		return nil
+	}
+	return &model.BoostActivationState{
+		ID:           e.ID,
+		IsApplied:    e.IsApplied,
+		AppliedAt:    e.AppliedAt,
+		RevertedAt:   e.RevertedAt,
+		Version:      e.Version,
+		Status:       e.Status,
+		ErrorMessage: e.ErrorMessage,
+		CreatedAt:    e.CreatedAt,
+		UpdatedAt:    e.UpdatedAt,
+	}
+}
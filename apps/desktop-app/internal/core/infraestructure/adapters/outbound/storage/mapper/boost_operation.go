@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"time"
+
 	"github.com/oLenador/mulltbost/internal/core/domain/entities"
 	storage "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
 )
@@ -11,12 +13,21 @@ func MapAppliedToDomain(a *storage.BoostOperation) *entities.BoostOperation {
 	}
 
 	return &entities.BoostOperation{
-		ID:         a.ID,
-		BoosterID:  a.BoosterID,
-		AppliedAt:  a.AppliedAt,
-		RevertedAt: a.RevertedAt,
-		Type:       a.Type,
-		ErrorMsg:   a.ErrorMsg,
+		ID:          a.ID,
+		BoosterID:   a.BoosterID,
+		AppliedAt:   a.AppliedAt,
+		RevertedAt:  a.RevertedAt,
+		Type:        a.Type,
+		ErrorMsg:    a.ErrorMsg,
+		Attempts:    a.Attempts,
+		BatchID:     a.BatchID,
+		Status:      a.Status,
+		Message:     a.Message,
+		QueuedAt:    a.QueuedAt,
+		StartedAt:   a.StartedAt,
+		CompletedAt: a.CompletedAt,
+		Duration:    time.Duration(a.DurationMs) * time.Millisecond,
+		Version:     a.Version,
 	}
 }
 
@@ -26,10 +37,20 @@ func MapAppliedFromDomain(e *entities.BoostOperation) *storage.BoostOperation {
 	}
 
 	return &storage.BoostOperation{
-		ID:         e.ID,
-		BoosterID:  e.BoosterID,
-		AppliedAt:  e.AppliedAt,
-		RevertedAt: e.RevertedAt,
-		ErrorMsg:   e.ErrorMsg,
+		ID:          e.ID,
+		BoosterID:   e.BoosterID,
+		AppliedAt:   e.AppliedAt,
+		RevertedAt:  e.RevertedAt,
+		Type:        e.Type,
+		ErrorMsg:    e.ErrorMsg,
+		Attempts:    e.Attempts,
+		BatchID:     e.BatchID,
+		Status:      e.Status,
+		Message:     e.Message,
+		QueuedAt:    e.QueuedAt,
+		StartedAt:   e.StartedAt,
+		CompletedAt: e.CompletedAt,
+		DurationMs:  e.Duration.Milliseconds(),
+		Version:     e.Version,
 	}
 }
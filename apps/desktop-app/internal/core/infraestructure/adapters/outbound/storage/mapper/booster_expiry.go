@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	model "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+)
+
+func MapBoosterExpiryToDomain(m *model.BoosterExpiry) *entities.BoosterExpiry {
+	if m == nil {
+		return nil
+	}
+	return &entities.BoosterExpiry{
+		BoosterID: m.BoosterID,
+		ExpiresAt: m.ExpiresAt,
+		WarnedAt:  m.WarnedAt,
+		CreatedAt: m.CreatedAt,
+	}
+}
+
+func MapBoosterExpiryFromDomain(e *entities.BoosterExpiry) *model.BoosterExpiry {
+	if e == nil {
+		return nil
+	}
+	return &model.BoosterExpiry{
+		BoosterID: e.BoosterID,
+		ExpiresAt: e.ExpiresAt,
+		WarnedAt:  e.WarnedAt,
+		CreatedAt: e.CreatedAt,
+	}
+}
@@ -0,0 +1,65 @@
+package storage
+
+import (
+	"strings"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	model "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+	"gorm.io/datatypes"
+)
+
+func MapJournalToDomain(m *model.OperationJournalEntry) *entities.JournalEntry {
+	if m == nil {
+		return nil
+	}
+
+	var dependsOn []string
+	if m.DependsOn != "" {
+		dependsOn = strings.Split(m.DependsOn, ",")
+	}
+
+	backup := make(map[string]interface{})
+	if m.BackupData != nil {
+		backup = map[string]interface{}(m.BackupData)
+	}
+
+	return &entities.JournalEntry{
+		OperationID: m.OperationID,
+		BoosterID:   m.BoosterID,
+		Operation:   m.Operation,
+		BatchID:     m.BatchID,
+		DependsOn:   dependsOn,
+		Phase:       m.Phase,
+		Success:     m.Success,
+		Message:     m.Message,
+		BackupData:  backup,
+		Error:       m.Error,
+		QueuedAt:    m.QueuedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func MapJournalFromDomain(e *entities.JournalEntry) *model.OperationJournalEntry {
+	if e == nil {
+		return nil
+	}
+
+	backup := datatypes.JSONMap{}
+	if e.BackupData != nil {
+		backup = datatypes.JSONMap(e.BackupData)
+	}
+
+	return &model.OperationJournalEntry{
+		OperationID: e.OperationID,
+		BoosterID:   e.BoosterID,
+		Operation:   e.Operation,
+		BatchID:     e.BatchID,
+		DependsOn:   strings.Join(e.DependsOn, ","),
+		Phase:       e.Phase,
+		Success:     e.Success,
+		Message:     e.Message,
+		BackupData:  backup,
+		Error:       e.Error,
+		QueuedAt:    e.QueuedAt,
+	}
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	model "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+	"gorm.io/datatypes"
+)
+
+func MapSnapshotToDomain(m *model.SystemSnapshot) *entities.Snapshot {
+	if m == nil {
+		return nil
+	}
+
+	boosters := make([]entities.SnapshotBooster, len(m.Boosters))
+	copy(boosters, m.Boosters)
+
+	return &entities.Snapshot{
+		ID:        m.ID,
+		Name:      m.Name,
+		Origin:    m.Origin,
+		BatchID:   m.BatchID,
+		CreatedAt: m.CreatedAt,
+		Boosters:  boosters,
+	}
+}
+
+func MapSnapshotFromDomain(e *entities.Snapshot) *model.SystemSnapshot {
+	if e == nil {
+		return nil
+	}
+
+	boosters := datatypes.JSONSlice[entities.SnapshotBooster]{}
+	if e.Boosters != nil {
+		boosters = datatypes.JSONSlice[entities.SnapshotBooster](e.Boosters)
+	}
+
+	return &model.SystemSnapshot{
+		ID:        e.ID,
+		Name:      e.Name,
+		Origin:    e.Origin,
+		BatchID:   e.BatchID,
+		CreatedAt: e.CreatedAt,
+		Boosters:  boosters,
+	}
+}
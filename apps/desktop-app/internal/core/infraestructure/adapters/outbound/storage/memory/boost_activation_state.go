@@ -0,0 +1,117 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oLenador/mulltbost/internal/core/application/ports/outbound"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+type ActivationRepo struct {
+	mu     sync.RWMutex
+	states map[string]entities.BoosterActivationState
+}
+
+func NewActivationRepo(states ...entities.BoosterActivationState) *ActivationRepo {
+	r := &ActivationRepo{states: make(map[string]entities.BoosterActivationState, len(states))}
+	for _, s := range states {
+		r.states[s.ID] = s
+	}
+	return r
+}
+
+// SyncWithAvailableBoosts segue as regras do repositório do banco: boosters
+// novos começam inativos e os que saíram do código ficam obsoletos e desativados
+func (r *ActivationRepo) SyncWithAvailableBoosts(ctx context.Context, boosts []entities.Booster) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	available := make(map[string]bool, len(boosts))
+	for _, boost := range boosts {
+		available[boost.ID] = true
+		state, exists := r.states[boost.ID]
+		if !exists {
+			r.states[boost.ID] = entities.BoosterActivationState{
+				ID:        boost.ID,
+				Version:   boost.Version,
+				Status:    entities.StatusInactive,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			continue
+		}
+		if state.Version != boost.Version {
+			state.Version = boost.Version
+			state.UpdatedAt = now
+			r.states[boost.ID] = state
+		}
+	}
+
+	for id, state := range r.states {
+		if available[id] {
+			continue
+		}
+		if state.IsApplied {
+			state.IsApplied = false
+			state.RevertedAt = &now
+			state.ErrorMessage = "Boost removido da versão atual"
+		}
+		state.Status = entities.StatusObsolete
+		state.UpdatedAt = now
+		r.states[id] = state
+	}
+	return nil
+}
+
+func (r *ActivationRepo) GetBoostState(ctx context.Context, boosterID string) (*entities.BoosterActivationState, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	state, ok := r.states[boosterID]
+	if !ok {
+		return nil, fmt.Errorf("boost %s não encontrado: %w", boosterID, outbound.ErrActivationStateNotFound)
+	}
+	return &state, nil
+}
+
+// GetAll retorna os estados ordenados por ID, incluindo os obsoletos
+func (r *ActivationRepo) GetAll(ctx context.Context) ([]entities.BoosterActivationState, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]entities.BoosterActivationState, 0, len(r.states))
+	for _, state := range r.states {
+		result = append(result, state)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *ActivationRepo) SetAppliedState(ctx context.Context, boosterID string, applied bool, errMsg string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	state, ok := r.states[boosterID]
+	if !ok {
+		return fmt.Errorf("boost %s não encontrado: %w", boosterID, outbound.ErrActivationStateNotFound)
+	}
+
+	now := time.Now()
+	state.IsApplied = applied
+	state.ErrorMessage = errMsg
+	state.UpdatedAt = now
+	if applied {
+		if state.AppliedAt == nil {
+			state.AppliedAt = &now
+		}
+		state.RevertedAt = nil
+		state.Status = entities.StatusActive
+	} else {
+		state.RevertedAt = &now
+		state.Status = entities.StatusInactive
+	}
+	r.states[boosterID] = state
+	return nil
+}
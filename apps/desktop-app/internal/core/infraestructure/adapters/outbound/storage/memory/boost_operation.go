@@ -0,0 +1,168 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+// OperationsRepo guarda as operações na ordem em que foram gravadas pela
+// primeira vez, como as linhas de uma tabela
+type OperationsRepo struct {
+	mu    sync.RWMutex
+	ops   []entities.BoostOperation
+	index map[string]int
+}
+
+func NewOperationsRepo(ops ...entities.BoostOperation) *OperationsRepo {
+	r := &OperationsRepo{index: make(map[string]int, len(ops))}
+	for _, op := range ops {
+		r.put(op)
+	}
+	return r
+}
+
+func (r *OperationsRepo) put(op entities.BoostOperation) {
+	if i, ok := r.index[op.ID]; ok {
+		r.ops[i] = op
+		return
+	}
+	r.index[op.ID] = len(r.ops)
+	r.ops = append(r.ops, op)
+}
+
+func (r *OperationsRepo) Save(ctx context.Context, entity *entities.BoostOperation) error {
+	if entity == nil {
+		return errors.New("nil applied boost")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.put(*entity)
+	return nil
+}
+
+func (r *OperationsRepo) GetByID(ctx context.Context, id string) (*entities.BoostOperation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	i, ok := r.index[id]
+	if !ok {
+		return nil, nil
+	}
+	op := r.ops[i]
+	return &op, nil
+}
+
+// filter copia as operações aceitas por keep, na ordem de gravação
+func (r *OperationsRepo) filter(keep func(op *entities.BoostOperation) bool) []entities.BoostOperation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]entities.BoostOperation, 0)
+	for i := range r.ops {
+		if keep(&r.ops[i]) {
+			result = append(result, r.ops[i])
+		}
+	}
+	return result
+}
+
+// GetByBatchID retorna as operações do lote na ordem em que foram enfileiradas
+func (r *OperationsRepo) GetByBatchID(ctx context.Context, batchID string) ([]entities.BoostOperation, error) {
+	result := r.filter(func(op *entities.BoostOperation) bool { return op.BatchID == batchID })
+	sort.SliceStable(result, func(i, j int) bool { return result[i].QueuedAt.Before(result[j].QueuedAt) })
+	return result, nil
+}
+
+// Query retorna as operações do filtro, usando a data de submissão para a janela de tempo
+func (r *OperationsRepo) Query(ctx context.Context, filter entities.OperationStatsFilter) ([]entities.BoostOperation, error) {
+	return r.filter(func(op *entities.BoostOperation) bool {
+		if filter.BoosterID != "" && op.BoosterID != filter.BoosterID {
+			return false
+		}
+		if filter.Since != nil && op.AppliedAt.Before(*filter.Since) {
+			return false
+		}
+		if filter.Until != nil && op.AppliedAt.After(*filter.Until) {
+			return false
+		}
+		return true
+	}), nil
+}
+
+// CloseUnfinished encerra as operações que ficaram enfileiradas ou em execução,
+// retornando quantas foram alteradas
+func (r *OperationsRepo) CloseUnfinished(ctx context.Context, status entities.OperationStatus, errMsg string, completedAt time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var closed int64
+	for i := range r.ops {
+		op := &r.ops[i]
+		if op.Status != entities.OperationQueued && op.Status != entities.OperationProcessing {
+			continue
+		}
+		at := completedAt
+		op.Status = status
+		op.ErrorMsg = errMsg
+		op.CompletedAt = &at
+		closed++
+	}
+	return closed, nil
+}
+
+func (r *OperationsRepo) GetByBoosterID(ctx context.Context, boosterID string) (*[]entities.BoostOperation, error) {
+	result := r.filter(func(op *entities.BoostOperation) bool { return op.BoosterID == boosterID })
+	return &result, nil
+}
+
+func (r *OperationsRepo) GetAll(ctx context.Context) (*[]entities.BoostOperation, error) {
+	result := r.filter(func(*entities.BoostOperation) bool { return true })
+	return &result, nil
+}
+
+func (r *OperationsRepo) GetByStatus(ctx context.Context, status entities.OperationStatus) (*[]entities.BoostOperation, error) {
+	result := r.filter(func(op *entities.BoostOperation) bool { return op.Status == status })
+	return &result, nil
+}
+
+func (r *OperationsRepo) GetByType(ctx context.Context, operationType entities.BoosterOperationType) (*[]entities.BoostOperation, error) {
+	result := r.filter(func(op *entities.BoostOperation) bool { return op.Type == operationType })
+	return &result, nil
+}
+
+func (r *OperationsRepo) GetByTimeRange(ctx context.Context, startTime, endTime time.Time) (*[]entities.BoostOperation, error) {
+	result := r.filter(func(op *entities.BoostOperation) bool {
+		return !op.AppliedAt.Before(startTime) && !op.AppliedAt.After(endTime)
+	})
+	return &result, nil
+}
+
+func (r *OperationsRepo) GetRecent(ctx context.Context, limit int) (*[]entities.BoostOperation, error) {
+	if limit <= 0 {
+		return &[]entities.BoostOperation{}, nil
+	}
+	result := r.filter(func(*entities.BoostOperation) bool { return true })
+	sort.SliceStable(result, func(i, j int) bool { return result[i].AppliedAt.After(result[j].AppliedAt) })
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return &result, nil
+}
+
+func (r *OperationsRepo) DeleteOlderThan(ctx context.Context, olderThan time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	kept := r.ops[:0]
+	r.index = make(map[string]int, len(r.ops))
+	for _, op := range r.ops {
+		if op.AppliedAt.Before(olderThan) {
+			continue
+		}
+		r.index[op.ID] = len(kept)
+		kept = append(kept, op)
+	}
+	r.ops = kept
+	return nil
+}
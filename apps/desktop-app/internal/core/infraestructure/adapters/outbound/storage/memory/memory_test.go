@@ -0,0 +1,178 @@
+package memory_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/application/ports/outbound"
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/memory"
+)
+
+func TestRollbackRepo_ReturnsCopies(t *testing.T) {
+	var repo outbound.BoosterStateRepository = memory.NewRollbackRepo()
+	ctx := context.Background()
+
+	state := &entities.BoosterRollbackState{
+		ID: "dns_flush", Applied: true, BackupData: entities.BackupData{"resolv_conf": "nameserver 1.1.1.1", "ttl": 30},
+	}
+	require.NoError(t, repo.Save(ctx, state))
+	state.BackupData["resolv_conf"] = "changed after save"
+
+	got, err := repo.GetByID(ctx, "dns_flush")
+	require.NoError(t, err)
+	assert.Equal(t, "nameserver 1.1.1.1", got.BackupData["resolv_conf"])
+	assert.Equal(t, float64(30), got.BackupData["ttl"], "backup data must read back as it does from the database")
+
+	got.BackupData["resolv_conf"] = "changed after read"
+	again, err := repo.GetByID(ctx, "dns_flush")
+	require.NoError(t, err)
+	assert.Equal(t, "nameserver 1.1.1.1", again.BackupData["resolv_conf"])
+
+	missing, err := repo.GetByID(ctx, "unknown")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	require.NoError(t, repo.Delete(ctx, "dns_flush"))
+	assert.Error(t, repo.Delete(ctx, "dns_flush"))
+	all, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func TestActivationRepo_SyncAndSetAppliedState(t *testing.T) {
+	var repo outbound.BoosterActivationRepository = memory.NewActivationRepo()
+	ctx := context.Background()
+
+	require.NoError(t, repo.SyncWithAvailableBoosts(ctx, []entities.Booster{
+		{ID: "kept", Version: "1.0.0"}, {ID: "removed", Version: "1.0.0"},
+	}))
+	state, err := repo.GetBoostState(ctx, "kept")
+	require.NoError(t, err)
+	assert.False(t, state.IsApplied)
+	assert.Equal(t, entities.StatusInactive, state.Status)
+
+	require.NoError(t, repo.SetAppliedState(ctx, "kept", true, ""))
+	require.NoError(t, repo.SetAppliedState(ctx, "removed", true, ""))
+	state, err = repo.GetBoostState(ctx, "kept")
+	require.NoError(t, err)
+	assert.True(t, state.IsApplied)
+	assert.Equal(t, entities.StatusActive, state.Status)
+	assert.NotNil(t, state.AppliedAt)
+
+	require.NoError(t, repo.SyncWithAvailableBoosts(ctx, []entities.Booster{{ID: "kept", Version: "2.0.0"}}))
+	state, err = repo.GetBoostState(ctx, "kept")
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", state.Version)
+	assert.True(t, state.IsApplied)
+
+	removed, err := repo.GetBoostState(ctx, "removed")
+	require.NoError(t, err)
+	assert.False(t, removed.IsApplied)
+	assert.Equal(t, entities.StatusObsolete, removed.Status)
+	assert.NotNil(t, removed.RevertedAt)
+
+	_, err = repo.GetBoostState(ctx, "unknown")
+	assert.ErrorIs(t, err, outbound.ErrActivationStateNotFound)
+	assert.ErrorIs(t, repo.SetAppliedState(ctx, "unknown", true, ""), outbound.ErrActivationStateNotFound)
+}
+
+func TestOperationsRepo_QueriesAndCloseUnfinished(t *testing.T) {
+	var repo outbound.OperationHistoryRepository = memory.NewOperationsRepo()
+	ctx := context.Background()
+	base := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	ops := []entities.BoostOperation{
+		{ID: "op-1", BoosterID: "a", Type: entities.ApplyOperationType, BatchID: "b1", Status: entities.OperationCompleted,
+			AppliedAt: base, QueuedAt: base.Add(2 * time.Second)},
+		{ID: "op-2", BoosterID: "b", Type: entities.ApplyOperationType, BatchID: "b1", Status: entities.OperationProcessing,
+			AppliedAt: base.Add(time.Hour), QueuedAt: base.Add(time.Second)},
+		{ID: "op-3", BoosterID: "a", Type: entities.RevertOperationType, Status: entities.OperationQueued,
+			AppliedAt: base.Add(2 * time.Hour), QueuedAt: base.Add(2 * time.Hour)},
+	}
+	for i := range ops {
+		require.NoError(t, repo.Save(ctx, &ops[i]))
+	}
+
+	batch, err := repo.GetByBatchID(ctx, "b1")
+	require.NoError(t, err)
+	require.Len(t, batch, 2)
+	assert.Equal(t, "op-2", batch[0].ID, "batch operations are ordered by queue time")
+
+	since := base.Add(30 * time.Minute)
+	filtered, err := repo.Query(ctx, entities.OperationStatsFilter{BoosterID: "a", Since: &since})
+	require.NoError(t, err)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "op-3", filtered[0].ID)
+
+	recent, err := repo.GetRecent(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, *recent, 2)
+	assert.Equal(t, "op-3", (*recent)[0].ID)
+
+	ranged, err := repo.GetByTimeRange(ctx, base, base.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, *ranged, 2)
+
+	closed, err := repo.CloseUnfinished(ctx, entities.OperationFailed, "interrupted", base.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), closed)
+	op, err := repo.GetByID(ctx, "op-3")
+	require.NoError(t, err)
+	assert.Equal(t, entities.OperationFailed, op.Status)
+	assert.Equal(t, "interrupted", op.ErrorMsg)
+	require.NotNil(t, op.CompletedAt)
+
+	require.NoError(t, repo.DeleteOlderThan(ctx, base.Add(time.Minute)))
+	all, err := repo.GetAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, *all, 2)
+	assert.Equal(t, "op-2", (*all)[0].ID)
+	missing, err := repo.GetByID(ctx, "op-1")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestRepos_ConcurrentAccess(t *testing.T) {
+	rollback := memory.NewRollbackRepo()
+	activation := memory.NewActivationRepo()
+	operations := memory.NewOperationsRepo()
+	ctx := context.Background()
+
+	boosts := make([]entities.Booster, 10)
+	for i := range boosts {
+		boosts[i] = entities.Booster{ID: fmt.Sprintf("boost-%d", i), Version: "1.0.0"}
+	}
+	require.NoError(t, activation.SyncWithAvailableBoosts(ctx, boosts))
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				id := boosts[(w+i)%len(boosts)].ID
+				assert.NoError(t, rollback.Save(ctx, &entities.BoosterRollbackState{ID: id, BackupData: entities.BackupData{"i": i}}))
+				_, _ = rollback.GetAll(ctx)
+				assert.NoError(t, activation.SetAppliedState(ctx, id, i%2 == 0, ""))
+				_, _ = activation.GetBoostState(ctx, id)
+				assert.NoError(t, operations.Save(ctx, &entities.BoostOperation{ID: fmt.Sprintf("op-%d-%d", w, i), BoosterID: id}))
+				_, _ = operations.GetByBoosterID(ctx, id)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	all, err := operations.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, *all, 8*50)
+	states, err := rollback.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, states, len(boosts))
+}
@@ -0,0 +1,101 @@
+// Package memory implementa os repositórios do booster em memória, para rodar o
+// domínio sem banco. Os valores são copiados na entrada e na saída, então quem
+// chama nunca altera o estado guardado sem passar pelo repositório.
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+)
+
+type RollbackRepo struct {
+	mu     sync.RWMutex
+	states map[string]entities.BoosterRollbackState
+}
+
+func NewRollbackRepo(states ...entities.BoosterRollbackState) *RollbackRepo {
+	r := &RollbackRepo{states: make(map[string]entities.BoosterRollbackState, len(states))}
+	for _, s := range states {
+		r.states[s.ID] = s
+	}
+	return r
+}
+
+func (r *RollbackRepo) Save(ctx context.Context, s *entities.BoosterRollbackState) error {
+	if s == nil {
+		return errors.New("nil rollback state")
+	}
+	state := *s
+	backup, err := cloneBackup(s.BackupData)
+	if err != nil {
+		return fmt.Errorf("invalid backup data for %s: %w", s.ID, err)
+	}
+	state.BackupData = backup
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states[state.ID] = state
+	return nil
+}
+
+func (r *RollbackRepo) GetByID(ctx context.Context, id string) (*entities.BoosterRollbackState, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	state, ok := r.states[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := copyRollback(state)
+	return &copied, nil
+}
+
+// GetAll retorna os estados ordenados por ID
+func (r *RollbackRepo) GetAll(ctx context.Context) ([]entities.BoosterRollbackState, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	result := make([]entities.BoosterRollbackState, 0, len(r.states))
+	for _, state := range r.states {
+		result = append(result, copyRollback(state))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
+}
+
+func (r *RollbackRepo) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.states[id]; !ok {
+		return fmt.Errorf("nenhuma linha afetada, id não encontrado: %s", id)
+	}
+	delete(r.states, id)
+	return nil
+}
+
+func copyRollback(s entities.BoosterRollbackState) entities.BoosterRollbackState {
+	// o backup guardado já passou por cloneBackup, então a cópia não falha
+	s.BackupData, _ = cloneBackup(s.BackupData)
+	return s
+}
+
+// cloneBackup copia o backup passando por JSON, como no banco: o valor lido tem
+// os mesmos tipos (números viram float64) em qualquer backend
+func cloneBackup(data entities.BackupData) (entities.BackupData, error) {
+	if data == nil {
+		return entities.BackupData{}, nil
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var cloned entities.BackupData
+	if err := json.Unmarshal(raw, &cloned); err != nil {
+		return nil, err
+	}
+	return cloned, nil
+}
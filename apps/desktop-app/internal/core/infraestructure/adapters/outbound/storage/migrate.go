@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wailsapp/wails/lib/logger"
+	"gorm.io/gorm"
+)
+
+var ErrDatabaseTooNew = errors.New("database was created by a newer version of the app")
+
+// Migration é uma mudança numerada do schema. Up e Down rodam numa transação
+// junto com o registro em schema_migrations, e não devem usar os models atuais:
+// cada migração descreve as tabelas como eram na sua versão. O que vem de fora
+// do banco, como a chave dos backups, chega pelo contexto do db.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+type schemaMigration struct {
+	Version   int       `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"type:text;not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string { return "schema_migrations" }
+
+var dbLogger = logger.NewCustomLogger("[Storage]")
+
+// LatestVersion é a versão do schema esperada por este build
+func LatestVersion() int {
+	return len(migrations)
+}
+
+// Migrate leva o banco até a versão mais recente
+func Migrate(db *gorm.DB, dbPath string) error {
+	return MigrateTo(db, dbPath, LatestVersion())
+}
+
+// MigrateTo aplica ou desfaz migrações até chegar em target. Antes de mudar um
+// banco com dados, grava uma cópia em BackupPath(dbPath, versão atual). Um banco
+// com versão maior que a deste build é recusado com ErrDatabaseTooNew.
+func MigrateTo(db *gorm.DB, dbPath string, target int) error {
+	if db == nil {
+		return fmt.Errorf("db is nil")
+	}
+	if target < 0 || target > LatestVersion() {
+		return fmt.Errorf("invalid schema version %d (latest is %d)", target, LatestVersion())
+	}
+	if err := db.Migrator().AutoMigrate(&schemaMigration{}); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	current, err := SchemaVersion(db)
+	if err != nil {
+		return err
+	}
+	if current > LatestVersion() {
+		return fmt.Errorf("%w: schema version %d, this build supports up to %d", ErrDatabaseTooNew, current, LatestVersion())
+	}
+	if current == target {
+		return nil
+	}
+
+	if err := backupBeforeMigration(db, dbPath, current); err != nil {
+		return err
+	}
+
+	if target > current {
+		for _, m := range migrations[current:target] {
+			if err := applyMigration(db, m); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for i := current - 1; i >= target; i-- {
+		if err := revertMigration(db, migrations[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SchemaVersion retorna a maior versão aplicada; 0 para um banco sem migrações
+func SchemaVersion(db *gorm.DB) (int, error) {
+	var version int
+	if err := db.Model(&schemaMigration{}).Select("COALESCE(MAX(version), 0)").Scan(&version).Error; err != nil {
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// BackupPath é onde fica a cópia do banco feita antes de migrar a partir de version
+func BackupPath(dbPath string, version int) string {
+	return fmt.Sprintf("%s.pre-migration-v%d", dbPath, version)
+}
+
+type preMigrationCopy struct {
+	path    string
+	version int
+}
+
+// preMigrationCopies lista as cópias BackupPath(dbPath, versão) existentes
+func preMigrationCopies(dbPath string) ([]preMigrationCopy, error) {
+	entries, err := os.ReadDir(filepath.Dir(dbPath))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := filepath.Base(dbPath) + ".pre-migration-v"
+	var copies []preMigrationCopy
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		version, err := strconv.Atoi(strings.TrimPrefix(name, prefix))
+		if err != nil {
+			continue
+		}
+		copies = append(copies, preMigrationCopy{path: filepath.Join(filepath.Dir(dbPath), name), version: version})
+	}
+	return copies, nil
+}
+
+func applyMigration(db *gorm.DB, m Migration) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := m.Up(tx); err != nil {
+			return err
+		}
+		return tx.Create(&schemaMigration{Version: m.Version, Name: m.Name, AppliedAt: time.Now()}).Error
+	})
+	if err != nil {
+		return fmt.Errorf("migration %d (%s) failed: %w", m.Version, m.Name, err)
+	}
+	dbLogger.Infof("applied migration %d (%s)", m.Version, m.Name)
+	return nil
+}
+
+func revertMigration(db *gorm.DB, m Migration) error {
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := m.Down(tx); err != nil {
+			return err
+		}
+		return tx.Delete(&schemaMigration{}, "version = ?", m.Version).Error
+	})
+	if err != nil {
+		return fmt.Errorf("rollback of migration %d (%s) failed: %w", m.Version, m.Name, err)
+	}
+	dbLogger.Infof("reverted migration %d (%s)", m.Version, m.Name)
+	return nil
+}
+
+// backupBeforeMigration copia o banco com VACUUM INTO, que gera um arquivo
+// consistente mesmo com a conexão aberta. Bancos sem dados não são copiados.
+func backupBeforeMigration(db *gorm.DB, dbPath string, version int) error {
+	if dbPath == "" {
+		return nil
+	}
+	var tables int64
+	err := db.Raw("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name NOT IN ('schema_migrations') AND name NOT LIKE 'sqlite_%'").
+		Scan(&tables).Error
+	if err != nil {
+		return fmt.Errorf("failed to inspect database: %w", err)
+	}
+	if tables == 0 {
+		return nil
+	}
+
+	backup := BackupPath(dbPath, version)
+	if err := os.Remove(backup); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove old backup %s: %w", backup, err)
+	}
+	if err := db.Exec("VACUUM INTO ?", backup).Error; err != nil {
+		return fmt.Errorf("failed to back up database before migration: %w", err)
+	}
+	dbLogger.Infof("database backed up to %s", backup)
+	return nil
+}
@@ -0,0 +1,229 @@
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	storage "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage"
+	model "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+	repos "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/repositories"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/secrets"
+)
+
+var allModels = []interface{}{
+	&model.BoosterRollbackState{}, &model.BoostOperation{}, &model.BoostActivationState{},
+	&model.OperationJournalEntry{}, &model.SystemSnapshot{}, &model.BoosterExpiry{}, &model.BoosterSettings{},
+}
+
+// memoryKeyStore guarda o keyset do teste em memória
+type memoryKeyStore struct {
+	keys *secrets.Keyset
+}
+
+func (s *memoryKeyStore) Name() string                   { return "memory" }
+func (s *memoryKeyStore) Load() (*secrets.Keyset, error) { return s.keys, nil }
+func (s *memoryKeyStore) Save(ks *secrets.Keyset) error  { s.keys = ks; return nil }
+
+func newTestCipher(t *testing.T) (*secrets.Cipher, *memoryKeyStore) {
+	ks, err := secrets.NewKeyset(time.Now())
+	require.NoError(t, err)
+	store := &memoryKeyStore{keys: ks}
+	c, err := secrets.NewCipher(ks, store)
+	require.NoError(t, err)
+	return c, store
+}
+
+var sharedCipher *secrets.Cipher
+
+// testCipher é o cipher que openTestDB coloca no contexto do banco
+func testCipher(t *testing.T) *secrets.Cipher {
+	if sharedCipher == nil {
+		sharedCipher, _ = newTestCipher(t)
+	}
+	return sharedCipher
+}
+
+// openTestDB abre um banco vazio com testCipher no contexto, como o NewDB faz
+func openTestDB(t *testing.T) (*gorm.DB, string) {
+	db, path := openTestDBWithoutKey(t)
+	return db.WithContext(storage.WithBackupCipher(context.Background(), testCipher(t))), path
+}
+
+func openTestDBWithoutKey(t *testing.T) (*gorm.DB, string) {
+	path := filepath.Join(t.TempDir(), "data_store.db")
+	db, err := gorm.Open(sqlite.Open(path), &gorm.Config{})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+	return db, path
+}
+
+// rawBackup é o backup_data como está gravado
+func rawBackup(t *testing.T, db *gorm.DB, id string) string {
+	var data string
+	require.NoError(t, db.Raw("SELECT backup_data FROM rollback_states WHERE id = ?", id).Scan(&data).Error)
+	return data
+}
+
+// lastLegacyVersion é o último schema criado pelo AutoMigrate; daí em diante os
+// bancos já nascem com schema_migrations e não há fixture
+const lastLegacyVersion = 8
+
+// loadFixture recria um banco criado pelo AutoMigrate de uma versão antiga do app
+func loadFixture(t *testing.T, db *gorm.DB, version int) {
+	script, err := os.ReadFile(filepath.Join("testdata", fmt.Sprintf("legacy_v%d.sql", version)))
+	require.NoError(t, err)
+	require.NoError(t, db.Exec(string(script)).Error)
+}
+
+// schemaOf descreve as colunas e índices de cada tabela, para comparar bancos
+func schemaOf(t *testing.T, db *gorm.DB) map[string][]string {
+	var tables []string
+	require.NoError(t, db.Raw("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT IN ('schema_migrations') AND name NOT LIKE 'sqlite_%'").Scan(&tables).Error)
+
+	schema := make(map[string][]string)
+	for _, table := range tables {
+		var columns []struct {
+			Name    string
+			Type    string
+			NotNull bool    `gorm:"column:notnull"`
+			Dflt    *string `gorm:"column:dflt_value"`
+			PK      int     `gorm:"column:pk"`
+		}
+		require.NoError(t, db.Raw(fmt.Sprintf("PRAGMA table_info(`%s`)", table)).Scan(&columns).Error)
+		var desc []string
+		for _, c := range columns {
+			dflt := ""
+			if c.Dflt != nil {
+				dflt = *c.Dflt
+			}
+			desc = append(desc, fmt.Sprintf("%s %s notnull=%t default=%s pk=%d", c.Name, c.Type, c.NotNull, dflt, c.PK))
+		}
+		var indexes []string
+		require.NoError(t, db.Raw("SELECT name FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL", table).Scan(&indexes).Error)
+		for _, idx := range indexes {
+			desc = append(desc, "index "+idx)
+		}
+		sort.Strings(desc)
+		schema[table] = desc
+	}
+	return schema
+}
+
+func expectedSchema(t *testing.T) map[string][]string {
+	db, _ := openTestDB(t)
+	require.NoError(t, db.AutoMigrate(allModels...))
+	return schemaOf(t, db)
+}
+
+func TestMigrate_AdoptsLegacyDatabasesFromEveryPreviousSchema(t *testing.T) {
+	expected := expectedSchema(t)
+
+	for version := 1; version <= lastLegacyVersion; version++ {
+		t.Run(fmt.Sprintf("legacy_v%d", version), func(t *testing.T) {
+			db, path := openTestDB(t)
+			loadFixture(t, db, version)
+
+			require.NoError(t, storage.Migrate(db, path))
+
+			current, err := storage.SchemaVersion(db)
+			require.NoError(t, err)
+			assert.Equal(t, storage.LatestVersion(), current)
+			assert.Equal(t, expected, schemaOf(t, db))
+
+			assert.NotContains(t, rawBackup(t, db, "dns_flush"), "nameserver", "backups must be encrypted")
+			rollbackRepo := repos.NewRollbackRepo(db)
+			rollbackRepo.SetCipher(testCipher(t))
+			rollback, err := rollbackRepo.GetByID(context.Background(), "dns_flush")
+			require.NoError(t, err)
+			assert.Equal(t, "nameserver 1.1.1.1", rollback.BackupData["resolv_conf"])
+
+			var op model.BoostOperation
+			require.NoError(t, db.First(&op, "id = ?", "op-1").Error)
+			assert.Equal(t, op.AppliedAt, op.QueuedAt, "queued_at must be backfilled from applied_at")
+
+			_, err = os.Stat(storage.BackupPath(path, 0))
+			assert.NoError(t, err, "legacy database must be backed up before migrating")
+		})
+	}
+}
+
+func TestMigrate_UpgradesFromEveryVersionAndRollsBack(t *testing.T) {
+	expected := expectedSchema(t)
+
+	for version := 0; version < storage.LatestVersion(); version++ {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			db, path := openTestDB(t)
+			require.NoError(t, storage.MigrateTo(db, path, version))
+
+			require.NoError(t, storage.Migrate(db, path))
+			assert.Equal(t, expected, schemaOf(t, db))
+
+			require.NoError(t, storage.MigrateTo(db, path, version))
+			current, err := storage.SchemaVersion(db)
+			require.NoError(t, err)
+			assert.Equal(t, version, current)
+
+			require.NoError(t, storage.Migrate(db, path))
+			assert.Equal(t, expected, schemaOf(t, db))
+		})
+	}
+}
+
+func TestMigrate_RollbackKeepsDataOfRemainingVersions(t *testing.T) {
+	db, path := openTestDB(t)
+	loadFixture(t, db, lastLegacyVersion)
+	require.NoError(t, storage.Migrate(db, path))
+
+	require.NoError(t, storage.MigrateTo(db, path, 3))
+
+	assert.False(t, db.Migrator().HasTable("system_snapshots"))
+	assert.False(t, db.Migrator().HasColumn("boosts_operations", "batch_id"))
+	var attempts int
+	require.NoError(t, db.Raw("SELECT attempts FROM boosts_operations WHERE id = ?", "op-1").Scan(&attempts).Error)
+	assert.Equal(t, 1, attempts)
+	assert.Contains(t, rawBackup(t, db, "dns_flush"), "nameserver 1.1.1.1", "backups must be decrypted below v9")
+
+	_, err := os.Stat(storage.BackupPath(path, storage.LatestVersion()))
+	assert.NoError(t, err, "database must be backed up before rolling back")
+}
+
+func TestMigrate_RefusesDatabaseFromNewerVersion(t *testing.T) {
+	db, path := openTestDB(t)
+	require.NoError(t, storage.Migrate(db, path))
+	require.NoError(t, db.Exec("INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, CURRENT_TIMESTAMP)",
+		storage.LatestVersion()+1, "from_the_future").Error)
+
+	err := storage.Migrate(db, path)
+	assert.ErrorIs(t, err, storage.ErrDatabaseTooNew)
+
+	current, err := storage.SchemaVersion(db)
+	require.NoError(t, err)
+	assert.Equal(t, storage.LatestVersion()+1, current)
+}
+
+func TestMigrate_FreshDatabaseIsNotBackedUp(t *testing.T) {
+	db, path := openTestDB(t)
+	require.NoError(t, storage.Migrate(db, path))
+
+	_, err := os.Stat(storage.BackupPath(path, 0))
+	assert.True(t, os.IsNotExist(err))
+
+	// nada pendente: uma segunda execução não muda nem copia o banco
+	require.NoError(t, storage.Migrate(db, path))
+	_, err = os.Stat(storage.BackupPath(path, storage.LatestVersion()))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,312 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// migrations em ordem; a posição i tem Version i+1. As primeiras versões
+// reproduzem o schema criado pelo AutoMigrate das versões antigas do app, por
+// isso criam tabelas e colunas só quando ainda não existem: um banco antigo,
+// sem schema_migrations, é adotado passando por todas elas.
+var migrations = []Migration{
+	{Version: 1, Name: "baseline", Up: migrateBaselineUp, Down: migrateBaselineDown},
+	{Version: 2, Name: "operation_journal", Up: migrateJournalUp, Down: migrateJournalDown},
+	{Version: 3, Name: "operation_attempts", Up: migrateAttemptsUp, Down: migrateAttemptsDown},
+	{Version: 4, Name: "operation_lifecycle", Up: migrateLifecycleUp, Down: migrateLifecycleDown},
+	{Version: 5, Name: "operation_duration", Up: migrateDurationUp, Down: migrateDurationDown},
+	{Version: 6, Name: "system_snapshots", Up: migrateSnapshotsUp, Down: migrateSnapshotsDown},
+	{Version: 7, Name: "booster_expiries", Up: migrateExpiriesUp, Down: migrateExpiriesDown},
+	{Version: 8, Name: "booster_settings", Up: migrateSettingsUp, Down: migrateSettingsDown},
+	{Version: 9, Name: "encrypt_rollback_backups", Up: migrateEncryptBackupsUp, Down: migrateEncryptBackupsDown},
+	{Version: 10, Name: "encrypt_journal_snapshot_backups", Up: migrateEncryptJournalSnapshotsUp, Down: migrateEncryptJournalSnapshotsDown},
+}
+
+// v1: rollback_states, boosts_operations e boost_activation_states
+
+type rollbackStateV1 struct {
+	ID         string         `gorm:"primaryKey;type:text"`
+	Applied    bool           `gorm:"not null;default:false"`
+	AppliedAt  *time.Time     `gorm:"index"`
+	RevertedAt *time.Time     `gorm:"index"`
+	Version    string         `gorm:"type:text;not null"`
+	BackupData datatypes.JSON `gorm:"type:json;not null;default:'{}'"`
+	Status     string         `gorm:"type:text;not null;index"`
+	ErrorMsg   string         `gorm:"type:text"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (rollbackStateV1) TableName() string { return "rollback_states" }
+
+type boostOperationV1 struct {
+	ID         string    `gorm:"primaryKey;type:text"`
+	UserID     string    `gorm:"type:text;index"`
+	BoosterID  string    `gorm:"type:text;index"`
+	Version    string    `gorm:"type:text"`
+	AppliedAt  time.Time `gorm:"not null;index"`
+	RevertedAt time.Time `gorm:"index"`
+	Status     string    `gorm:"type:text;index"`
+	ErrorMsg   string    `gorm:"type:text"`
+	Type       string    `gorm:"type:text"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+func (boostOperationV1) TableName() string { return "boosts_operations" }
+
+type boostActivationStateV1 struct {
+	ID           string     `gorm:"primaryKey;type:text"`
+	IsApplied    bool       `gorm:"not null;default:false"`
+	AppliedAt    *time.Time `gorm:"index"`
+	RevertedAt   *time.Time `gorm:"index"`
+	Version      string     `gorm:"type:text;not null"`
+	Status       string     `gorm:"type:text;not null;index"`
+	ErrorMessage string     `gorm:"type:text"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func (boostActivationStateV1) TableName() string { return "boost_activation_states" }
+
+func migrateBaselineUp(tx *gorm.DB) error {
+	return createTables(tx, &rollbackStateV1{}, &boostOperationV1{}, &boostActivationStateV1{})
+}
+
+func migrateBaselineDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable("boost_activation_states", "boosts_operations", "rollback_states")
+}
+
+// v2: journal write-ahead das operações
+
+type operationJournalEntryV2 struct {
+	OperationID string         `gorm:"primaryKey;type:text"`
+	BoosterID   string         `gorm:"type:text;not null;index"`
+	Operation   string         `gorm:"type:text;not null"`
+	BatchID     string         `gorm:"type:text;index"`
+	DependsOn   string         `gorm:"type:text"`
+	Phase       string         `gorm:"type:text;not null;index"`
+	Success     bool           `gorm:"not null;default:false"`
+	Message     string         `gorm:"type:text"`
+	BackupData  datatypes.JSON `gorm:"type:json;not null;default:'{}'"`
+	Error       string         `gorm:"type:text"`
+	QueuedAt    time.Time      `gorm:"not null;index"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (operationJournalEntryV2) TableName() string { return "operation_journal" }
+
+func migrateJournalUp(tx *gorm.DB) error {
+	return createTables(tx, &operationJournalEntryV2{})
+}
+
+func migrateJournalDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable("operation_journal")
+}
+
+// v3: tentativas do retry
+
+type boostOperationV3 struct {
+	Attempts int `gorm:"not null;default:1"`
+}
+
+func (boostOperationV3) TableName() string { return "boosts_operations" }
+
+func migrateAttemptsUp(tx *gorm.DB) error {
+	return addColumns(tx, &boostOperationV3{}, "Attempts")
+}
+
+func migrateAttemptsDown(tx *gorm.DB) error {
+	return dropColumns(tx, "boosts_operations", "attempts")
+}
+
+// v4: ciclo de vida das operações
+
+type boostOperationV4 struct {
+	BatchID     string    `gorm:"type:text;index"`
+	Message     string    `gorm:"type:text"`
+	QueuedAt    time.Time `gorm:"index"`
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+func (boostOperationV4) TableName() string { return "boosts_operations" }
+
+func migrateLifecycleUp(tx *gorm.DB) error {
+	if err := addColumns(tx, &boostOperationV4{}, "BatchID", "Message", "QueuedAt", "StartedAt", "CompletedAt"); err != nil {
+		return err
+	}
+	// operações gravadas antes do ciclo de vida não têm queued_at; sem ele
+	// ficariam fora de ordem nas consultas por lote
+	return tx.Exec("UPDATE boosts_operations SET queued_at = applied_at WHERE queued_at IS NULL").Error
+}
+
+func migrateLifecycleDown(tx *gorm.DB) error {
+	return dropColumns(tx, "boosts_operations", "batch_id", "message", "queued_at", "started_at", "completed_at")
+}
+
+// v5: duração das operações
+
+type boostOperationV5 struct {
+	DurationMs int64
+}
+
+func (boostOperationV5) TableName() string { return "boosts_operations" }
+
+func migrateDurationUp(tx *gorm.DB) error {
+	return addColumns(tx, &boostOperationV5{}, "DurationMs")
+}
+
+func migrateDurationDown(tx *gorm.DB) error {
+	return dropColumns(tx, "boosts_operations", "duration_ms")
+}
+
+// v6: snapshots nomeados
+
+type systemSnapshotV6 struct {
+	ID        string         `gorm:"primaryKey;type:text"`
+	Name      string         `gorm:"type:text;not null;index"`
+	Origin    string         `gorm:"type:text;not null;index"`
+	BatchID   string         `gorm:"type:text;index"`
+	Boosters  datatypes.JSON `gorm:"type:json;not null;default:'[]'"`
+	CreatedAt time.Time      `gorm:"not null;index"`
+}
+
+func (systemSnapshotV6) TableName() string { return "system_snapshots" }
+
+func migrateSnapshotsUp(tx *gorm.DB) error {
+	return createTables(tx, &systemSnapshotV6{})
+}
+
+func migrateSnapshotsDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable("system_snapshots")
+}
+
+// v7: boosts com prazo
+
+type boosterExpiryV7 struct {
+	BoosterID string     `gorm:"primaryKey;type:text"`
+	ExpiresAt time.Time  `gorm:"not null;index"`
+	WarnedAt  *time.Time `gorm:""`
+	CreatedAt time.Time  `gorm:"not null"`
+	UpdatedAt time.Time
+}
+
+func (boosterExpiryV7) TableName() string { return "booster_expiries" }
+
+func migrateExpiriesUp(tx *gorm.DB) error {
+	return createTables(tx, &boosterExpiryV7{})
+}
+
+func migrateExpiriesDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable("booster_expiries")
+}
+
+// v8: parâmetros dos boosters
+
+type boosterSettingsV8 struct {
+	BoosterID string         `gorm:"primaryKey;type:text"`
+	Params    datatypes.JSON `gorm:"type:json;not null;default:'{}'"`
+	UpdatedAt time.Time
+}
+
+func (boosterSettingsV8) TableName() string { return "booster_settings" }
+
+func migrateSettingsUp(tx *gorm.DB) error {
+	return createTables(tx, &boosterSettingsV8{})
+}
+
+func migrateSettingsDown(tx *gorm.DB) error {
+	return tx.Migrator().DropTable("booster_settings")
+}
+
+// v9: rollback_states.backup_data cifrado. O schema não muda: o backup passa a
+// ser um objeto JSON com o texto cifrado. A chave vem de WithBackupCipher.
+
+func migrateEncryptBackupsUp(tx *gorm.DB) error {
+	c, err := backupCipherFrom(tx)
+	if err != nil {
+		return err
+	}
+	return sealBackups(tx, c, rewriteRollbackBackups)
+}
+
+func migrateEncryptBackupsDown(tx *gorm.DB) error {
+	c, err := backupCipherFrom(tx)
+	if err != nil {
+		return err
+	}
+	return openBackups(tx, c, rewriteRollbackBackups)
+}
+
+// v10: os backups guardados em operation_journal.backup_data e em
+// system_snapshots.boosters[].backupData também passam a ser cifrados
+
+func migrateEncryptJournalSnapshotsUp(tx *gorm.DB) error {
+	c, err := backupCipherFrom(tx)
+	if err != nil {
+		return err
+	}
+	return sealBackups(tx, c, rewriteJournalBackups, rewriteSnapshotBackups)
+}
+
+func migrateEncryptJournalSnapshotsDown(tx *gorm.DB) error {
+	c, err := backupCipherFrom(tx)
+	if err != nil {
+		return err
+	}
+	return openBackups(tx, c, rewriteJournalBackups, rewriteSnapshotBackups)
+}
+
+func createTables(tx *gorm.DB, tables ...interface{}) error {
+	for _, table := range tables {
+		if tx.Migrator().HasTable(table) {
+			continue
+		}
+		if err := tx.Migrator().CreateTable(table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addColumns adiciona as colunas e os índices declarados em model que ainda não existem
+func addColumns(tx *gorm.DB, model interface{}, fields ...string) error {
+	stmt := &gorm.Statement{DB: tx}
+	if err := stmt.Parse(model); err != nil {
+		return err
+	}
+	for _, name := range fields {
+		if !tx.Migrator().HasColumn(model, name) {
+			if err := tx.Migrator().AddColumn(model, name); err != nil {
+				return err
+			}
+		}
+	}
+	for _, idx := range stmt.Schema.ParseIndexes() {
+		if !tx.Migrator().HasIndex(model, idx.Name) {
+			if err := tx.Migrator().CreateIndex(model, idx.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// dropColumns remove colunas com ALTER TABLE DROP COLUMN; o SQLite não remove
+// colunas indexadas, então os índices criados pelo gorm saem antes
+func dropColumns(tx *gorm.DB, table string, columns ...string) error {
+	for _, column := range columns {
+		if err := tx.Exec(fmt.Sprintf("DROP INDEX IF EXISTS `idx_%s_%s`", table, column)).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(fmt.Sprintf("ALTER TABLE `%s` DROP COLUMN `%s`", table, column)).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -7,17 +7,24 @@ import (
 )
 
 type BoostOperation struct {
-	ID         string                        `gorm:"primaryKey;type:text"`
-	UserID     string                        `gorm:"type:text;index"`
-	BoosterID  string                        `gorm:"type:text;index"`
-	Version    string                        `gorm:"type:text"`
-	AppliedAt  time.Time                     `gorm:"not null;index"`
-	RevertedAt time.Time                    `gorm:"index"`
-	Status     entities.BoosterExecutionStatus      `gorm:"type:text;index"`
-	ErrorMsg   string                        `gorm:"type:text"`
-	Type       entities.BoosterOperationType `gorm:"type:text"`
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID          string                        `gorm:"primaryKey;type:text"`
+	UserID      string                        `gorm:"type:text;index"`
+	BoosterID   string                        `gorm:"type:text;index"`
+	Version     string                        `gorm:"type:text"`
+	AppliedAt   time.Time                     `gorm:"not null;index"`
+	RevertedAt  time.Time                     `gorm:"index"`
+	Status      entities.OperationStatus      `gorm:"type:text;index"`
+	ErrorMsg    string                        `gorm:"type:text"`
+	Type        entities.BoosterOperationType `gorm:"type:text"`
+	Attempts    int                           `gorm:"not null;default:1"`
+	BatchID     string                        `gorm:"type:text;index"`
+	Message     string                        `gorm:"type:text"`
+	QueuedAt    time.Time                     `gorm:"index"`
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+	DurationMs  int64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 func (BoostOperation) TableName() string { return "boosts_operations" }
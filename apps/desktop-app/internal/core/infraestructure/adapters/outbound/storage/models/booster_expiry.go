@@ -0,0 +1,13 @@
+package storage
+
+import "time"
+
+type BoosterExpiry struct {
+	BoosterID string     `gorm:"primaryKey;type:text"`
+	ExpiresAt time.Time  `gorm:"not null;index"`
+	WarnedAt  *time.Time `gorm:""`
+	CreatedAt time.Time  `gorm:"not null"`
+	UpdatedAt time.Time
+}
+
+func (BoosterExpiry) TableName() string { return "booster_expiries" }
@@ -0,0 +1,15 @@
+package storage
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+type BoosterSettings struct {
+	BoosterID string            `gorm:"primaryKey;type:text"`
+	Params    datatypes.JSONMap `gorm:"type:json;not null;default:'{}'"`
+	UpdatedAt time.Time
+}
+
+func (BoosterSettings) TableName() string { return "booster_settings" }
@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"gorm.io/datatypes"
+)
+
+type OperationJournalEntry struct {
+	OperationID string                        `gorm:"primaryKey;type:text"`
+	BoosterID   string                        `gorm:"type:text;not null;index"`
+	Operation   entities.BoosterOperationType `gorm:"type:text;not null"`
+	BatchID     string                        `gorm:"type:text;index"`
+	DependsOn   string                        `gorm:"type:text"`
+	Phase       entities.JournalPhase         `gorm:"type:text;not null;index"`
+	Success     bool                          `gorm:"not null;default:false"`
+	Message     string                        `gorm:"type:text"`
+	BackupData  datatypes.JSONMap             `gorm:"type:json;not null;default:'{}'"`
+	Error       string                        `gorm:"type:text"`
+	QueuedAt    time.Time                     `gorm:"not null;index"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (OperationJournalEntry) TableName() string { return "operation_journal" }
+
+// JournalBackupAAD é o dado associado usado ao cifrar o backup da operação
+func JournalBackupAAD(operationID string) string { return "operation_journal/" + operationID }
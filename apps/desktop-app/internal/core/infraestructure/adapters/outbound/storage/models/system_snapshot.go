@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"gorm.io/datatypes"
+)
+
+type SystemSnapshot struct {
+	ID        string                                        `gorm:"primaryKey;type:text"`
+	Name      string                                        `gorm:"type:text;not null;index"`
+	Origin    entities.SnapshotOrigin                       `gorm:"type:text;not null;index"`
+	BatchID   string                                        `gorm:"type:text;index"`
+	Boosters  datatypes.JSONSlice[entities.SnapshotBooster] `gorm:"type:json;not null;default:'[]'"`
+	CreatedAt time.Time                                     `gorm:"not null;index"`
+}
+
+func (SystemSnapshot) TableName() string { return "system_snapshots" }
+
+// SnapshotBackupAAD é o dado associado usado ao cifrar o backup de um booster do snapshot
+func SnapshotBackupAAD(snapshotID, boosterID string) string {
+	return "system_snapshots/" + snapshotID + "/" + boosterID
+}
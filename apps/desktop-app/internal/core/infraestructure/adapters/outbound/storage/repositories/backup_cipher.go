@@ -0,0 +1,35 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/secrets"
+)
+
+// sealBackup cifra um backup com c; sem cipher o backup fica em texto puro
+func sealBackup(c *secrets.Cipher, data map[string]interface{}, aad string) (map[string]interface{}, error) {
+	if c == nil {
+		return data, nil
+	}
+	sealed, err := c.SealMap(data, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt backup of %s: %w", aad, err)
+	}
+	return sealed, nil
+}
+
+// openBackup decifra um backup gerado por sealBackup; um backup em texto puro
+// é retornado como está
+func openBackup(c *secrets.Cipher, data map[string]interface{}, aad string) (map[string]interface{}, error) {
+	if _, sealed := secrets.SealedValue(data); !sealed {
+		return data, nil
+	}
+	if c == nil {
+		return nil, fmt.Errorf("backup of %s is encrypted but no key is configured", aad)
+	}
+	opened, err := c.OpenMap(data, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt backup of %s: %w", aad, err)
+	}
+	return opened, nil
+}
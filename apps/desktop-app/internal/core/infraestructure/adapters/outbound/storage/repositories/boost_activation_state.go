@@ -2,35 +2,65 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
+	"github.com/oLenador/mulltbost/internal/core/application/ports/outbound"
 	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	mapper "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/mapper"
 	storage "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
 	"gorm.io/gorm"
 )
 
+// BoostConfigRepository guarda o estado de ativação dos boosts com um cache
+// write-through: toda alteração é gravada no banco e só então no cache, que
+// guarda valores (nunca ponteiros compartilhados com quem chama). O cache não
+// expira por tempo; ele acompanha as transições feitas pelo próprio repositório
+// e uma entrada cuja gravação falhou é descartada e marcada em stale, para ser
+// relida do banco na próxima consulta.
 type BoostConfigRepository struct {
-	db             *gorm.DB
-	cache          map[string]*storage.BoostActivationState
-	lastSync       time.Time
+	db *gorm.DB
+
+	// writeMu serializa as alterações para que a verificação e a gravação de
+	// ActivateBoost, DeactivateBoost e SetAppliedState sejam atômicas
+	writeMu sync.Mutex
+	// mu protege cache, stale e currentVersion; nunca é mantido durante acesso ao banco
+	mu             sync.RWMutex
+	cache          map[string]storage.BoostActivationState
+	stale          map[string]bool
 	currentVersion string
 }
 
 const OBSOLETE_OLDER_THAN_DAYS int = 7
 
-func NewBoostConfigRepository(db *gorm.DB) *BoostConfigRepository {
+// NewBoostConfigRepository cria o repositório e, se boosts não estiver vazio,
+// sincroniza os estados com eles. Sem boosts nada é sincronizado, pois isso
+// marcaria todos os estados gravados como obsoletos.
+func NewBoostConfigRepository(db *gorm.DB, boosts []entities.Booster) *BoostConfigRepository {
 	repo := &BoostConfigRepository{
 		db:             db,
-		cache:          make(map[string]*storage.BoostActivationState),
+		cache:          make(map[string]storage.BoostActivationState),
+		stale:          make(map[string]bool),
 		currentVersion: "1.0.0",
 	}
 
+	if len(boosts) > 0 {
+		if err := repo.SyncWithAvailableBoosts(context.Background(), boosts); err != nil {
+			log.Printf("Erro ao sincronizar estados dos boosts: %v", err)
+		}
+	}
 	return repo
 }
 
-func (r *BoostConfigRepository) SyncWithAvailableBoosts(boosts []entities.Booster) error {
+func (r *BoostConfigRepository) SyncWithAvailableBoosts(ctx context.Context, boosts []entities.Booster) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	db := r.db.WithContext(ctx)
+
 	// Criar mapa de boosts disponíveis
 	availableBoosts := make(map[string]entities.Booster)
 	for _, boost := range boosts {
@@ -39,28 +69,31 @@ func (r *BoostConfigRepository) SyncWithAvailableBoosts(boosts []entities.Booste
 
 	// 1. Buscar estados existentes
 	var existingStates []storage.BoostActivationState
-	if err := r.db.Find(&existingStates).Error; err != nil {
+	if err := db.Find(&existingStates).Error; err != nil {
 		return fmt.Errorf("erro ao buscar estados existentes: %w", err)
 	}
-	existingMap := make(map[string]*storage.BoostActivationState)
-	for i := range existingStates {
-		// usar ponteiro do slice
-		existingMap[existingStates[i].ID] = &existingStates[i]
+	existingMap := make(map[string]storage.BoostActivationState)
+	for _, state := range existingStates {
+		existingMap[state.ID] = state
 	}
 
 	// 2. Processar boosts disponíveis
 	for boostKey, boost := range availableBoosts {
-		if existing := existingMap[boostKey]; existing != nil {
+		if existing, ok := existingMap[boostKey]; ok {
 			// Atualizar versão se necessário (adaptação: use a versão do boost)
 			if existing.Version != boost.Version {
 				existing.Version = boost.Version
 				existing.UpdatedAt = time.Now()
-				_ = r.db.Save(existing)
+				if err := db.Save(&existing).Error; err != nil {
+					log.Printf("Erro ao atualizar versão do boost %s: %v", boostKey, err)
+					r.discard(boostKey)
+					continue
+				}
 			}
-			r.cache[boostKey] = existing
+			r.store(existing)
 		} else {
 			// Boost novo - criar estado
-			newState := &storage.BoostActivationState{
+			newState := storage.BoostActivationState{
 				ID:        boostKey,
 				IsApplied: false,
 				Version:   boost.Version,
@@ -68,68 +101,101 @@ func (r *BoostConfigRepository) SyncWithAvailableBoosts(boosts []entities.Booste
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
 			}
-			if err := r.db.Create(newState).Error; err != nil {
+			if err := db.Create(&newState).Error; err != nil {
 				log.Printf("Erro ao criar estado para boost %s: %v", boostKey, err)
 				continue
 			}
-			r.cache[boostKey] = newState
+			r.store(newState)
 		}
 	}
 
 	// 3. Marcar boosts órfãos como obsoletos em vez de deletar
 	for dbBoostKey, existing := range existingMap {
-		if _, stillExists := availableBoosts[dbBoostKey]; !stillExists {
-			// Boost removido do código - marcar como obsoleto e desativar
-			if existing.IsApplied {
-				log.Printf("Boost %s foi removido do código mas ainda está aplicado. Revertendo/Desativando...", dbBoostKey)
-				existing.IsApplied = false
-				existing.RevertedAt = timePtr(time.Now())
-				existing.Status = entities.StatusObsolete
-				existing.ErrorMessage = "Boost removido da versão atual"
-				existing.UpdatedAt = time.Now()
-				_ = r.db.Save(existing)
-			} else {
-				// Se já estava inativo, apenas marcar obsoleto e salvar
-				existing.Status = entities.StatusObsolete
-				existing.UpdatedAt = time.Now()
-				_ = r.db.Save(existing)
-			}
-			// NÃO adicionar ao cache (fica invisível para a aplicação)
+		if _, stillExists := availableBoosts[dbBoostKey]; stillExists {
+			continue
+		}
+		// Boost removido do código - marcar como obsoleto e desativar
+		if existing.IsApplied {
+			log.Printf("Boost %s foi removido do código mas ainda está aplicado. Revertendo/Desativando...", dbBoostKey)
+			existing.IsApplied = false
+			existing.RevertedAt = timePtr(time.Now())
+			existing.ErrorMessage = "Boost removido da versão atual"
 		}
+		existing.Status = entities.StatusObsolete
+		existing.UpdatedAt = time.Now()
+		if err := db.Save(&existing).Error; err != nil {
+			log.Printf("Erro ao marcar boost %s como obsoleto: %v", dbBoostKey, err)
+		}
+		// Sai do cache (fica invisível para a aplicação)
+		r.evict(dbBoostKey)
 	}
 
-	r.lastSync = time.Now()
 	return nil
 }
 
-func (r *BoostConfigRepository) GetAllActiveBoosts(ctx context.Context, boosts []entities.Booster) (map[string]*storage.BoostActivationState, error) {
-	// Recarregar cache se necessário (a cada 1 hora)
-	if time.Since(r.lastSync) > time.Hour {
-		if err := r.SyncWithAvailableBoosts(boosts); err != nil {
-			return nil, err
-		}
+// GetAllActiveBoosts retorna cópias dos estados aplicados. As entradas
+// descartadas por falha de gravação são relidas do banco antes.
+func (r *BoostConfigRepository) GetAllActiveBoosts(ctx context.Context) (map[string]*entities.BoosterActivationState, error) {
+	if err := r.reloadStale(ctx); err != nil {
+		return nil, fmt.Errorf("erro ao recarregar estados dos boosts: %w", err)
 	}
 
-	result := make(map[string]*storage.BoostActivationState)
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string]*entities.BoosterActivationState)
 	for key, state := range r.cache {
-		if state != nil && state.IsApplied {
-			result[key] = state
+		if state.IsApplied {
+			result[key] = mapper.MapActivationToDomain(&state)
 		}
 	}
 	return result, nil
 }
 
 func (r *BoostConfigRepository) IsBoostActive(ctx context.Context, boostKey string) bool {
-	if state, exists := r.cache[boostKey]; exists {
-		return state.IsApplied
+	r.mu.RLock()
+	state, exists := r.cache[boostKey]
+	stale := r.stale[boostKey]
+	r.mu.RUnlock()
+
+	if stale {
+		state, err := r.loadState(ctx, boostKey)
+		return err == nil && state.IsApplied
 	}
-	return false
+	return exists && state.IsApplied
+}
+
+// reloadStale relê do banco as entradas descartadas por falha de gravação.
+// As que não existem mais no banco deixam de ser relidas.
+func (r *BoostConfigRepository) reloadStale(ctx context.Context) error {
+	r.mu.RLock()
+	ids := make([]string, 0, len(r.stale))
+	for id := range r.stale {
+		ids = append(ids, id)
+	}
+	r.mu.RUnlock()
+
+	for _, id := range ids {
+		if _, err := r.loadState(ctx, id); err != nil {
+			if !errors.Is(err, outbound.ErrActivationStateNotFound) {
+				return err
+			}
+			r.evict(id)
+		}
+	}
+	return nil
 }
 
 func (r *BoostConfigRepository) ActivateBoost(ctx context.Context, boostKey string) error {
-	state, exists := r.cache[boostKey]
-	if !exists {
-		return fmt.Errorf("boost %s não encontrado", boostKey)
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	state, err := r.loadState(ctx, boostKey)
+	if err != nil {
+		return err
+	}
+	if state.Status == entities.StatusObsolete {
+		return fmt.Errorf("boost %s não encontrado: %w", boostKey, outbound.ErrActivationStateNotFound)
 	}
 	if state.IsApplied {
 		return fmt.Errorf("boost %s já está ativo", boostKey)
@@ -148,17 +214,19 @@ func (r *BoostConfigRepository) ActivateBoost(ctx context.Context, boostKey stri
 	state.ErrorMessage = ""
 	state.UpdatedAt = now
 
-	if err := r.db.Save(state).Error; err != nil {
+	if err := r.save(ctx, state); err != nil {
 		return fmt.Errorf("erro ao ativar boost: %w", err)
 	}
-
 	return nil
 }
 
 func (r *BoostConfigRepository) DeactivateBoost(ctx context.Context, boostKey string) error {
-	state, exists := r.cache[boostKey]
-	if !exists {
-		return fmt.Errorf("boost %s não encontrado", boostKey)
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
+	state, err := r.loadState(ctx, boostKey)
+	if err != nil {
+		return err
 	}
 	if !state.IsApplied {
 		return fmt.Errorf("boost %s já está inativo", boostKey)
@@ -170,17 +238,49 @@ func (r *BoostConfigRepository) DeactivateBoost(ctx context.Context, boostKey st
 	state.Status = entities.StatusInactive
 	state.UpdatedAt = now
 
-	if err := r.db.Save(state).Error; err != nil {
+	if err := r.save(ctx, state); err != nil {
 		return fmt.Errorf("erro ao desativar boost: %w", err)
 	}
+	return nil
+}
+
+// SetAppliedState grava o estado de ativação sem as verificações de ActivateBoost.
+// É chamado pelo processor a cada aplicação ou reversão concluída e pela
+// reconciliação para reparar divergências com o estado de rollback.
+func (r *BoostConfigRepository) SetAppliedState(ctx context.Context, boostKey string, applied bool, errMsg string) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
 
+	state, err := r.loadState(ctx, boostKey)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	state.IsApplied = applied
+	state.ErrorMessage = errMsg
+	state.UpdatedAt = now
+	if applied {
+		if state.AppliedAt == nil {
+			state.AppliedAt = &now
+		}
+		state.RevertedAt = nil
+		state.Status = entities.StatusActive
+	} else {
+		state.RevertedAt = &now
+		state.Status = entities.StatusInactive
+	}
+
+	if err := r.save(ctx, state); err != nil {
+		return fmt.Errorf("erro ao atualizar estado do boost: %w", err)
+	}
 	return nil
 }
 
 func (r *BoostConfigRepository) canApplyBoost(ctx context.Context, boostKey string) (bool, error) {
 	// Verificar no BoosterRollbackState se já foi aplicado
 	var rollbackState storage.BoosterRollbackState
-	err := r.db.Where("id = ?", boostKey).First(&rollbackState).Error
+	err := r.db.WithContext(ctx).Where("id = ?", boostKey).First(&rollbackState).Error
 	if err == gorm.ErrRecordNotFound {
 		return true, nil // Nunca foi aplicado, pode aplicar
 	}
@@ -195,11 +295,28 @@ func (r *BoostConfigRepository) canApplyBoost(ctx context.Context, boostKey stri
 }
 
 func (r *BoostConfigRepository) CleanupObsoleteStates(ctx context.Context) error {
+	r.writeMu.Lock()
+	defer r.writeMu.Unlock()
+
 	cutoff := time.Now().AddDate(0, 0, -OBSOLETE_OLDER_THAN_DAYS)
-	result := r.db.Where("status = ? AND is_applied = ? AND updated_at < ?", entities.StatusObsolete, false, cutoff).Delete(&storage.BoostActivationState{})
+	var ids []string
+	err := r.db.WithContext(ctx).Model(&storage.BoostActivationState{}).
+		Where("status = ? AND is_applied = ? AND updated_at < ?", entities.StatusObsolete, false, cutoff).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	result := r.db.WithContext(ctx).Where("id IN ?", ids).Delete(&storage.BoostActivationState{})
 	if result.Error != nil {
 		return result.Error
 	}
+	for _, id := range ids {
+		r.evict(id)
+	}
 	log.Printf("Removidos %d estados obsoletos de boosts", result.RowsAffected)
 	return nil
 }
@@ -209,22 +326,82 @@ func timePtr(t time.Time) *time.Time {
 }
 
 func (r *BoostConfigRepository) SetCurrentVersion(version string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.currentVersion = version
 }
 
-func (r *BoostConfigRepository) GetBoostState(ctx context.Context, boostKey string) (*storage.BoostActivationState, error) {
-	if state, exists := r.cache[boostKey]; exists {
+// GetBoostState retorna uma cópia do estado; alterações devem passar pelo repositório
+func (r *BoostConfigRepository) GetBoostState(ctx context.Context, boostKey string) (*entities.BoosterActivationState, error) {
+	state, err := r.loadState(ctx, boostKey)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.MapActivationToDomain(&state), nil
+}
+
+// loadState retorna o estado do cache ou, se não estiver lá, do banco
+func (r *BoostConfigRepository) loadState(ctx context.Context, boostKey string) (storage.BoostActivationState, error) {
+	if state, exists := r.cached(boostKey); exists {
 		return state, nil
 	}
 	// Se não está no cache, buscar no banco
 	var state storage.BoostActivationState
-	if err := r.db.Where("id = ?", boostKey).First(&state).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("id = ?", boostKey).First(&state).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return nil, fmt.Errorf("boost %s não encontrado", boostKey)
+			return state, fmt.Errorf("boost %s não encontrado: %w", boostKey, outbound.ErrActivationStateNotFound)
 		}
-		return nil, err
+		return state, err
 	}
-	// Adicionar ao cache
-	r.cache[boostKey] = &state
-	return &state, nil
+
+	// Uma alteração feita enquanto o banco era lido já está no cache e vence
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if cached, exists := r.cache[boostKey]; exists {
+		return cached, nil
+	}
+	r.cache[boostKey] = state
+	delete(r.stale, boostKey)
+	return state, nil
+}
+
+// save grava o estado e só então o coloca no cache. Se a gravação falhar, o
+// estado no banco é incerto e a entrada sai do cache para ser relida.
+func (r *BoostConfigRepository) save(ctx context.Context, state storage.BoostActivationState) error {
+	if err := r.db.WithContext(ctx).Save(&state).Error; err != nil {
+		r.discard(state.ID)
+		return err
+	}
+	r.store(state)
+	return nil
+}
+
+func (r *BoostConfigRepository) cached(boostKey string) (storage.BoostActivationState, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	state, exists := r.cache[boostKey]
+	return state, exists
+}
+
+func (r *BoostConfigRepository) store(state storage.BoostActivationState) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cache[state.ID] = state
+	delete(r.stale, state.ID)
+}
+
+func (r *BoostConfigRepository) evict(boostKey string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.cache, boostKey)
+	delete(r.stale, boostKey)
+}
+
+// discard tira do cache uma entrada cuja gravação falhou e a marca para ser
+// relida do banco
+func (r *BoostConfigRepository) discard(boostKey string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.cache, boostKey)
+	r.stale[boostKey] = true
 }
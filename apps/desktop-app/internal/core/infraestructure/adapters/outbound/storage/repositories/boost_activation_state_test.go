@@ -2,6 +2,9 @@ package storage_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -292,4 +295,113 @@ func TestSetCurrentVersionAndSync(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "2.0.0", st.Version)
 }
-func ptrTime(t time.Time) *time.Time { return &t }
\ No newline at end of file
+func ptrTime(t time.Time) *time.Time { return &t }
+
+// 6) Aplicações e reversões concorrentes (rodar com -race): o cache nunca diverge do banco
+func TestBoostConfigRepository_ConcurrentApplyAndRevert(t *testing.T) {
+	db := setupTestDBForMoreTests(t)
+	// cada conexão de ":memory:" é um banco novo; as goroutines precisam ver as mesmas tabelas
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	ctx := context.Background()
+
+	var boosts []entities.Booster
+	for i := 0; i < 4; i++ {
+		boosts = append(boosts, entities.Booster{ID: fmt.Sprintf("boost-%d", i), Version: "1.0.0"})
+	}
+	repository := repo.NewBoostConfigRepository(db, boosts)
+
+	var wg sync.WaitGroup
+	for _, boost := range boosts {
+		for worker := 0; worker < 3; worker++ {
+			wg.Add(1)
+			go func(id string, worker int) {
+				defer wg.Done()
+				for i := 0; i < 20; i++ {
+					switch (i + worker) % 4 {
+					case 0:
+						_ = repository.ActivateBoost(ctx, id)
+					case 1:
+						_ = repository.DeactivateBoost(ctx, id)
+					default:
+						assert.NoError(t, repository.SetAppliedState(ctx, id, i%2 == 0, ""))
+					}
+				}
+			}(boost.ID, worker)
+		}
+	}
+	// leitores e uma nova sincronização concorrendo com as alterações
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			for _, boost := range boosts {
+				state, err := repository.GetBoostState(ctx, boost.ID)
+				if assert.NoError(t, err) {
+					assert.Equal(t, state.IsApplied, state.Status == entities.StatusActive)
+				}
+				repository.IsBoostActive(ctx, boost.ID)
+			}
+			_, err := repository.GetAllActiveBoosts(ctx)
+			assert.NoError(t, err)
+		}
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.NoError(t, repository.SyncWithAvailableBoosts(ctx, boosts))
+	}()
+	wg.Wait()
+
+	for _, boost := range boosts {
+		var stored storage.BoostActivationState
+		require.NoError(t, db.Where("id = ?", boost.ID).First(&stored).Error)
+		state, err := repository.GetBoostState(ctx, boost.ID)
+		require.NoError(t, err)
+		assert.Equal(t, stored.IsApplied, state.IsApplied, boost.ID)
+		assert.Equal(t, stored.Status, state.Status, boost.ID)
+		assert.Equal(t, stored.IsApplied, repository.IsBoostActive(ctx, boost.ID))
+	}
+}
+
+// 7) GetBoostState retorna cópias: alterar o retorno não altera o cache
+func TestGetBoostState_ReturnsCopy(t *testing.T) {
+	db := setupTestDBForMoreTests(t)
+	ctx := context.Background()
+	repository := repo.NewBoostConfigRepository(db, []entities.Booster{{ID: "b1", Version: "1.0.0"}})
+
+	state, err := repository.GetBoostState(ctx, "b1")
+	require.NoError(t, err)
+	state.IsApplied = true
+	state.Status = entities.StatusActive
+
+	assert.False(t, repository.IsBoostActive(ctx, "b1"))
+	again, err := repository.GetBoostState(ctx, "b1")
+	require.NoError(t, err)
+	assert.Equal(t, entities.StatusInactive, again.Status)
+}
+
+// 8) Uma gravação que falha não some com o boost aplicado da lista de ativos
+func TestGetAllActiveBoosts_ReloadsStateAfterFailedSave(t *testing.T) {
+	db := setupTestDBForMoreTests(t)
+	ctx := context.Background()
+	repository := repo.NewBoostConfigRepository(db, []entities.Booster{{ID: "b1", Version: "1.0.0"}})
+	require.NoError(t, repository.ActivateBoost(ctx, "b1"))
+
+	// a próxima atualização falha antes de chegar ao banco
+	failNext := true
+	require.NoError(t, db.Callback().Update().Before("gorm:update").Register("test:fail_once", func(tx *gorm.DB) {
+		if failNext {
+			failNext = false
+			_ = tx.AddError(errors.New("disk I/O error"))
+		}
+	}))
+	require.Error(t, repository.SetAppliedState(ctx, "b1", true, "falha simulada"))
+
+	active, err := repository.GetAllActiveBoosts(ctx)
+	require.NoError(t, err)
+	require.Contains(t, active, "b1")
+	assert.True(t, active["b1"].IsApplied)
+	assert.True(t, repository.IsBoostActive(ctx, "b1"))
+}
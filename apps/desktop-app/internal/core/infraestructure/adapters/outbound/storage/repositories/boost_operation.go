@@ -75,7 +75,7 @@ func (r *BoostOperationsRepo) GetAll(ctx context.Context) (*[]entities.BoostOper
 	return &domain, nil
 }
 
-func (r *BoostOperationsRepo) GetByStatus(ctx context.Context, status entities.BoosterExecutionStatus) (*[]entities.BoostOperation, error) {
+func (r *BoostOperationsRepo) GetByStatus(ctx context.Context, status entities.OperationStatus) (*[]entities.BoostOperation, error) {
 	var models []storage.BoostOperation
 	if err := r.db.WithContext(ctx).Where("status = ?", status).Find(&models).Error; err != nil {
 		return nil, err
@@ -89,6 +89,60 @@ func (r *BoostOperationsRepo) GetByStatus(ctx context.Context, status entities.B
 	return &domain, nil
 }
 
+// GetByBatchID retorna as operações do lote na ordem em que foram enfileiradas
+func (r *BoostOperationsRepo) GetByBatchID(ctx context.Context, batchID string) ([]entities.BoostOperation, error) {
+	var models []storage.BoostOperation
+	if err := r.db.WithContext(ctx).Where("batch_id = ?", batchID).Order("queued_at asc").Find(&models).Error; err != nil {
+		return nil, err
+	}
+	domain := make([]entities.BoostOperation, 0, len(models))
+	for i := range models {
+		if m := mapper.MapAppliedToDomain(&models[i]); m != nil {
+			domain = append(domain, *m)
+		}
+	}
+	return domain, nil
+}
+
+// Query retorna as operações do filtro, usando a data de submissão para a janela de tempo
+func (r *BoostOperationsRepo) Query(ctx context.Context, filter entities.OperationStatsFilter) ([]entities.BoostOperation, error) {
+	query := r.db.WithContext(ctx)
+	if filter.BoosterID != "" {
+		query = query.Where("booster_id = ?", filter.BoosterID)
+	}
+	if filter.Since != nil {
+		query = query.Where("applied_at >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		query = query.Where("applied_at <= ?", *filter.Until)
+	}
+
+	var models []storage.BoostOperation
+	if err := query.Find(&models).Error; err != nil {
+		return nil, err
+	}
+	domain := make([]entities.BoostOperation, 0, len(models))
+	for i := range models {
+		if m := mapper.MapAppliedToDomain(&models[i]); m != nil {
+			domain = append(domain, *m)
+		}
+	}
+	return domain, nil
+}
+
+// CloseUnfinished encerra as operações que ficaram enfileiradas ou em execução,
+// retornando quantas foram alteradas
+func (r *BoostOperationsRepo) CloseUnfinished(ctx context.Context, status entities.OperationStatus, errMsg string, completedAt time.Time) (int64, error) {
+	res := r.db.WithContext(ctx).Model(&storage.BoostOperation{}).
+		Where("status IN ?", []entities.OperationStatus{entities.OperationQueued, entities.OperationProcessing}).
+		Updates(map[string]interface{}{
+			"status":       status,
+			"error_msg":    errMsg,
+			"completed_at": completedAt,
+		})
+	return res.RowsAffected, res.Error
+}
+
 func (r *BoostOperationsRepo) GetByType(ctx context.Context, operationType entities.BoosterOperationType) (*[]entities.BoostOperation, error) {
 	var models []storage.BoostOperation
 	if err := r.db.WithContext(ctx).Where("type = ?", operationType).Find(&models).Error; err != nil {
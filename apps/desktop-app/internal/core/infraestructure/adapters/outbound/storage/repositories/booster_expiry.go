@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	mapper "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/mapper"
+	storage "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"gorm.io/gorm"
+)
+
+// BoosterExpiryRepo persiste os timers dos boosters aplicados por tempo limitado
+type BoosterExpiryRepo struct {
+	db *gorm.DB
+}
+
+func NewBoosterExpiryRepo(db *gorm.DB) *BoosterExpiryRepo { return &BoosterExpiryRepo{db: db} }
+
+// Save cria ou substitui o timer do booster
+func (r *BoosterExpiryRepo) Save(ctx context.Context, expiry *entities.BoosterExpiry) error {
+	if expiry == nil {
+		return errors.New("nil booster expiry")
+	}
+	model := mapper.MapBoosterExpiryFromDomain(expiry)
+	return r.db.WithContext(ctx).Save(model).Error
+}
+
+func (r *BoosterExpiryRepo) GetByBoosterID(ctx context.Context, boosterID string) (*entities.BoosterExpiry, error) {
+	var model storage.BoosterExpiry
+	err := r.db.WithContext(ctx).First(&model, "booster_id = ?", boosterID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	return mapper.MapBoosterExpiryToDomain(&model), err
+}
+
+// GetAll retorna os timers do que expira primeiro para o último
+func (r *BoosterExpiryRepo) GetAll(ctx context.Context) ([]entities.BoosterExpiry, error) {
+	var models []storage.BoosterExpiry
+	if err := r.db.WithContext(ctx).Order("expires_at asc").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]entities.BoosterExpiry, len(models))
+	for i := range models {
+		result[i] = *mapper.MapBoosterExpiryToDomain(&models[i])
+	}
+	return result, nil
+}
+
+func (r *BoosterExpiryRepo) Delete(ctx context.Context, boosterID string) error {
+	return r.db.WithContext(ctx).Delete(&storage.BoosterExpiry{}, "booster_id = ?", boosterID).Error
+}
+
+// MarkWarned registra que o aviso de expiração próxima já foi emitido
+func (r *BoosterExpiryRepo) MarkWarned(ctx context.Context, boosterID string, warnedAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&storage.BoosterExpiry{}).
+		Where("booster_id = ?", boosterID).
+		Update("warned_at", warnedAt).Error
+}
@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	storage "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// BoosterSettingsRepo persiste os valores dos parâmetros configurados por booster
+type BoosterSettingsRepo struct {
+	db *gorm.DB
+}
+
+func NewBoosterSettingsRepo(db *gorm.DB) *BoosterSettingsRepo { return &BoosterSettingsRepo{db: db} }
+
+// Get retorna os valores salvos do booster; nil se o usuário nunca os alterou
+func (r *BoosterSettingsRepo) Get(ctx context.Context, boosterID string) (entities.BoosterParams, error) {
+	var model storage.BoosterSettings
+	err := r.db.WithContext(ctx).First(&model, "booster_id = ?", boosterID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entities.BoosterParams(model.Params), nil
+}
+
+func (r *BoosterSettingsRepo) Save(ctx context.Context, boosterID string, params entities.BoosterParams) error {
+	if params == nil {
+		params = entities.BoosterParams{}
+	}
+	model := &storage.BoosterSettings{
+		BoosterID: boosterID,
+		Params:    datatypes.JSONMap(params),
+	}
+	return r.db.WithContext(ctx).Save(model).Error
+}
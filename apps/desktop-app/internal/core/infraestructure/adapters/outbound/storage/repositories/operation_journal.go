@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	mapper "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/mapper"
+	storage "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/secrets"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// terminalPhases são as fases que não voltam a mudar
+var terminalPhases = []entities.JournalPhase{
+	entities.JournalStateSaved,
+	entities.JournalFailed,
+	entities.JournalCancelled,
+	entities.JournalRecovered,
+}
+
+// OperationJournalRepo persiste o journal write-ahead da queue de execução
+type OperationJournalRepo struct {
+	db     *gorm.DB
+	cipher *secrets.Cipher
+}
+
+func NewOperationJournalRepo(db *gorm.DB) *OperationJournalRepo { return &OperationJournalRepo{db: db} }
+
+// SetCipher ativa a cifragem do backup_data, como em RollbackRepo
+func (r *OperationJournalRepo) SetCipher(c *secrets.Cipher) {
+	r.cipher = c
+}
+
+func (r *OperationJournalRepo) toDomain(model *storage.OperationJournalEntry) (*entities.JournalEntry, error) {
+	opened, err := openBackup(r.cipher, model.BackupData, storage.JournalBackupAAD(model.OperationID))
+	if err != nil {
+		return nil, err
+	}
+	model.BackupData = opened
+	return mapper.MapJournalToDomain(model), nil
+}
+
+func (r *OperationJournalRepo) Save(ctx context.Context, entry *entities.JournalEntry) error {
+	if entry == nil {
+		return errors.New("nil journal entry")
+	}
+	model := mapper.MapJournalFromDomain(entry)
+	sealed, err := sealBackup(r.cipher, model.BackupData, storage.JournalBackupAAD(model.OperationID))
+	if err != nil {
+		return err
+	}
+	model.BackupData = sealed
+	return r.db.WithContext(ctx).Save(model).Error
+}
+
+func (r *OperationJournalRepo) GetByID(ctx context.Context, operationID string) (*entities.JournalEntry, error) {
+	var model storage.OperationJournalEntry
+	err := r.db.WithContext(ctx).First(&model, "operation_id = ?", operationID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomain(&model)
+}
+
+// UpdatePhase avança a fase da operação; fases terminais não são sobrescritas
+func (r *OperationJournalRepo) UpdatePhase(ctx context.Context, operationID string, phase entities.JournalPhase, errMsg string) error {
+	return r.db.WithContext(ctx).
+		Model(&storage.OperationJournalEntry{}).
+		Where("operation_id = ? AND phase NOT IN ?", operationID, terminalPhases).
+		Updates(map[string]interface{}{
+			"phase": phase,
+			"error": errMsg,
+		}).Error
+}
+
+// RecordExecutorResult grava o resultado do executor antes de o estado ser salvo
+func (r *OperationJournalRepo) RecordExecutorResult(ctx context.Context, operationID string, success bool, message string, backupData entities.BackupData) error {
+	backup := datatypes.JSONMap{}
+	if backupData != nil {
+		backup = datatypes.JSONMap(backupData)
+	}
+	backup, err := sealBackup(r.cipher, backup, storage.JournalBackupAAD(operationID))
+	if err != nil {
+		return err
+	}
+
+	return r.db.WithContext(ctx).
+		Model(&storage.OperationJournalEntry{}).
+		Where("operation_id = ? AND phase NOT IN ?", operationID, terminalPhases).
+		Updates(map[string]interface{}{
+			"phase":       entities.JournalExecutorFinished,
+			"success":     success,
+			"message":     message,
+			"backup_data": backup,
+		}).Error
+}
+
+// GetUnfinished retorna as operações que não chegaram a uma fase terminal, na ordem em que foram enfileiradas
+func (r *OperationJournalRepo) GetUnfinished(ctx context.Context) ([]entities.JournalEntry, error) {
+	var models []storage.OperationJournalEntry
+	err := r.db.WithContext(ctx).
+		Where("phase NOT IN ?", terminalPhases).
+		Order("queued_at asc").
+		Find(&models).Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]entities.JournalEntry, len(models))
+	for i := range models {
+		entry, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *entry
+	}
+	return result, nil
+}
+
+// DeleteFinishedBefore remove as operações terminadas antes do corte
+func (r *OperationJournalRepo) DeleteFinishedBefore(ctx context.Context, cutoff time.Time) error {
+	return r.db.WithContext(ctx).
+		Where("phase IN ? AND updated_at < ?", terminalPhases, cutoff).
+		Delete(&storage.OperationJournalEntry{}).Error
+}
@@ -7,22 +7,56 @@ import (
 
 	mapper "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/mapper"
 	storage "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/secrets"
 	
 	"github.com/oLenador/mulltbost/internal/core/domain/entities"
 	"gorm.io/gorm"
 )
 
 type RollbackRepo struct {
-	db *gorm.DB
+	db     *gorm.DB
+	cipher *secrets.Cipher
 }
 
 func NewRollbackRepo(db *gorm.DB) *RollbackRepo { return &RollbackRepo{db: db} }
 
+// SetCipher ativa a cifragem do backup_data; sem ele o backup é gravado em
+// texto puro e um backup cifrado não pode ser lido
+func (r *RollbackRepo) SetCipher(c *secrets.Cipher) {
+	r.cipher = c
+}
+
+func (r *RollbackRepo) toDomain(model *storage.BoosterRollbackState) (*entities.BoosterRollbackState, error) {
+	opened, err := openBackup(r.cipher, model.BackupData, model.ID)
+	if err != nil {
+		return nil, err
+	}
+	model.BackupData = opened
+	return mapper.MapRollbackToDomain(model), nil
+}
+
+func (r *RollbackRepo) toDomainList(models []storage.BoosterRollbackState) ([]entities.BoosterRollbackState, error) {
+	result := make([]entities.BoosterRollbackState, len(models))
+	for i := range models {
+		state, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *state
+	}
+	return result, nil
+}
+
 func (r *RollbackRepo) Save(ctx context.Context, s *entities.BoosterRollbackState) error {
 	if s == nil {
 		return errors.New("nil rollback state")
 	}
 	model := mapper.MapRollbackFromDomain(s)
+	sealed, err := sealBackup(r.cipher, model.BackupData, model.ID)
+	if err != nil {
+		return err
+	}
+	model.BackupData = sealed
 	return r.db.WithContext(ctx).Save(model).Error
 }
 
@@ -32,7 +66,10 @@ func (r *RollbackRepo) GetByID(ctx context.Context, id string) (*entities.Booste
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
 	}
-	return mapper.MapRollbackToDomain(&model), err
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomain(&model)
 }
 
 func (r *RollbackRepo) GetAll(ctx context.Context) ([]entities.BoosterRollbackState, error) {
@@ -41,12 +78,7 @@ func (r *RollbackRepo) GetAll(ctx context.Context) ([]entities.BoosterRollbackSt
 	if err != nil {
 		return nil, err
 	}
-
-	result := make([]entities.BoosterRollbackState, len(models))
-	for i := range models {
-		result[i] = *mapper.MapRollbackToDomain(&models[i])
-	}
-	return result, nil
+	return r.toDomainList(models)
 }
 
 func (r *RollbackRepo) GetByStatus(ctx context.Context, status entities.BoosterExecutionStatus) ([]entities.BoosterRollbackState, error) {
@@ -58,12 +90,7 @@ func (r *RollbackRepo) GetByStatus(ctx context.Context, status entities.BoosterE
 	if err != nil {
 		return nil, err
 	}
-
-	result := make([]entities.BoosterRollbackState, len(models))
-	for i := range models {
-		result[i] = *mapper.MapRollbackToDomain(&models[i])
-	}
-	return result, nil
+	return r.toDomainList(models)
 }
 
 func (r *RollbackRepo) UpdateStatus(ctx context.Context, id string, status entities.BoosterExecutionStatus, errMsg string) error {
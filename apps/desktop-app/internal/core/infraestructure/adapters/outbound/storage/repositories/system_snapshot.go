@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"errors"
+
+	mapper "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/mapper"
+	storage "github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/models"
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/secrets"
+
+	"github.com/oLenador/mulltbost/internal/core/domain/entities"
+	"gorm.io/gorm"
+)
+
+// SnapshotRepo persiste os snapshots nomeados do estado dos boosters
+type SnapshotRepo struct {
+	db     *gorm.DB
+	cipher *secrets.Cipher
+}
+
+func NewSnapshotRepo(db *gorm.DB) *SnapshotRepo { return &SnapshotRepo{db: db} }
+
+// SetCipher ativa a cifragem dos backups dos boosters, como em RollbackRepo
+func (r *SnapshotRepo) SetCipher(c *secrets.Cipher) {
+	r.cipher = c
+}
+
+func (r *SnapshotRepo) toDomain(model *storage.SystemSnapshot) (*entities.Snapshot, error) {
+	snapshot := mapper.MapSnapshotToDomain(model)
+	for i := range snapshot.Boosters {
+		booster := &snapshot.Boosters[i]
+		opened, err := openBackup(r.cipher, booster.BackupData, storage.SnapshotBackupAAD(snapshot.ID, booster.BoosterID))
+		if err != nil {
+			return nil, err
+		}
+		booster.BackupData = opened
+	}
+	return snapshot, nil
+}
+
+func (r *SnapshotRepo) Save(ctx context.Context, snapshot *entities.Snapshot) error {
+	if snapshot == nil {
+		return errors.New("nil snapshot")
+	}
+	model := mapper.MapSnapshotFromDomain(snapshot)
+	// o mapper reaproveita o slice do chamador, que não deve ver os backups cifrados
+	boosters := make([]entities.SnapshotBooster, len(model.Boosters))
+	for i, booster := range model.Boosters {
+		if booster.BackupData != nil {
+			sealed, err := sealBackup(r.cipher, booster.BackupData, storage.SnapshotBackupAAD(model.ID, booster.BoosterID))
+			if err != nil {
+				return err
+			}
+			booster.BackupData = sealed
+		}
+		boosters[i] = booster
+	}
+	model.Boosters = boosters
+	return r.db.WithContext(ctx).Save(model).Error
+}
+
+func (r *SnapshotRepo) GetByID(ctx context.Context, id string) (*entities.Snapshot, error) {
+	var model storage.SystemSnapshot
+	err := r.db.WithContext(ctx).First(&model, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.toDomain(&model)
+}
+
+// List retorna os snapshots do mais recente para o mais antigo
+func (r *SnapshotRepo) List(ctx context.Context) ([]entities.Snapshot, error) {
+	var models []storage.SystemSnapshot
+	if err := r.db.WithContext(ctx).Order("created_at desc").Find(&models).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]entities.Snapshot, len(models))
+	for i := range models {
+		snapshot, err := r.toDomain(&models[i])
+		if err != nil {
+			return nil, err
+		}
+		result[i] = *snapshot
+	}
+	return result, nil
+}
+
+func (r *SnapshotRepo) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Delete(&storage.SystemSnapshot{}, "id = ?", id).Error
+}
+
+// PruneOrigin mantém apenas os keep snapshots mais recentes com a origem informada
+func (r *SnapshotRepo) PruneOrigin(ctx context.Context, origin entities.SnapshotOrigin, keep int) error {
+	keepIDs := r.db.Model(&storage.SystemSnapshot{}).
+		Select("id").
+		Where("origin = ?", origin).
+		Order("created_at desc").
+		Limit(keep)
+
+	return r.db.WithContext(ctx).
+		Where("origin = ? AND id NOT IN (?)", origin, keepIDs).
+		Delete(&storage.SystemSnapshot{}).Error
+}
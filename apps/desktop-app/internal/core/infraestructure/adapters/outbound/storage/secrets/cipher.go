@@ -0,0 +1,232 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	ErrUnknownKey = errors.New("data was encrypted with a key that is not in the keyset")
+	ErrDecrypt    = errors.New("failed to decrypt data")
+)
+
+const (
+	sealedVersion = "v1"
+	// hkdfInfo separa as chaves derivadas para os backups de outros usos do segredo
+	hkdfInfo = "mulltboost rollback backup v1"
+	// sealedField é o único campo do JSON que guarda um backup cifrado
+	sealedField = "$sealed"
+)
+
+// Cipher cifra com AES-256-GCM usando chaves derivadas do keyset. O texto
+// cifrado tem o formato v1:<id da chave>:<base64 de nonce+dados>, e o aad
+// amarra o dado ao registro de onde veio.
+type Cipher struct {
+	mu    sync.RWMutex
+	keys  *Keyset
+	aeads map[string]cipher.AEAD
+	store KeyStore
+}
+
+// NewCipher cria o cipher do keyset; store recebe o keyset após uma rotação e
+// pode ser nil quando as chaves não mudam
+func NewCipher(ks *Keyset, store KeyStore) (*Cipher, error) {
+	c := &Cipher{store: store}
+	if err := c.use(ks.clone()); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Cipher) use(ks *Keyset) error {
+	if err := ks.validate(); err != nil {
+		return err
+	}
+	aeads := make(map[string]cipher.AEAD, len(ks.Keys))
+	for _, key := range ks.Keys {
+		derived, err := hkdf.Key(sha256.New, key.Secret, nil, hkdfInfo, keySize)
+		if err != nil {
+			return err
+		}
+		block, err := aes.NewCipher(derived)
+		if err != nil {
+			return err
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return err
+		}
+		aeads[key.ID] = aead
+	}
+	c.mu.Lock()
+	c.keys = ks
+	c.aeads = aeads
+	c.mu.Unlock()
+	return nil
+}
+
+// PrimaryKey retorna o ID e a data de criação da chave que cifra os dados novos
+func (c *Cipher) PrimaryKey() (string, time.Time) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	key, _ := c.keys.PrimaryKey()
+	return key.ID, key.CreatedAt
+}
+
+// HasKey diz se o keyset tem a chave, ou seja, se o que foi cifrado com ela pode ser lido
+func (c *Cipher) HasKey(id string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.aeads[id]
+	return ok
+}
+
+func (c *Cipher) Seal(plaintext, aad []byte) (string, error) {
+	c.mu.RLock()
+	id := c.keys.Primary
+	aead := c.aeads[id]
+	c.mu.RUnlock()
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, aad)
+	return sealedVersion + ":" + id + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *Cipher) Open(sealed string, aad []byte) ([]byte, error) {
+	id, payload, err := parseSealed(sealed)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.RLock()
+	aead, ok := c.aeads[id]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: key %s", ErrUnknownKey, id)
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(payload)
+	if err != nil || len(data) < aead.NonceSize() {
+		return nil, ErrDecrypt
+	}
+	plaintext, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], aad)
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+	return plaintext, nil
+}
+
+// KeyID retorna a chave com que o texto foi cifrado
+func KeyID(sealed string) (string, error) {
+	id, _, err := parseSealed(sealed)
+	return id, err
+}
+
+func parseSealed(sealed string) (id, payload string, err error) {
+	parts := strings.SplitN(sealed, ":", 3)
+	if len(parts) != 3 || parts[0] != sealedVersion || parts[1] == "" {
+		return "", "", fmt.Errorf("%w: unrecognized format", ErrDecrypt)
+	}
+	return parts[1], parts[2], nil
+}
+
+// HasOldKeys diz se há chaves além da primária esperando por Retire
+func (c *Cipher) HasOldKeys() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.keys.Keys) > 1
+}
+
+// Rotate cria uma chave primária nova e grava o keyset com as chaves antigas,
+// que continuam decifrando os dados até serem recifrados e removidas por Retire
+func (c *Cipher) Rotate(now time.Time) error {
+	c.mu.RLock()
+	ks := c.keys.clone()
+	c.mu.RUnlock()
+
+	if _, err := ks.Rotate(now); err != nil {
+		return err
+	}
+	return c.save(ks)
+}
+
+// Retire remove as chaves antigas, se houver; só deve ser chamado depois que
+// nenhum dado cifrado com elas restar
+func (c *Cipher) Retire() error {
+	c.mu.RLock()
+	ks := c.keys.clone()
+	c.mu.RUnlock()
+
+	if len(ks.Keys) == 1 {
+		return nil
+	}
+	ks.Retire()
+	return c.save(ks)
+}
+
+// save grava o keyset antes de usá-lo, para que nenhum dado seja cifrado com
+// uma chave que não foi persistida
+func (c *Cipher) save(ks *Keyset) error {
+	if c.store == nil {
+		return fmt.Errorf("cipher has no key store")
+	}
+	if err := c.store.Save(ks); err != nil {
+		return fmt.Errorf("failed to save keys to %s: %w", c.store.Name(), err)
+	}
+	return c.use(ks)
+}
+
+// SealMap cifra um objeto JSON, retornando um objeto cujo único campo é o texto cifrado
+func (c *Cipher) SealMap(data map[string]interface{}, aad string) (map[string]interface{}, error) {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := c.Seal(plaintext, []byte(aad))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{sealedField: sealed}, nil
+}
+
+// OpenMap decifra um objeto gerado por SealMap; um objeto que não está cifrado
+// é retornado como está
+func (c *Cipher) OpenMap(data map[string]interface{}, aad string) (map[string]interface{}, error) {
+	sealed, ok := SealedValue(data)
+	if !ok {
+		return data, nil
+	}
+	plaintext, err := c.Open(sealed, []byte(aad))
+	if err != nil {
+		return nil, err
+	}
+	var opened map[string]interface{}
+	if err := json.Unmarshal(plaintext, &opened); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrDecrypt, err)
+	}
+	return opened, nil
+}
+
+// SealedValue retorna o texto cifrado de um objeto gerado por SealMap
+func SealedValue(data map[string]interface{}) (string, bool) {
+	if len(data) != 1 {
+		return "", false
+	}
+	sealed, ok := data[sealedField].(string)
+	return sealed, ok
+}
@@ -0,0 +1,55 @@
+//go:build linux
+
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// No Linux o chaveiro é o Secret Service (gnome-keyring, KWallet), acessado
+// pelo secret-tool da libsecret. Sem o secret-tool ou sem uma sessão D-Bus o
+// chaveiro é tratado como indisponível.
+
+func keyringGet(service, account string) ([]byte, error) {
+	path, err := exec.LookPath("secret-tool")
+	if err != nil {
+		return nil, ErrKeyringUnavailable
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(path, "lookup", "service", service, "account", account)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		// sem saída de erro, o código 1 só indica que o segredo não existe
+		if errors.As(err, &exitErr) && strings.TrimSpace(stderr.String()) == "" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %s", ErrKeyringUnavailable, strings.TrimSpace(stderr.String()))
+	}
+	data := bytes.TrimSpace(stdout.Bytes())
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return data, nil
+}
+
+func keyringSet(service, account string, data []byte) error {
+	path, err := exec.LookPath("secret-tool")
+	if err != nil {
+		return ErrKeyringUnavailable
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command(path, "store", "--label="+service+" rollback backup keys", "service", service, "account", account)
+	// o segredo vai pela entrada padrão para não aparecer na lista de processos
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("secret-tool store failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
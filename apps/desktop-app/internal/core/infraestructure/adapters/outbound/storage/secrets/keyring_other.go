@@ -0,0 +1,11 @@
+//go:build !linux && !windows
+
+package secrets
+
+func keyringGet(service, account string) ([]byte, error) {
+	return nil, ErrKeyringUnavailable
+}
+
+func keyringSet(service, account string, data []byte) error {
+	return ErrKeyringUnavailable
+}
@@ -0,0 +1,95 @@
+//go:build windows
+
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	advapi32      = windows.NewLazySystemDLL("advapi32.dll")
+	procCredRead  = advapi32.NewProc("CredReadW")
+	procCredWrite = advapi32.NewProc("CredWriteW")
+	procCredFree  = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric          = 1
+	credPersistLocalMachine  = 2
+	credMaxGenericBlobLength = 5 * 512
+)
+
+// credential espelha a CREDENTIALW do wincred.h
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        windows.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// No Windows o chaveiro é o Credential Manager, numa credencial genérica do usuário
+
+func credentialTarget(service, account string) string {
+	return service + "/" + account
+}
+
+func keyringGet(service, account string) ([]byte, error) {
+	if err := procCredRead.Find(); err != nil {
+		return nil, ErrKeyringUnavailable
+	}
+	target, err := windows.UTF16PtrFromString(credentialTarget(service, account))
+	if err != nil {
+		return nil, err
+	}
+	var cred *credential
+	ret, _, callErr := procCredRead.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred)))
+	if ret == 0 {
+		if errors.Is(callErr, windows.ERROR_NOT_FOUND) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: CredReadW: %v", ErrKeyringUnavailable, callErr)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred)))
+
+	blob := unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)
+	data := make([]byte, len(blob))
+	copy(data, blob)
+	return data, nil
+}
+
+func keyringSet(service, account string, data []byte) error {
+	if err := procCredWrite.Find(); err != nil {
+		return ErrKeyringUnavailable
+	}
+	if len(data) == 0 || len(data) > credMaxGenericBlobLength {
+		return fmt.Errorf("keyset of %d bytes does not fit in a credential", len(data))
+	}
+	target, err := windows.UTF16PtrFromString(credentialTarget(service, account))
+	if err != nil {
+		return err
+	}
+	cred := credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(data)),
+		CredentialBlob:     &data[0],
+		Persist:            credPersistLocalMachine,
+	}
+	ret, _, callErr := procCredWrite.Call(uintptr(unsafe.Pointer(&cred)), 0)
+	if ret == 0 {
+		return fmt.Errorf("CredWriteW failed: %v", callErr)
+	}
+	return nil
+}
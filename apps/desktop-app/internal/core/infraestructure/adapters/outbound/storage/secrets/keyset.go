@@ -0,0 +1,112 @@
+// Package secrets guarda as chaves que cifram os backups de rollback e faz a
+// cifragem. As chaves ficam no chaveiro do sistema ou, sem ele, num arquivo
+// legível só pelo usuário.
+package secrets
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+const keySize = 32
+
+// Key é um segredo aleatório; a chave de cifragem é derivada dele
+type Key struct {
+	ID        string    `json:"id"`
+	Secret    []byte    `json:"secret"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Keyset são as chaves em uso. Novos dados são cifrados com a Primary; as
+// demais só decifram o que ainda não foi recifrado após uma rotação.
+type Keyset struct {
+	Primary string `json:"primary"`
+	Keys    []Key  `json:"keys"`
+}
+
+func newKey(now time.Time) (Key, error) {
+	id := make([]byte, 4)
+	secret := make([]byte, keySize)
+	if _, err := rand.Read(id); err != nil {
+		return Key{}, err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return Key{}, err
+	}
+	return Key{ID: hex.EncodeToString(id), Secret: secret, CreatedAt: now.UTC()}, nil
+}
+
+// NewKeyset cria um keyset com uma única chave
+func NewKeyset(now time.Time) (*Keyset, error) {
+	ks := &Keyset{}
+	if _, err := ks.Rotate(now); err != nil {
+		return nil, err
+	}
+	return ks, nil
+}
+
+// Rotate adiciona uma chave nova e a torna a primária
+func (ks *Keyset) Rotate(now time.Time) (Key, error) {
+	key, err := newKey(now)
+	if err != nil {
+		return Key{}, fmt.Errorf("failed to generate key: %w", err)
+	}
+	ks.Keys = append(ks.Keys, key)
+	ks.Primary = key.ID
+	return key, nil
+}
+
+// Retire remove as chaves que não são a primária
+func (ks *Keyset) Retire() {
+	for _, key := range ks.Keys {
+		if key.ID == ks.Primary {
+			ks.Keys = []Key{key}
+			return
+		}
+	}
+}
+
+func (ks *Keyset) Find(id string) (Key, bool) {
+	for _, key := range ks.Keys {
+		if key.ID == id {
+			return key, true
+		}
+	}
+	return Key{}, false
+}
+
+func (ks *Keyset) PrimaryKey() (Key, bool) {
+	return ks.Find(ks.Primary)
+}
+
+// merge acrescenta as chaves de other que ainda não estão em ks, mantendo a primária de ks
+func (ks *Keyset) merge(other *Keyset) {
+	for _, key := range other.Keys {
+		if _, ok := ks.Find(key.ID); !ok {
+			ks.Keys = append(ks.Keys, key)
+		}
+	}
+}
+
+func (ks *Keyset) validate() error {
+	if len(ks.Keys) == 0 {
+		return fmt.Errorf("keyset has no keys")
+	}
+	for _, key := range ks.Keys {
+		if key.ID == "" || len(key.Secret) != keySize {
+			return fmt.Errorf("invalid key %q in keyset", key.ID)
+		}
+	}
+	if _, ok := ks.PrimaryKey(); !ok {
+		return fmt.Errorf("primary key %q is not in the keyset", ks.Primary)
+	}
+	return nil
+}
+
+func (ks *Keyset) clone() *Keyset {
+	cloned := &Keyset{Primary: ks.Primary, Keys: make([]Key, len(ks.Keys))}
+	copy(cloned.Keys, ks.Keys)
+	return cloned
+}
@@ -0,0 +1,220 @@
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/oLenador/mulltbost/internal/config"
+)
+
+var (
+	ErrKeyringUnavailable = errors.New("os keyring is unavailable")
+	ErrNoKeys             = errors.New("no backup keys found")
+)
+
+const (
+	keyringService = config.AppName
+	keyringAccount = "rollback-backup-keys"
+)
+
+// KeyStore guarda o keyset. Load retorna nil sem erro quando ainda não há
+// keyset; um store que não pode ser usado neste sistema retorna ErrKeyringUnavailable.
+type KeyStore interface {
+	Name() string
+	Load() (*Keyset, error)
+	Save(ks *Keyset) error
+}
+
+// KeyFilePath é o arquivo de chaves usado quando não há chaveiro do sistema.
+// Fica fora do diretório de dados para não ir junto com os backups do banco.
+func KeyFilePath() string {
+	return filepath.Join(xdg.ConfigHome, config.AppDir, "backup.key")
+}
+
+// DefaultStores são o chaveiro do sistema e, como alternativa, o arquivo de chaves
+func DefaultStores() []KeyStore {
+	return []KeyStore{NewKeyringStore(), NewFileKeyStore(KeyFilePath())}
+}
+
+// FileKeyStore guarda o keyset num arquivo com permissão 0600. Fora do Windows,
+// um arquivo que outros usuários podem ler é recusado.
+type FileKeyStore struct {
+	path string
+}
+
+func NewFileKeyStore(path string) *FileKeyStore {
+	return &FileKeyStore{path: path}
+}
+
+func (s *FileKeyStore) Name() string { return "file " + s.path }
+
+func (s *FileKeyStore) Load() (*Keyset, error) {
+	info, err := os.Stat(s.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("key file %s must not be accessible by other users (mode %04o)", s.path, info.Mode().Perm())
+	}
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	return decodeKeyset(data)
+}
+
+func (s *FileKeyStore) Save(ks *Keyset) error {
+	data, err := json.Marshal(ks)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	// Grava num temporário e renomeia para não deixar o arquivo pela metade
+	tmp := s.path + ".tmp"
+	if err := os.Remove(tmp); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp, 0o600); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// KeyringStore guarda o keyset no chaveiro do sistema: Secret Service no Linux
+// e Credential Manager no Windows
+type KeyringStore struct {
+	service string
+	account string
+}
+
+func NewKeyringStore() *KeyringStore {
+	return &KeyringStore{service: keyringService, account: keyringAccount}
+}
+
+func (s *KeyringStore) Name() string { return "os keyring" }
+
+func (s *KeyringStore) Load() (*Keyset, error) {
+	data, err := keyringGet(s.service, s.account)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	return decodeKeyset(data)
+}
+
+func (s *KeyringStore) Save(ks *Keyset) error {
+	data, err := json.Marshal(ks)
+	if err != nil {
+		return err
+	}
+	return keyringSet(s.service, s.account, data)
+}
+
+func decodeKeyset(data []byte) (*Keyset, error) {
+	var ks Keyset
+	if err := json.Unmarshal(data, &ks); err != nil {
+		return nil, fmt.Errorf("invalid keyset: %w", err)
+	}
+	if err := ks.validate(); err != nil {
+		return nil, err
+	}
+	return &ks, nil
+}
+
+// Load carrega as chaves dos stores, em ordem de preferência, e retorna o
+// cipher que grava as próximas rotações no primeiro store que aceitar. As chaves
+// de todos os stores disponíveis são unidas: se o chaveiro falhou num boot e a
+// chave foi criada no arquivo, os dados cifrados com as duas continuam legíveis.
+// Sem chave em nenhum store retorna ErrNoKeys; quem chama decide se pode criar
+// um keyset novo com Create.
+func Load(stores ...KeyStore) (*Cipher, error) {
+	var (
+		available []KeyStore
+		keys      *Keyset
+		found     []KeyStore
+	)
+	for _, store := range stores {
+		ks, err := store.Load()
+		if errors.Is(err, ErrKeyringUnavailable) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load keys from %s: %w", store.Name(), err)
+		}
+		available = append(available, store)
+		if ks == nil {
+			continue
+		}
+		found = append(found, store)
+		if keys == nil {
+			keys = ks
+		} else {
+			keys.merge(ks)
+		}
+	}
+	if len(available) == 0 {
+		return nil, fmt.Errorf("no key store available")
+	}
+	if keys == nil {
+		return nil, ErrNoKeys
+	}
+
+	if len(found) == 1 && found[0] == available[0] {
+		return NewCipher(keys, found[0])
+	}
+	// as chaves unidas passam a morar no store preferido que aceitar a gravação
+	store, err := saveFirst(keys, available)
+	if err != nil {
+		return nil, err
+	}
+	return NewCipher(keys, store)
+}
+
+// Create gera um keyset novo e o grava no primeiro store disponível que aceitar
+func Create(now time.Time, stores ...KeyStore) (*Cipher, error) {
+	created, err := NewKeyset(now)
+	if err != nil {
+		return nil, err
+	}
+	store, err := saveFirst(created, stores)
+	if err != nil {
+		return nil, err
+	}
+	return NewCipher(created, store)
+}
+
+// Open é Load seguido de Create quando nenhum store tem chaves. Só serve quando
+// não há dado cifrado que uma chave nova deixaria ilegível.
+func Open(now time.Time, stores ...KeyStore) (*Cipher, error) {
+	c, err := Load(stores...)
+	if errors.Is(err, ErrNoKeys) {
+		return Create(now, stores...)
+	}
+	return c, err
+}
+
+func saveFirst(ks *Keyset, stores []KeyStore) (KeyStore, error) {
+	var errs []error
+	for _, store := range stores {
+		err := store.Save(ks)
+		if err == nil {
+			return store, nil
+		}
+		errs = append(errs, fmt.Errorf("%s: %w", store.Name(), err))
+	}
+	return nil, fmt.Errorf("failed to save keys: %w", errors.Join(errs...))
+}
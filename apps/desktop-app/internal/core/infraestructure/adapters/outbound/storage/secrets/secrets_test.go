@@ -0,0 +1,135 @@
+package secrets_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/oLenador/mulltbost/internal/core/infraestructure/adapters/outbound/storage/secrets"
+)
+
+// fakeStore é um KeyStore em memória; unavailable simula um chaveiro ausente
+type fakeStore struct {
+	name        string
+	keys        *secrets.Keyset
+	unavailable bool
+	saves       int
+}
+
+func (s *fakeStore) Name() string { return s.name }
+
+func (s *fakeStore) Load() (*secrets.Keyset, error) {
+	if s.unavailable {
+		return nil, secrets.ErrKeyringUnavailable
+	}
+	return s.keys, nil
+}
+
+func (s *fakeStore) Save(ks *secrets.Keyset) error {
+	if s.unavailable {
+		return secrets.ErrKeyringUnavailable
+	}
+	s.keys = ks
+	s.saves++
+	return nil
+}
+
+func TestCipher_SealOpenAndRotation(t *testing.T) {
+	store := &fakeStore{name: "keyring"}
+	c, err := secrets.Open(time.Now(), store)
+	require.NoError(t, err)
+
+	sealed, err := c.SealMap(map[string]interface{}{"resolv_conf": "nameserver 10.0.0.53"}, "dns_flush")
+	require.NoError(t, err)
+	value, ok := secrets.SealedValue(sealed)
+	require.True(t, ok)
+	assert.NotContains(t, value, "10.0.0.53")
+
+	opened, err := c.OpenMap(sealed, "dns_flush")
+	require.NoError(t, err)
+	assert.Equal(t, "nameserver 10.0.0.53", opened["resolv_conf"])
+
+	_, err = c.OpenMap(sealed, "tcp_fast_open")
+	assert.ErrorIs(t, err, secrets.ErrDecrypt, "the aad must match")
+
+	plain := map[string]interface{}{"resolv_conf": "legacy"}
+	opened, err = c.OpenMap(plain, "dns_flush")
+	require.NoError(t, err)
+	assert.Equal(t, plain, opened, "unencrypted data is returned as is")
+
+	require.NoError(t, c.Rotate(time.Now()))
+	require.Len(t, store.keys.Keys, 2)
+	opened, err = c.OpenMap(sealed, "dns_flush")
+	require.NoError(t, err, "old keys keep decrypting until retired")
+	assert.Equal(t, "nameserver 10.0.0.53", opened["resolv_conf"])
+
+	require.NoError(t, c.Retire())
+	require.Len(t, store.keys.Keys, 1)
+	_, err = c.OpenMap(sealed, "dns_flush")
+	assert.ErrorIs(t, err, secrets.ErrUnknownKey)
+}
+
+func TestOpen_FallsBackAndMergesKeysFromEveryStore(t *testing.T) {
+	keyring := &fakeStore{name: "keyring", unavailable: true}
+	file := &fakeStore{name: "file"}
+
+	// sem chaveiro, a chave é criada no arquivo
+	fromFile, err := secrets.Open(time.Now(), keyring, file)
+	require.NoError(t, err)
+	require.NotNil(t, file.keys)
+	sealed, err := fromFile.Seal([]byte("secret"), nil)
+	require.NoError(t, err)
+
+	// com o chaveiro de volta e já com uma chave, as duas são unidas nele
+	existing, err := secrets.NewKeyset(time.Now())
+	require.NoError(t, err)
+	keyring.unavailable = false
+	keyring.keys = existing
+
+	merged, err := secrets.Open(time.Now(), keyring, file)
+	require.NoError(t, err)
+	require.Len(t, keyring.keys.Keys, 2)
+	assert.Equal(t, existing.Primary, keyring.keys.Primary)
+
+	plaintext, err := merged.Open(sealed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", string(plaintext))
+
+	saves := keyring.saves
+	_, err = secrets.Open(time.Now(), keyring, &fakeStore{name: "empty file"})
+	require.NoError(t, err)
+	assert.Equal(t, saves, keyring.saves, "keys already in the preferred store are not rewritten")
+}
+
+func TestFileKeyStore_UsesPrivatePermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config", "backup.key")
+	store := secrets.NewFileKeyStore(path)
+
+	ks, err := store.Load()
+	require.NoError(t, err)
+	assert.Nil(t, ks)
+
+	created, err := secrets.NewKeyset(time.Now())
+	require.NoError(t, err)
+	require.NoError(t, store.Save(created))
+
+	loaded, err := store.Load()
+	require.NoError(t, err)
+	assert.Equal(t, created.Primary, loaded.Primary)
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	require.NoError(t, os.Chmod(path, 0o644))
+	_, err = store.Load()
+	assert.Error(t, err, "a key file readable by other users must be refused")
+}
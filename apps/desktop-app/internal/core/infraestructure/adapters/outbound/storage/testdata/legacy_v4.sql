@@ -0,0 +1,28 @@
+PRAGMA foreign_keys=OFF;
+BEGIN TRANSACTION;
+CREATE TABLE `rollback_states` (`id` text,`applied` numeric NOT NULL DEFAULT false,`applied_at` datetime,`reverted_at` datetime,`version` text NOT NULL,`backup_data` JSON NOT NULL DEFAULT '{}',`status` text NOT NULL,`error_msg` text,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`));
+INSERT INTO rollback_states VALUES('dns_flush',1,'2025-01-10 10:00:00',NULL,'1.0.0','{"resolv_conf":"nameserver 1.1.1.1"}','applied','','2025-01-10 10:00:00','2025-01-10 10:00:00');
+CREATE TABLE `boosts_operations` (`id` text,`user_id` text,`booster_id` text,`version` text,`applied_at` datetime NOT NULL,`reverted_at` datetime,`status` text,`error_msg` text,`type` text,`attempts` integer NOT NULL DEFAULT 1,`batch_id` text,`message` text,`queued_at` datetime,`started_at` datetime,`completed_at` datetime,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`));
+INSERT INTO boosts_operations VALUES('op-1','','dns_flush','1.0.0','2025-01-10 10:00:00',NULL,'','','apply',1,NULL,NULL,NULL,NULL,NULL,'2025-01-10 10:00:00','2025-01-10 10:00:00');
+CREATE TABLE `boost_activation_states` (`id` text,`is_applied` numeric NOT NULL DEFAULT false,`applied_at` datetime,`reverted_at` datetime,`version` text NOT NULL,`status` text NOT NULL,`error_message` text,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`id`));
+INSERT INTO boost_activation_states VALUES('dns_flush',1,'2025-01-10 10:00:00',NULL,'1.0.0','applied','','2025-01-10 10:00:00','2025-01-10 10:00:00');
+CREATE TABLE `operation_journal` (`operation_id` text,`booster_id` text NOT NULL,`operation` text NOT NULL,`batch_id` text,`depends_on` text,`phase` text NOT NULL,`success` numeric NOT NULL DEFAULT false,`message` text,`backup_data` JSON NOT NULL DEFAULT '{}',`error` text,`queued_at` datetime NOT NULL,`created_at` datetime,`updated_at` datetime,PRIMARY KEY (`operation_id`));
+INSERT INTO operation_journal VALUES('op-1','dns_flush','apply','','','state_saved',1,'','{"resolv_conf":"nameserver 1.1.1.1"}','','2025-01-10 10:00:00','2025-01-10 10:00:00','2025-01-10 10:00:00');
+CREATE INDEX `idx_rollback_states_status` ON `rollback_states`(`status`);
+CREATE INDEX `idx_rollback_states_reverted_at` ON `rollback_states`(`reverted_at`);
+CREATE INDEX `idx_rollback_states_applied_at` ON `rollback_states`(`applied_at`);
+CREATE INDEX `idx_boosts_operations_queued_at` ON `boosts_operations`(`queued_at`);
+CREATE INDEX `idx_boosts_operations_batch_id` ON `boosts_operations`(`batch_id`);
+CREATE INDEX `idx_boosts_operations_status` ON `boosts_operations`(`status`);
+CREATE INDEX `idx_boosts_operations_reverted_at` ON `boosts_operations`(`reverted_at`);
+CREATE INDEX `idx_boosts_operations_applied_at` ON `boosts_operations`(`applied_at`);
+CREATE INDEX `idx_boosts_operations_booster_id` ON `boosts_operations`(`booster_id`);
+CREATE INDEX `idx_boosts_operations_user_id` ON `boosts_operations`(`user_id`);
+CREATE INDEX `idx_boost_activation_states_status` ON `boost_activation_states`(`status`);
+CREATE INDEX `idx_boost_activation_states_reverted_at` ON `boost_activation_states`(`reverted_at`);
+CREATE INDEX `idx_boost_activation_states_applied_at` ON `boost_activation_states`(`applied_at`);
+CREATE INDEX `idx_operation_journal_queued_at` ON `operation_journal`(`queued_at`);
+CREATE INDEX `idx_operation_journal_phase` ON `operation_journal`(`phase`);
+CREATE INDEX `idx_operation_journal_batch_id` ON `operation_journal`(`batch_id`);
+CREATE INDEX `idx_operation_journal_booster_id` ON `operation_journal`(`booster_id`);
+COMMIT;